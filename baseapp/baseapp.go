@@ -3,8 +3,10 @@ package baseapp
 import (
 	"fmt"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -25,6 +27,12 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Key to store the consensus params in the main store.
+var mainConsensusParamsKey = []byte("consensus_params")
+
+// Descriptor used when charging a tx's gas to the block gas meter.
+const blockGasDescriptor = "block gas meter"
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -40,13 +48,14 @@ const (
 // The ABCI application
 type BaseApp struct {
 	// initialized on creation
-	Logger     log.Logger
-	name       string               // application name from abci.Info
-	cdc        *wire.Codec          // Amino codec
-	db         dbm.DB               // common DB backend
-	cms        sdk.CommitMultiStore // Main (uncached) state
-	router     Router               // handle any kind of message
-	codespacer *sdk.Codespacer      // handle module codespacing
+	Logger      log.Logger
+	name        string               // application name from abci.Info
+	cdc         *wire.Codec          // Amino codec
+	db          dbm.DB               // common DB backend
+	cms         sdk.CommitMultiStore // Main (uncached) state
+	router      Router               // handle any kind of message
+	queryRouter QueryRouter          // router for redirecting query calls
+	codespacer  *sdk.Codespacer      // handle module codespacing
 
 	// must be set
 	txDecoder   sdk.TxDecoder   // unmarshal []byte into sdk.Tx
@@ -59,6 +68,13 @@ type BaseApp struct {
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
 
+	// may be nil, takes the state snapshots
+	snapshotManager *store.SnapshotManager
+
+	// set on load, and in InitChain for the consensus params
+	baseKey         sdk.StoreKey          // main KVStore in cms
+	consensusParams *abci.ConsensusParams // block limits set by Tendermint on genesis
+
 	//--------------------
 	// Volatile
 	// checkState is set on initialization and reset on Commit.
@@ -75,14 +91,15 @@ var _ abci.Application = (*BaseApp)(nil)
 // NOTE: The db is used to store the version number for now.
 func NewBaseApp(name string, cdc *wire.Codec, logger log.Logger, db dbm.DB) *BaseApp {
 	app := &BaseApp{
-		Logger:     logger,
-		name:       name,
-		cdc:        cdc,
-		db:         db,
-		cms:        store.NewCommitMultiStore(db),
-		router:     NewRouter(),
-		codespacer: sdk.NewCodespacer(),
-		txDecoder:  defaultTxDecoder(cdc),
+		Logger:      logger,
+		name:        name,
+		cdc:         cdc,
+		db:          db,
+		cms:         store.NewCommitMultiStore(db),
+		router:      NewRouter(),
+		queryRouter: NewQueryRouter(),
+		codespacer:  sdk.NewCodespacer(),
+		txDecoder:   defaultTxDecoder(cdc),
 	}
 	// Register the undefined & root codespaces, which should not be used by any modules
 	app.codespacer.RegisterOrPanic(sdk.CodespaceRoot)
@@ -106,6 +123,13 @@ func (app *BaseApp) MountStoresIAVL(keys ...*sdk.KVStoreKey) {
 	}
 }
 
+// Mount transient stores to the provided keys in the BaseApp multistore
+func (app *BaseApp) MountStoresTransient(keys ...*sdk.TransientStoreKey) {
+	for _, key := range keys {
+		app.MountStore(key, sdk.StoreTypeTransient)
+	}
+}
+
 // Mount a store to the provided key in the BaseApp multistore, using a specified DB
 func (app *BaseApp) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db dbm.DB) {
 	app.cms.MountStoreWithDB(key, typ, db)
@@ -116,6 +140,24 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
+// Set which versions of the IAVL stores are kept, applied to all the mounted
+// IAVL stores
+func (app *BaseApp) SetPruning(pruning sdk.PruningOptions) {
+	app.cms.SetPruning(pruning)
+}
+
+// Set the directory of the state snapshots and how often they are taken
+func (app *BaseApp) SetSnapshotOptions(dir string, options store.SnapshotOptions) {
+	app.snapshotManager = store.NewSnapshotManager(app.cms, dir, options)
+	app.snapshotManager.SetLogger(app.Logger.With("module", "snapshots"))
+}
+
+// SnapshotManager returns the manager of the state snapshots, nil if the
+// snapshot options weren't set
+func (app *BaseApp) SnapshotManager() *store.SnapshotManager {
+	return app.snapshotManager
+}
+
 // Set the txDecoder function
 func (app *BaseApp) SetTxDecoder(txDecoder sdk.TxDecoder) {
 	app.txDecoder = txDecoder
@@ -159,7 +201,8 @@ func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	app.pubkeyPeerFilter = pf
 }
-func (app *BaseApp) Router() Router { return app.router }
+func (app *BaseApp) Router() Router           { return app.router }
+func (app *BaseApp) QueryRouter() QueryRouter { return app.queryRouter }
 
 // load latest application version
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
@@ -198,6 +241,18 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	if main == nil {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
+	app.baseKey = mainKey
+
+	// load the consensus params persisted on InitChain, if any
+	consensusParamsBz := main.Get(mainConsensusParamsKey)
+	if consensusParamsBz != nil {
+		var consensusParams = &abci.ConsensusParams{}
+		err := proto.Unmarshal(consensusParamsBz, consensusParams)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse consensus params")
+		}
+		app.consensusParams = consensusParams
+	}
 
 	// XXX: Do we really need the header? What does it have that we want
 	// here that's not already in the CommitID ? If an app wants to have it,
@@ -232,6 +287,14 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	return nil
 }
 
+// the maximum gas all the txs of a block may consume, 0 if unlimited
+func (app *BaseApp) MaxBlockGas() int64 {
+	if app.consensusParams == nil || app.consensusParams.BlockSize == nil {
+		return 0
+	}
+	return app.consensusParams.BlockSize.MaxGas
+}
+
 // NewContext returns a new Context with the correct store, the given header, and nil txBytes.
 func (app *BaseApp) NewContext(isCheckTx bool, header abci.Header) sdk.Context {
 	if isCheckTx {
@@ -293,6 +356,19 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 	app.setDeliverState(abci.Header{ChainID: req.ChainId})
 	app.setCheckState(abci.Header{ChainID: req.ChainId})
 
+	// Persist the consensus params, they are only sent on genesis.
+	// Written to the deliverState so they are committed with the first block.
+	if req.ConsensusParams != nil {
+		app.consensusParams = req.ConsensusParams
+		if app.baseKey != nil {
+			bz, err := proto.Marshal(req.ConsensusParams)
+			if err != nil {
+				panic(err)
+			}
+			app.deliverState.ms.GetKVStore(app.baseKey).Set(mainConsensusParamsKey, bz)
+		}
+	}
+
 	if app.initChainer == nil {
 		return
 	}
@@ -344,6 +420,11 @@ func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 				Code:  uint32(sdk.ABCICodeOK),
 				Value: []byte(version.GetVersion()),
 			}
+		case "max_block_gas":
+			return abci.ResponseQuery{
+				Code:  uint32(sdk.ABCICodeOK),
+				Value: []byte(strconv.FormatInt(app.MaxBlockGas(), 10)),
+			}
 		default:
 			result = sdk.ErrUnknownRequest(fmt.Sprintf("Unknown query: %s", path)).Result()
 		}
@@ -363,6 +444,10 @@ func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		req.Path = "/" + strings.Join(path[1:], "/")
 		return queryable.Query(req)
 	}
+	// "/custom" prefix for keeper queries
+	if len(path) >= 1 && path[0] == "custom" {
+		return app.handleQueryCustom(path, req)
+	}
 	// "/p2p" prefix for p2p queries
 	if len(path) >= 4 && path[0] == "p2p" {
 		if path[1] == "filter" {
@@ -378,6 +463,53 @@ func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return sdk.ErrUnknownRequest(msg).QueryResult()
 }
 
+// handleQueryCustom routes "/custom/<module>/<path...>" to the Querier
+// registered for <module>, with a context on the state at req.Height.
+func (app *BaseApp) handleQueryCustom(path []string, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// path[0] is "custom", the queryRouter routes using path[1].
+	// For example, in the path "custom/gov/proposal", queryRouter routes using "gov"
+	if len(path) < 2 || path[1] == "" {
+		return sdk.ErrUnknownRequest("No route for custom query specified").QueryResult()
+	}
+	querier := app.queryRouter.Route(path[1])
+	if querier == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
+	}
+
+	// default to the latest committed state
+	height := req.Height
+	if height == 0 {
+		height = app.LastBlockHeight()
+	}
+	cacheMS, err := app.cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		msg := fmt.Sprintf("failed to load state at height %d: %v", height, err)
+		return sdk.ErrInternal(msg).QueryResult()
+	}
+
+	header := abci.Header{Height: height}
+	if app.checkState != nil {
+		header.ChainID = app.checkState.ctx.ChainID()
+	}
+	ctx := sdk.NewContext(cacheMS, header, true, app.Logger)
+
+	// Passes the rest of the path as an argument to the querier.
+	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
+	resBytes, queryErr := querier(ctx, path[2:], req)
+	if queryErr != nil {
+		return abci.ResponseQuery{
+			Code:   uint32(queryErr.ABCICode()),
+			Log:    queryErr.ABCILog(),
+			Height: height,
+		}
+	}
+	return abci.ResponseQuery{
+		Code:   uint32(sdk.ABCICodeOK),
+		Value:  resBytes,
+		Height: height,
+	}
+}
+
 // Implements ABCI
 func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
 	// Initialize the DeliverTx state.
@@ -392,6 +524,16 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		// by InitChain. Context is now updated with Header information.
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header)
 	}
+
+	// Reset the block gas meter, txs are rejected once it is used up
+	var blockGasMeter sdk.GasMeter
+	if maxGas := app.MaxBlockGas(); maxGas > 0 {
+		blockGasMeter = sdk.NewGasMeter(maxGas)
+	} else {
+		blockGasMeter = sdk.NewInfiniteGasMeter()
+	}
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(blockGasMeter)
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
 	}
@@ -468,12 +610,23 @@ func (app *BaseApp) Deliver(tx sdk.Tx) (result sdk.Result) {
 // txBytes may be nil in some cases, eg. in tests.
 // Also, in the future we may support "internal" transactions.
 func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result) {
+	// Declared upfront so the deferred functions can access the gas meters.
+	var ctx sdk.Context
+	var blockGasCharged bool
+
 	// Handle any panics.
 	defer func() {
 		if r := recover(); r != nil {
 			switch r.(type) {
 			case sdk.ErrorOutOfGas:
-				log := fmt.Sprintf("out of gas in location: %v", r.(sdk.ErrorOutOfGas).Descriptor)
+				descriptor := r.(sdk.ErrorOutOfGas).Descriptor
+				if descriptor == blockGasDescriptor {
+					log := fmt.Sprintf("block gas limit of %d exceeded", ctx.BlockGasMeter().Limit())
+					result = sdk.ErrOutOfBlockGas(log).Result()
+					result.GasUsed = ctx.GasMeter().GasConsumed()
+					return
+				}
+				log := fmt.Sprintf("out of gas in location: %v", descriptor)
 				result = sdk.ErrOutOfGas(log).Result()
 			default:
 				log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
@@ -482,6 +635,16 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 	}()
 
+	// Charge the block for the gas used by a tx which didn't get to write its
+	// state. NOTE: this must be deferred after the recover above, so that a
+	// panic from exhausting the block gas meter gets handled there.
+	defer func() {
+		if mode == runTxModeDeliver && !blockGasCharged && !ctx.IsZero() {
+			blockGasCharged = true
+			ctx.BlockGasMeter().ConsumeGas(ctx.GasMeter().GasConsumed(), blockGasDescriptor)
+		}
+	}()
+
 	// Get the Msg.
 	var msgs = tx.GetMsgs()
 	if msgs == nil || len(msgs) == 0 {
@@ -498,14 +661,24 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	}
 
 	// Get the context
-	var ctx sdk.Context
 	if mode == runTxModeCheck || mode == runTxModeSimulate {
 		ctx = app.checkState.ctx.WithTxBytes(txBytes)
 	} else {
 		ctx = app.deliverState.ctx.WithTxBytes(txBytes)
 		ctx = ctx.WithSigningValidators(app.signedValidators)
+
+		// Don't run anything once the block gas limit has been reached, the
+		// tx used no gas so there is nothing to charge
+		if ctx.BlockGasMeter().IsOutOfGas() {
+			blockGasCharged = true
+			log := fmt.Sprintf("block gas limit of %d reached", ctx.BlockGasMeter().Limit())
+			return sdk.ErrOutOfBlockGas(log).Result()
+		}
 	}
 
+	// Meter the gas of each tx separately, the ante handler may set a limit
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
 	// Simulate a DeliverTx for gas calculation
 	if mode == runTxModeSimulate {
 		ctx = ctx.WithIsCheckTx(false)
@@ -514,12 +687,15 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// Run the ante handler.
 	if app.anteHandler != nil {
 		newCtx, result, abort := app.anteHandler(ctx, tx)
-		if abort {
-			return result
-		}
+		// keep the gas meter of the ante handler even if it aborts, so that
+		// the block is charged for its gas
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
+		if abort {
+			result.GasUsed = ctx.GasMeter().GasConsumed()
+			return result
+		}
 	}
 
 	// Get the correct cache
@@ -572,6 +748,13 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 	}
 
+	// Charge the block for the tx gas before writing any state, this panics
+	// if the block gas limit is exceeded in which case the tx is reverted.
+	if mode == runTxModeDeliver {
+		blockGasCharged = true
+		ctx.BlockGasMeter().ConsumeGas(ctx.GasMeter().GasConsumed(), blockGasDescriptor)
+	}
+
 	// If not a simulated run and result was successful, write to app.checkState.ms or app.deliverState.ms
 	// Only update state if all messages pass.
 	if mode != runTxModeSimulate && result.IsOK() {
@@ -611,6 +794,15 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 		"commit", commitID,
 	)
 
+	// The snapshot is taken in the background, a failed snapshot doesn't
+	// stop the chain
+	if app.snapshotManager != nil {
+		err := app.snapshotManager.SnapshotIfDue(commitID.Version)
+		if err != nil {
+			app.Logger.Error("Failed to start a state snapshot", "height", commitID.Version, "err", err)
+		}
+	}
+
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
@@ -42,11 +42,13 @@ func TestMountStores(t *testing.T) {
 	// make some cap keys
 	capKey1 := sdk.NewKVStoreKey("key1")
 	capKey2 := sdk.NewKVStoreKey("key2")
+	transientKey := sdk.NewTransientStoreKey("transient")
 
 	// no stores are mounted
 	require.Panics(t, func() { app.LoadLatestVersion(capKey1) })
 
 	app.MountStoresIAVL(capKey1, capKey2)
+	app.MountStoresTransient(transientKey)
 
 	// stores are mounted
 	err := app.LoadLatestVersion(capKey1)
@@ -57,6 +59,8 @@ func TestMountStores(t *testing.T) {
 	require.NotNil(t, store1)
 	store2 := app.cms.GetCommitKVStore(capKey2)
 	require.NotNil(t, store2)
+	transientStore := app.cms.GetCommitKVStore(transientKey)
+	require.Equal(t, sdk.StoreTypeTransient, transientStore.GetStoreType())
 }
 
 // Test that we can make commits and then reload old versions.
@@ -466,6 +470,157 @@ func TestTxGasLimits(t *testing.T) {
 	app.Commit()
 }
 
+// Test that txs are rejected once the block gas limit is reached,
+// and that the limit survives a restart
+func TestMaxBlockGasLimits(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	name := t.Name()
+	app := NewBaseApp(name, nil, logger, db)
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	counterKey := []byte("counter")
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(100))
+		return
+	})
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(10, "counter")
+		store := ctx.KVStore(capKey)
+		store.Set(counterKey, append(store.Get(counterKey), 0x01))
+		return sdk.Result{}
+	})
+
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{MaxGas: 25},
+		},
+	})
+	require.Equal(t, int64(25), app.MaxBlockGas())
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	header := abci.Header{AppHash: []byte("apphash")}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	// the first two txs fit in the block and report their own gas
+	for i := 0; i < 2; i++ {
+		res := app.Deliver(tx)
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+		require.Equal(t, int64(10), res.GasUsed)
+	}
+
+	// the third one exceeds the limit and is reverted
+	res := app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfBlockGas), res.Code)
+	require.Equal(t, int64(10), res.GasUsed)
+
+	// from there on txs aren't run at all
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfBlockGas), res.Code)
+	require.Equal(t, int64(0), res.GasUsed)
+
+	store := app.deliverState.ctx.KVStore(capKey)
+	require.Equal(t, []byte{0x01, 0x01}, store.Get(counterKey))
+
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the block gas meter is reset on the next block
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	res = app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the limit is queryable
+	query := abci.RequestQuery{Path: "/app/max_block_gas"}
+	queryRes := app.Query(query)
+	require.Equal(t, []byte("25"), queryRes.Value)
+
+	// and reloaded from the store on restart
+	app = NewBaseApp(name, nil, logger, db)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	require.Equal(t, int64(25), app.MaxBlockGas())
+}
+
+// Test that the block is charged for the gas of an aborted ante handler
+func TestBlockGasAnteAbort(t *testing.T) {
+	app := newBaseApp(t.Name())
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(100))
+		newCtx.GasMeter().ConsumeGas(15, "signature")
+		return newCtx, sdk.ErrUnauthorized("signature verification failed").Result(), true
+	})
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{MaxGas: 25},
+		},
+	})
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{AppHash: []byte("apphash")}})
+	res := app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Equal(t, int64(15), res.GasUsed)
+	require.Equal(t, sdk.Gas(15), app.deliverState.ctx.BlockGasMeter().GasConsumed())
+
+	// the second one exhausts the block gas
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfBlockGas), res.Code)
+
+	// the next ones don't run and don't charge anything
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfBlockGas), res.Code)
+	require.Equal(t, int64(0), res.GasUsed)
+}
+
+// Test that the block is charged for the gas of an ante handler running
+// out of gas
+func TestBlockGasAnteOutOfGas(t *testing.T) {
+	app := newBaseApp(t.Name())
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	setUpGasMeter := auth.NewSetUpGasMeterDecorator()
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (sdk.Context, sdk.Result, bool) {
+		return setUpGasMeter.AnteHandle(ctx, tx, func(ctx sdk.Context, tx sdk.Tx) (sdk.Context, sdk.Result, bool) {
+			ctx.GasMeter().ConsumeGas(15, "signature")
+			return ctx, sdk.Result{}, false
+		})
+	})
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{MaxGas: 25},
+		},
+	})
+
+	msg := testBurnMsg{makePrivKey("my secret").PubKey().Address(), nil}
+	tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{Gas: 10}, nil, "")
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{AppHash: []byte("apphash")}})
+	res := app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	require.Equal(t, int64(15), res.GasUsed)
+	require.Equal(t, sdk.Gas(15), app.deliverState.ctx.BlockGasMeter().GasConsumed())
+
+	// the second one exhausts the block gas
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfBlockGas), res.Code)
+}
+
 // Test that we can only query from the latest committed state.
 func TestQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -513,6 +668,82 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+// Test that custom queries are routed to the registered querier and can
+// be served from past committed state.
+func TestCustomQuery(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	key := []byte("hello")
+	var value []byte
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		store := ctx.KVStore(capKey)
+		store.Set(key, value)
+		return sdk.Result{}
+	})
+	app.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) != 1 || path[0] != "value" {
+			return nil, sdk.ErrUnknownRequest("unknown test query")
+		}
+		return ctx.KVStore(capKey).Get(req.Data), nil
+	})
+
+	query := abci.RequestQuery{
+		Path: "/custom/test/value",
+		Data: key,
+	}
+
+	// query is empty before we do anything
+	res := app.Query(query)
+	require.Equal(t, sdk.ABCICodeOK, sdk.ABCICodeType(res.Code), res.Log)
+	require.Equal(t, 0, len(res.Value))
+
+	// commit two blocks each writing a different value
+	tx := testUpdatePowerTx{} // doesn't matter
+	for _, v := range []string{"first", "second"} {
+		value = []byte(v)
+		app.BeginBlock(abci.RequestBeginBlock{})
+		app.Deliver(tx)
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// query defaults to the latest committed state
+	res = app.Query(query)
+	require.Equal(t, sdk.ABCICodeOK, sdk.ABCICodeType(res.Code), res.Log)
+	require.Equal(t, []byte("second"), res.Value)
+	require.Equal(t, int64(2), res.Height)
+
+	// query at a past height
+	query.Height = 1
+	res = app.Query(query)
+	require.Equal(t, sdk.ABCICodeOK, sdk.ABCICodeType(res.Code), res.Log)
+	require.Equal(t, []byte("first"), res.Value)
+	require.Equal(t, int64(1), res.Height)
+
+	// query at a height which doesn't exist yet
+	query.Height = 3
+	res = app.Query(query)
+	require.NotEqual(t, sdk.ABCICodeOK, sdk.ABCICodeType(res.Code))
+
+	// querier errors are returned
+	query = abci.RequestQuery{Path: "/custom/test/other"}
+	res = app.Query(query)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.ABCICodeType(res.Code))
+
+	// unknown route
+	query = abci.RequestQuery{Path: "/custom/nonexistent/value"}
+	res = app.Query(query)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.ABCICodeType(res.Code))
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -0,0 +1,42 @@
+package baseapp
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueryRouter provides queryables for each query path.
+type QueryRouter interface {
+	AddRoute(r string, q sdk.Querier) (rtr QueryRouter)
+	Route(path string) (q sdk.Querier)
+}
+
+type queryRouter struct {
+	routes map[string]sdk.Querier
+}
+
+// nolint
+// NewQueryRouter - create new queryRouter
+// TODO either make Function unexported or make return type (router) Exported
+func NewQueryRouter() *queryRouter {
+	return &queryRouter{
+		routes: map[string]sdk.Querier{},
+	}
+}
+
+// AddRoute - add a querier for the given module route. Panics on a
+// non-alphanumeric route or on a duplicate registration.
+func (qrt *queryRouter) AddRoute(r string, q sdk.Querier) QueryRouter {
+	if !isAlpha(r) {
+		panic("route expressions can only contain alphanumeric characters")
+	}
+	if qrt.routes[r] != nil {
+		panic("route has already been initialized")
+	}
+	qrt.routes[r] = q
+	return qrt
+}
+
+// Route - return the querier registered for a module route, or nil
+func (qrt *queryRouter) Route(path string) sdk.Querier {
+	return qrt.routes[path]
+}
@@ -0,0 +1,46 @@
+package context
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/lite"
+	tmliteproxy "github.com/tendermint/tendermint/lite/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// NewCertifiedHeaderSource returns a source of the headers of the node
+// certified by the Tendermint light client, which follows the validator set
+// changes of the chain from its commits. The trusted commits are kept in the
+// directory, the first one is the latest commit of the node when the first
+// header is requested.
+func NewCertifiedHeaderSource(chainID, dir, nodeURI string) HeaderSource {
+	node := rpcclient.NewHTTP(nodeURI, "/websocket")
+	var (
+		mtx  sync.Mutex
+		cert *lite.InquiringCertifier
+	)
+	return func(height int64) (*tmtypes.Header, error) {
+		if chainID == "" {
+			return nil, errors.New("chain ID required to certify the headers but not specified")
+		}
+
+		// the certifier updates its trusted validator set
+		mtx.Lock()
+		defer mtx.Unlock()
+		if cert == nil {
+			c, err := tmliteproxy.GetCertifier(chainID, dir, nodeURI)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create the light client certifier")
+			}
+			cert = c
+		}
+		commit, err := tmliteproxy.GetCertifiedCommit(height, node, cert)
+		if err != nil {
+			return nil, err
+		}
+		return commit.Header, nil
+	}
+}
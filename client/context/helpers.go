@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -65,27 +67,103 @@ func (ctx CoreContext) Query(path string) (res []byte, err error) {
 	return ctx.query(path, nil)
 }
 
+// QueryWithData queries a module-defined custom route, e.g.
+// "custom/stake/validatorDelegations", passing data as the request params
+func (ctx CoreContext) QueryWithData(path string, data []byte) (res []byte, err error) {
+	return ctx.query(path, data)
+}
+
 // QueryStore from Tendermint with the provided key and storename
 func (ctx CoreContext) QueryStore(key cmn.HexBytes, storeName string) (res []byte, err error) {
 	return ctx.queryStore(key, storeName, "key")
 }
 
 // Query from Tendermint with the provided storename and subspace
+// All the pages of the subspace are queried at the height of the first one.
 func (ctx CoreContext) QuerySubspace(cdc *wire.Codec, subspace []byte, storeName string) (res []sdk.KVPair, err error) {
-	resRaw, err := ctx.queryStore(subspace, storeName, "subspace")
+	var startKey []byte
+	for {
+		result, height, err := ctx.querySubspacePage(cdc, subspace, startKey, 0, storeName)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, result.KVs...)
+		if len(result.NextKey) == 0 {
+			return res, nil
+		}
+		ctx = ctx.WithHeight(height)
+		startKey = result.NextKey
+	}
+}
+
+// QuerySubspacePage queries at most limit pairs of the subspace from the
+// start key, the node caps the limit. The returned next key is the start key
+// of the next page, nil on the last page.
+func (ctx CoreContext) QuerySubspacePage(cdc *wire.Codec, subspace, startKey []byte, limit int, storeName string) (res []sdk.KVPair, nextKey []byte, err error) {
+	result, _, err := ctx.querySubspacePage(cdc, subspace, startKey, limit, storeName)
 	if err != nil {
-		return res, err
+		return nil, nil, err
+	}
+	if len(result.NextKey) == 0 {
+		return result.KVs, nil, nil
+	}
+	return result.KVs, result.NextKey, nil
+}
+
+// query a page of the subspace and return the height of the result
+func (ctx CoreContext) querySubspacePage(cdc *wire.Codec, subspace, startKey []byte, limit int, storeName string) (result store.SubspaceQueryResult, height int64, err error) {
+	params := store.SubspaceQueryParams{
+		Subspace: subspace,
+		StartKey: startKey,
+		Limit:    limit,
+	}
+	verify, err := ctx.verifiesProofs()
+	if err != nil {
+		return result, 0, err
+	}
+	bz, err := cdc.MarshalBinary(params)
+	if err != nil {
+		return result, 0, err
+	}
+
+	path := fmt.Sprintf("/store/%s/subspace", storeName)
+	resp, err := ctx.queryABCI(path, bz)
+	if err != nil {
+		return result, 0, err
+	}
+	err = cdc.UnmarshalBinary(resp.Value, &result)
+	if err != nil {
+		return result, 0, err
+	}
+
+	if verify {
+		appHash, err := ctx.trustedAppHash(resp.Height)
+		if err != nil {
+			return result, 0, err
+		}
+		err = VerifySubspaceQueryProof(storeName, params, result, resp, appHash)
+		if err != nil {
+			return result, 0, err
+		}
 	}
-	cdc.MustUnmarshalBinary(resRaw, &res)
-	return
+	return result, resp.Height, nil
 }
 
 // Query from Tendermint with the provided storename and path
 func (ctx CoreContext) query(path string, key common.HexBytes) (res []byte, err error) {
-	node, err := ctx.GetNode()
+	resp, err := ctx.queryABCI(path, key)
 	if err != nil {
 		return res, err
 	}
+	return resp.Value, nil
+}
+
+// Query from Tendermint with the provided path, returning the whole response
+func (ctx CoreContext) queryABCI(path string, key common.HexBytes) (resp abci.ResponseQuery, err error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return resp, err
+	}
 
 	opts := rpcclient.ABCIQueryOptions{
 		Height:  ctx.Height,
@@ -93,19 +171,103 @@ func (ctx CoreContext) query(path string, key common.HexBytes) (res []byte, err
 	}
 	result, err := node.ABCIQueryWithOptions(path, key, opts)
 	if err != nil {
-		return res, err
+		return resp, err
 	}
-	resp := result.Response
+	resp = result.Response
 	if resp.Code != uint32(0) {
-		return res, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+		return resp, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
 	}
-	return resp.Value, nil
+	return resp, nil
 }
 
 // Query from Tendermint with the provided storename and path
+// If the node isn't trusted, the proof of a key query is verified against
+// the app hash of the trusted header.
 func (ctx CoreContext) queryStore(key cmn.HexBytes, storeName, endPath string) (res []byte, err error) {
+	verify, err := ctx.verifiesProofs()
+	if err != nil {
+		return res, err
+	}
 	path := fmt.Sprintf("/store/%s/%s", storeName, endPath)
-	return ctx.query(path, key)
+	resp, err := ctx.queryABCI(path, key)
+	if err != nil {
+		return res, err
+	}
+	if verify && endPath == "key" {
+		appHash, err := ctx.trustedAppHash(resp.Height)
+		if err != nil {
+			return res, err
+		}
+		err = VerifyQueryProof(storeName, key, resp, appHash)
+		if err != nil {
+			return res, err
+		}
+	}
+	return resp.Value, nil
+}
+
+// the proofs of the store queries are verified if the node isn't trusted,
+// which requires a source of trusted headers
+func (ctx CoreContext) verifiesProofs() (bool, error) {
+	if ctx.TrustNode {
+		return false, nil
+	}
+	if ctx.TrustedHeader == nil {
+		return false, errors.New("no source of trusted headers to verify the responses of the untrusted node")
+	}
+	return true, nil
+}
+
+// get the app hash committing the state of a height, which is in the
+// trusted header of the next height
+func (ctx CoreContext) trustedAppHash(height int64) ([]byte, error) {
+	header, err := ctx.TrustedHeader(height + 1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the trusted header of height %d", height+1)
+	}
+	return header.AppHash, nil
+}
+
+// VerifyQueryProof verifies the value of a store key query response
+// against an app hash. The IAVL proof of the key is chained to the root
+// hash of the store, which is chained to the app hash by the multistore
+// proof.
+func VerifyQueryProof(storeName string, key []byte, resp abci.ResponseQuery, appHash []byte) error {
+	proof, err := decodeQueryProof(storeName, resp)
+	if err != nil {
+		return err
+	}
+	err = store.VerifyMultiStoreProof(proof, storeName, key, resp.Value, appHash)
+	if err != nil {
+		return errors.Wrap(err, "invalid query proof")
+	}
+	return nil
+}
+
+// VerifySubspaceQueryProof verifies the page of a store subspace query
+// response against an app hash, including that no pair of the subspace is
+// omitted from the page
+func VerifySubspaceQueryProof(storeName string, params store.SubspaceQueryParams, result store.SubspaceQueryResult, resp abci.ResponseQuery, appHash []byte) error {
+	proof, err := decodeQueryProof(storeName, resp)
+	if err != nil {
+		return err
+	}
+	err = store.VerifyMultiStoreRangeProof(proof, storeName, params, result, appHash)
+	if err != nil {
+		return errors.Wrap(err, "invalid query proof")
+	}
+	return nil
+}
+
+func decodeQueryProof(storeName string, resp abci.ResponseQuery) (store.MultiStoreProof, error) {
+	if len(resp.Proof) == 0 {
+		return store.MultiStoreProof{}, errors.Errorf("no proof in the response of the query of %s", storeName)
+	}
+	proof, err := store.DecodeMultiStoreProof(resp.Proof)
+	if err != nil {
+		return proof, errors.Wrap(err, "failed to decode the query proof")
+	}
+	return proof, nil
 }
 
 // Get the from address from the name flag
@@ -0,0 +1,109 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// commits a multistore with a pair in the acc store
+func newQueryableMultiStore(t *testing.T, key, value []byte) (sdk.Queryable, sdk.CommitID) {
+	accKey := sdk.NewKVStoreKey("acc")
+	ms := store.NewCommitMultiStore(dbm.NewMemDB())
+	ms.MountStoreWithDB(accKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(sdk.NewKVStoreKey("main"), sdk.StoreTypeIAVL, nil)
+	require.Nil(t, ms.LoadLatestVersion())
+	ms.GetKVStore(accKey).Set(key, value)
+	cid := ms.Commit()
+	return ms, cid
+}
+
+func TestVerifyQueryProof(t *testing.T) {
+	key, value := []byte("key"), []byte("value")
+	ms, cid := newQueryableMultiStore(t, key, value)
+
+	query := func(key []byte) abci.ResponseQuery {
+		resp := ms.Query(abci.RequestQuery{Path: "/acc/key", Data: key, Height: cid.Version, Prove: true})
+		require.Equal(t, uint32(sdk.CodeOK), resp.Code)
+		return resp
+	}
+
+	// the value is proven against the app hash
+	resp := query(key)
+	require.Equal(t, value, resp.Value)
+	require.Nil(t, VerifyQueryProof("acc", key, resp, cid.Hash))
+
+	// so is the absence of a key
+	missing := query([]byte("missing"))
+	require.Nil(t, missing.Value)
+	require.Nil(t, VerifyQueryProof("acc", []byte("missing"), missing, cid.Hash))
+
+	// but not another value, key, store or app hash
+	tampered := resp
+	tampered.Value = []byte("other")
+	require.NotNil(t, VerifyQueryProof("acc", key, tampered, cid.Hash))
+	tampered = missing
+	tampered.Value = value
+	require.NotNil(t, VerifyQueryProof("acc", []byte("missing"), tampered, cid.Hash))
+	require.NotNil(t, VerifyQueryProof("acc", []byte("missing"), resp, cid.Hash))
+	require.NotNil(t, VerifyQueryProof("main", key, resp, cid.Hash))
+	require.NotNil(t, VerifyQueryProof("acc", key, resp, []byte("garbage")))
+
+	// a response without a valid proof isn't verified
+	tampered = resp
+	tampered.Proof = nil
+	require.NotNil(t, VerifyQueryProof("acc", key, tampered, cid.Hash))
+	tampered.Proof = []byte("garbage")
+	require.NotNil(t, VerifyQueryProof("acc", key, tampered, cid.Hash))
+}
+
+func TestVerifySubspaceQueryProof(t *testing.T) {
+	cdc := wire.NewCodec()
+	ms, cid := newQueryableMultiStore(t, []byte("key1"), []byte("value1"))
+
+	params := store.SubspaceQueryParams{Subspace: []byte("key")}
+	resp := ms.Query(abci.RequestQuery{Path: "/acc/subspace", Data: cdc.MustMarshalBinary(params), Height: cid.Version, Prove: true})
+	require.Equal(t, uint32(sdk.CodeOK), resp.Code)
+	var result store.SubspaceQueryResult
+	cdc.MustUnmarshalBinary(resp.Value, &result)
+	require.Equal(t, 1, len(result.KVs))
+
+	require.Nil(t, VerifySubspaceQueryProof("acc", params, result, resp, cid.Hash))
+	require.NotNil(t, VerifySubspaceQueryProof("acc", params, store.SubspaceQueryResult{}, resp, cid.Hash))
+	require.NotNil(t, VerifySubspaceQueryProof("acc", params, result, resp, []byte("garbage")))
+}
+
+func TestQueryUntrustedNode(t *testing.T) {
+	// the responses of an untrusted node can't be verified without a source
+	// of trusted headers
+	ctx := CoreContext{TrustNode: false}
+	_, err := ctx.QueryStore([]byte("key"), "acc")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "trusted headers")
+	_, _, err = ctx.QuerySubspacePage(wire.NewCodec(), []byte("key"), nil, 0, "acc")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "trusted headers")
+
+	// they are verified against the app hash of the header of the next height
+	var heights []int64
+	ctx = ctx.WithTrustedHeader(func(height int64) (*tmtypes.Header, error) {
+		heights = append(heights, height)
+		return &tmtypes.Header{AppHash: []byte("hash")}, nil
+	})
+	appHash, err := ctx.trustedAppHash(4)
+	require.Nil(t, err)
+	require.Equal(t, []byte("hash"), appHash)
+	require.Equal(t, []int64{5}, heights)
+
+	// a trusted node isn't verified
+	verify, err := CoreContext{TrustNode: true}.verifiesProofs()
+	require.Nil(t, err)
+	require.False(t, verify)
+}
@@ -2,6 +2,7 @@ package context
 
 import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
@@ -25,8 +26,13 @@ type CoreContext struct {
 	Async           bool
 	JSON            bool
 	PrintResponse   bool
+	TrustedHeader   HeaderSource
 }
 
+// HeaderSource returns the trusted header of a height, the proofs of the
+// store queries are verified against its app hash
+type HeaderSource func(height int64) (*tmtypes.Header, error)
+
 // WithChainID - return a copy of the context with an updated chainID
 func (c CoreContext) WithChainID(chainID string) CoreContext {
 	c.ChainID = chainID
@@ -111,3 +117,9 @@ func (c CoreContext) WithUseLedger(useLedger bool) CoreContext {
 	c.UseLedger = useLedger
 	return c
 }
+
+// WithTrustedHeader - return a copy of the context with an updated source of trusted headers
+func (c CoreContext) WithTrustedHeader(source HeaderSource) CoreContext {
+	c.TrustedHeader = source
+	return c
+}
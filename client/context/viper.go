@@ -2,10 +2,12 @@ package context
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/spf13/viper"
 
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
+	"github.com/tendermint/tendermint/libs/cli"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	tmtypes "github.com/tendermint/tendermint/types"
 
@@ -23,7 +25,7 @@ func NewCoreContextFromViper() CoreContext {
 	// if chain ID is not specified manually, read default chain ID
 	if chainID == "" {
 		def, err := defaultChainID()
-		if err != nil {
+		if err == nil {
 			chainID = def
 		}
 	}
@@ -34,7 +36,7 @@ func NewCoreContextFromViper() CoreContext {
 	} else {
 		keyName = viper.GetString(client.FlagFrom)
 	}
-	return CoreContext{
+	ctx := CoreContext{
 		ChainID:         chainID,
 		Height:          viper.GetInt64(client.FlagHeight),
 		Gas:             viper.GetInt64(client.FlagGas),
@@ -53,6 +55,17 @@ func NewCoreContextFromViper() CoreContext {
 		JSON:            viper.GetBool(client.FlagJson),
 		PrintResponse:   viper.GetBool(client.FlagPrintResponse),
 	}
+	// the responses of an untrusted node are verified by the light client
+	if !ctx.TrustNode && nodeURI != "" {
+		ctx.TrustedHeader = NewCertifiedHeaderSource(chainID, LiteDir(chainID), nodeURI)
+	}
+	return ctx
+}
+
+// LiteDir returns the directory of the commits trusted by the light client
+// of a chain, in the home directory
+func LiteDir(chainID string) string {
+	return filepath.Join(viper.GetString(cli.HomeFlag), "lite", chainID)
 }
 
 // read chain ID from genesis file, if present
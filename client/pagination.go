@@ -0,0 +1,45 @@
+package client
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// Query parameters of the paginated REST queries. The start key of the next
+// page is returned hex encoded in the HeaderNextKey header, which is missing
+// on the last page.
+// nolint
+const (
+	RestPageStart = "start"
+	RestPageLimit = "limit"
+	HeaderNextKey = "Next-Key"
+)
+
+// ParsePageParams parses the hex encoded start key and the limit of a
+// paginated REST query, both are optional
+func ParsePageParams(r *http.Request) (startKey []byte, limit int, err error) {
+	if strStart := r.URL.Query().Get(RestPageStart); len(strStart) != 0 {
+		startKey, err = hex.DecodeString(strStart)
+		if err != nil {
+			return nil, 0, errors.Errorf("start key [%s] is not hex encoded", strStart)
+		}
+	}
+	if strLimit := r.URL.Query().Get(RestPageLimit); len(strLimit) != 0 {
+		limit, err = strconv.Atoi(strLimit)
+		if err != nil || limit < 0 {
+			return nil, 0, errors.Errorf("limit [%s] is not a valid number", strLimit)
+		}
+	}
+	return startKey, limit, nil
+}
+
+// SetNextKeyHeader sets the header with the start key of the next page, if
+// there is one. It must be called before writing the response.
+func SetNextKeyHeader(w http.ResponseWriter, nextKey []byte) {
+	if len(nextKey) != 0 {
+		w.Header().Set(HeaderNextKey, hex.EncodeToString(nextKey))
+	}
+}
@@ -12,13 +12,18 @@ import (
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distr "github.com/cosmos/cosmos-sdk/x/fee_distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const (
@@ -44,6 +49,10 @@ type GaiaApp struct {
 	keySlashing      *sdk.KVStoreKey
 	keyGov           *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
+	keyCrisis        *sdk.KVStoreKey
+	keyParams        *sdk.KVStoreKey
+	keyUpgrade       *sdk.KVStoreKey
+	keyDistr         *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
@@ -53,9 +62,19 @@ type GaiaApp struct {
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
 	govKeeper           gov.Keeper
+	crisisKeeper        crisis.Keeper
+	paramsKeeper        params.Keeper
+	upgradeKeeper       upgrade.Keeper
+	distrKeeper         distr.Keeper
+
+	// the module manager
+	mm *module.Manager
 }
 
-func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
+// NewGaiaApp returns a reference to an initialized GaiaApp. All the
+// registered invariants are asserted every invCheckPeriod blocks, never if
+// invCheckPeriod is zero.
+func NewGaiaApp(logger log.Logger, db dbm.DB, invCheckPeriod uint) *GaiaApp {
 	cdc := MakeCodec()
 
 	// create your application object
@@ -69,6 +88,10 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 		keySlashing:      sdk.NewKVStoreKey("slashing"),
 		keyGov:           sdk.NewKVStoreKey("gov"),
 		keyFeeCollection: sdk.NewKVStoreKey("fee"),
+		keyCrisis:        sdk.NewKVStoreKey("crisis"),
+		keyParams:        sdk.NewKVStoreKey("params"),
+		keyUpgrade:       sdk.NewKVStoreKey("upgrade"),
+		keyDistr:         sdk.NewKVStoreKey("distr"),
 	}
 
 	// define the accountMapper
@@ -79,27 +102,58 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 	)
 
 	// add handlers
+	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
 	app.coinKeeper = bank.NewKeeper(app.accountMapper)
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
-	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.RegisterCodespace(slashing.DefaultCodespace))
-	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
+	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.paramsKeeper.Subspace(stake.DefaultParamspace), app.RegisterCodespace(stake.DefaultCodespace))
+	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Subspace(slashing.DefaultParamspace), app.RegisterCodespace(slashing.DefaultCodespace))
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade, app.RegisterCodespace(upgrade.DefaultCodespace))
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper, app.paramsKeeper.Subspace(gov.DefaultParamspace), app.coinKeeper, app.stakeKeeper, app.upgradeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
+	app.crisisKeeper = crisis.NewKeeper(app.cdc, app.keyCrisis, app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(crisis.DefaultCodespace))
+	app.distrKeeper = distr.NewKeeper(app.cdc, app.keyDistr, app.paramsKeeper.Subspace(distr.DefaultParamspace), app.stakeKeeper, app.coinKeeper, app.feeCollectionKeeper, app.RegisterCodespace(distr.DefaultCodespace))
+
+	// the rewards of the delegations are settled before their shares change,
+	// and the signing infos of the validators are started once bonded
+	app.stakeKeeper.SetHooks(sdk.NewMultiStakingHooks(
+		app.distrKeeper.Hooks(),
+		app.slashingKeeper.Hooks(),
+	))
 
-	// register message routes
-	app.Router().
-		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
-		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
-		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+	// register the modules, the order of their hooks is the registration
+	// order unless set otherwise below
+	app.mm = module.NewManager(
+		bank.NewAppModule(app.coinKeeper),
+		ibc.NewAppModule(app.ibcMapper, app.coinKeeper),
+		stake.NewAppModule(app.stakeKeeper, app.accountMapper,
+			app.feeCollectionKeeper.HeldTokens, app.govKeeper.HeldTokens, app.distrKeeper.HeldTokens),
+		slashing.NewAppModule(app.slashingKeeper),
+		gov.NewAppModule(app.govKeeper),
+		crisis.NewAppModule(app.crisisKeeper, invCheckPeriod),
+		params.NewAppModule(app.paramsKeeper),
+		upgrade.NewAppModule(app.upgradeKeeper),
+		distr.NewAppModule(app.distrKeeper),
+	)
+	// upgrade runs first, so that no module runs before a pending upgrade
+	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, distr.ModuleName, slashing.ModuleName)
+	// crisis runs last, to check the invariants on the final state of the block
+	app.mm.SetOrderEndBlockers(stake.ModuleName, gov.ModuleName, crisis.ModuleName)
+	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
+	app.registerInvariants(app.crisisKeeper)
 
 	// initialize BaseApp
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection)
+	app.SetAnteHandler(sdk.ChainAnteDecorators(
+		auth.NewSetUpGasMeterDecorator(),
+		auth.NewSigCountDecorator(),
+		auth.NewValidateMemoDecorator(),
+		auth.NewSigVerificationDecorator(app.accountMapper),
+		auth.NewDeductFeeDecorator(app.accountMapper, app.feeCollectionKeeper),
+		auth.NewIncrementSequenceDecorator(app.accountMapper),
+	))
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyCrisis, app.keyParams, app.keyUpgrade, app.keyDistr)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -116,32 +170,29 @@ func MakeCodec() *wire.Codec {
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
 	gov.RegisterWire(cdc)
+	crisis.RegisterWire(cdc)
+	distr.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
 	return cdc
 }
 
-// application updates every end block
-func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
-	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+// register the invariants of the modules and of the fee collection
+func (app *GaiaApp) registerInvariants(ir sdk.InvariantRouter) {
+	app.mm.RegisterInvariants(ir)
+	auth.RegisterInvariants(ir, app.feeCollectionKeeper)
+}
 
-	return abci.ResponseBeginBlock{
-		Tags: tags.ToKVPairs(),
-	}
+// application updates every begin block
+func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	return app.mm.BeginBlock(ctx, req)
 }
 
 // application updates every end block
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
-
-	tags, _ := gov.EndBlocker(ctx, app.govKeeper)
-
-	return abci.ResponseEndBlock{
-		ValidatorUpdates: validatorUpdates,
-		Tags:             tags,
-	}
+	return app.mm.EndBlock(ctx, req)
 }
 
 // custom logic for gaia initialization
@@ -163,10 +214,20 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
-	// load the initial stake information
-	stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
-
-	gov.InitGenesis(ctx, app.govKeeper, gov.DefaultGenesisState())
+	// load the genesis state of the modules, keyed by module name
+	var modulesState map[string]json.RawMessage
+	err = json.Unmarshal(stateJSON, &modulesState)
+	if err != nil {
+		panic(err)
+	}
+	err = app.mm.ValidateGenesis(modulesState)
+	if err != nil {
+		panic(err)
+	}
+	err = app.mm.InitGenesis(ctx, modulesState)
+	if err != nil {
+		panic(err)
+	}
 
 	return abci.ResponseInitChain{}
 }
@@ -184,11 +245,12 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	}
 	app.accountMapper.IterateAccounts(ctx, appendAccount)
 
-	genState := GenesisState{
-		Accounts:  accounts,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
+	genState := app.mm.ExportGenesis(ctx)
+	genState["accounts"], err = app.cdc.MarshalJSON(accounts)
+	if err != nil {
+		return nil, nil, err
 	}
-	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
+	appState, err = json.MarshalIndent(genState, "", "  ")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1,8 +1,21 @@
 package app
 
 import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distr "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -15,8 +28,12 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 	}
 
 	genesisState := GenesisState{
-		Accounts:  genaccs,
-		StakeData: stake.DefaultGenesisState(),
+		Accounts:     genaccs,
+		StakeData:    stake.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		CrisisData:   crisis.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
 	}
 
 	stateBytes, err := wire.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -31,3 +48,36 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 
 	return nil
 }
+
+func TestGaiaExport(t *testing.T) {
+	db := dbm.NewMemDB()
+	gapp := NewGaiaApp(log.NewNopLogger(), db, 0)
+
+	acc := auth.NewBaseAccountWithAddress(sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address()))
+	acc.Coins = sdk.Coins{sdk.NewCoin("steak", 100)}
+	require.Nil(t, setGenesis(gapp, &acc))
+
+	// the genesis state of every module is exported
+	appState, _, err := gapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+
+	var genState map[string]json.RawMessage
+	require.Nil(t, json.Unmarshal(appState, &genState))
+	for _, key := range []string{"accounts", stake.ModuleName, slashing.ModuleName, gov.ModuleName, distr.ModuleName} {
+		require.Contains(t, genState, key)
+	}
+
+	var govData gov.GenesisState
+	require.Nil(t, gapp.cdc.UnmarshalJSON(genState[gov.ModuleName], &govData))
+	require.Equal(t, gov.DefaultGenesisState(), govData)
+
+	// a new chain can be started from the exported state
+	newGapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	newGapp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	newGapp.Commit()
+
+	ctx := newGapp.NewContext(true, abci.Header{})
+	res := newGapp.accountMapper.GetAccount(ctx, acc.Address)
+	require.NotNil(t, res)
+	require.Equal(t, acc.Coins, res.GetCoins())
+}
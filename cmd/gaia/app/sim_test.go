@@ -0,0 +1,127 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand"
+	"testing"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distr "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govsim "github.com/cosmos/cosmos-sdk/x/gov/simulation"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	slashingsim "github.com/cosmos/cosmos-sdk/x/slashing/simulation"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakesim "github.com/cosmos/cosmos-sdk/x/stake/simulation"
+)
+
+var (
+	simulationEnabled   bool
+	simulationSeed      int64
+	simulationNumBlocks int
+	simulationBlockSize int
+)
+
+func init() {
+	flag.BoolVar(&simulationEnabled, "SimulationEnabled", false, "Enable the full Gaia simulation")
+	flag.Int64Var(&simulationSeed, "SimulationSeed", 42, "Seed of the simulation")
+	flag.IntVar(&simulationNumBlocks, "SimulationNumBlocks", 500, "Number of blocks of the simulation")
+	flag.IntVar(&simulationBlockSize, "SimulationBlockSize", 100, "Number of operations per block of the simulation")
+}
+
+// genesis with random amounts of steak for the accounts, and no validators:
+// they are created by the simulation
+func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+	genAccs := make([]GenesisAccount, len(accs))
+	var looseTokens int64
+	for i, acc := range accs {
+		amount := 1 + r.Int63n(1000)
+		genAccs[i] = GenesisAccount{
+			Address: acc.Address,
+			Coins:   sdk.Coins{sdk.NewCoin("steak", amount)},
+		}
+		looseTokens += amount
+	}
+
+	stakeGenesis := stake.DefaultGenesisState()
+	stakeGenesis.Pool.LooseTokens = looseTokens
+	// short enough for unbondings to complete during the simulation
+	stakeGenesis.Params.UnbondingTime = 60 * 60
+	govGenesis := gov.DefaultGenesisState()
+	// short enough for proposals to end during the simulation
+	govGenesis.DepositProcedure.MaxDepositPeriod = 60 * 60
+	govGenesis.VotingProcedure.VotingPeriod = 60 * 60
+
+	genesisState := GenesisState{
+		Accounts:     genAccs,
+		StakeData:    stakeGenesis,
+		SlashingData: slashing.DefaultGenesisState(),
+		GovData:      govGenesis,
+		CrisisData:   crisis.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+	}
+	appState, err := wire.MarshalJSONIndent(MakeCodec(), genesisState)
+	if err != nil {
+		panic(err)
+	}
+	return appState
+}
+
+func operations(app *GaiaApp) []simulation.WeightedOperation {
+	return []simulation.WeightedOperation{
+		{Weight: 100, Op: banksim.SimulateSingleInputMsgSend(app.accountMapper)},
+		{Weight: 5, Op: stakesim.SimulateMsgCreateValidator(app.accountMapper, app.stakeKeeper)},
+		{Weight: 5, Op: stakesim.SimulateMsgEditValidator(app.stakeKeeper)},
+		{Weight: 100, Op: stakesim.SimulateMsgDelegate(app.accountMapper, app.stakeKeeper)},
+		{Weight: 100, Op: stakesim.SimulateMsgBeginUnbonding(app.stakeKeeper)},
+		{Weight: 100, Op: stakesim.SimulateMsgCompleteUnbonding(app.stakeKeeper)},
+		{Weight: 100, Op: stakesim.SimulateMsgBeginRedelegate(app.stakeKeeper)},
+		{Weight: 100, Op: stakesim.SimulateMsgCompleteRedelegate(app.stakeKeeper)},
+		{Weight: 5, Op: govsim.SimulateMsgSubmitProposal(app.accountMapper, app.govKeeper)},
+		{Weight: 50, Op: govsim.SimulateMsgDeposit(app.accountMapper, app.govKeeper)},
+		{Weight: 50, Op: govsim.SimulateMsgVote(app.govKeeper)},
+		{Weight: 10, Op: slashingsim.SimulateMsgUnrevoke(app.slashingKeeper, app.stakeKeeper)},
+	}
+}
+
+// the invariants registered with the crisis module
+func invariants(app *GaiaApp) *simulation.Invariants {
+	invs := simulation.NewInvariants()
+	app.registerInvariants(invs)
+	return invs
+}
+
+// TestFullGaiaSimulation runs a simulation of Gaia, for instance:
+//
+//	go test ./cmd/gaia/app -run TestFullGaiaSimulation -SimulationEnabled=true \
+//		-SimulationSeed=42 -SimulationNumBlocks=500 -SimulationBlockSize=100 -v
+//
+// A failing simulation reports its seed, it can be rerun with the same
+// flags to reproduce the failure.
+func TestFullGaiaSimulation(t *testing.T) {
+	if !simulationEnabled {
+		t.Skip("skipping the Gaia simulation, enable it with -SimulationEnabled=true")
+	}
+
+	app := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	simulation.SimulateFromSeed(
+		t, app.BaseApp, appStateFn, simulationSeed,
+		operations(app), invariants(app),
+		simulationNumBlocks, simulationBlockSize,
+	)
+}
+
+// TestGaiaSimulationSmoke runs a short simulation of Gaia as part of the
+// unit tests
+func TestGaiaSimulationSmoke(t *testing.T) {
+	app := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	simulation.SimulateFromSeed(t, app.BaseApp, appStateFn, 42, operations(app), invariants(app), 20, 20)
+}
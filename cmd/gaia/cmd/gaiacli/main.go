@@ -13,10 +13,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	crisiscmd "github.com/cosmos/cosmos-sdk/x/crisis/client/cli"
+	distrcmd "github.com/cosmos/cosmos-sdk/x/fee_distribution/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
+	paramscmd "github.com/cosmos/cosmos-sdk/x/params/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
+	upgradecmd "github.com/cosmos/cosmos-sdk/x/upgrade/client/cli"
 
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 )
@@ -88,6 +92,7 @@ func main() {
 			stakecmd.GetCmdQueryValidators("stake", cdc),
 			stakecmd.GetCmdQueryDelegation("stake", cdc),
 			stakecmd.GetCmdQueryDelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorDelegations("stake", cdc),
 			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
 		)...)
 	stakeCmd.AddCommand(
@@ -98,6 +103,7 @@ func main() {
 			stakecmd.GetCmdUnbond("stake", cdc),
 			stakecmd.GetCmdRedelegate("stake", cdc),
 			slashingcmd.GetCmdUnrevoke(cdc),
+			slashingcmd.GetCmdSubmitEvidence(cdc),
 		)...)
 	rootCmd.AddCommand(
 		stakeCmd,
@@ -112,6 +118,10 @@ func main() {
 		client.GetCommands(
 			govcmd.GetCmdQueryProposal("gov", cdc),
 			govcmd.GetCmdQueryVote("gov", cdc),
+			govcmd.GetCmdQueryProposals("gov", cdc),
+			govcmd.GetCmdQueryVotes("gov", cdc),
+			govcmd.GetCmdQueryDeposits("gov", cdc),
+			govcmd.GetCmdQueryTally("gov", cdc),
 		)...)
 	govCmd.AddCommand(
 		client.PostCommands(
@@ -123,6 +133,60 @@ func main() {
 		govCmd,
 	)
 
+	//Add params commands
+	paramsCmd := &cobra.Command{
+		Use:   "params",
+		Short: "Parameter subcommands",
+	}
+	paramsCmd.AddCommand(
+		client.GetCommands(
+			paramscmd.GetCmdQueryParam("params", cdc),
+		)...)
+	rootCmd.AddCommand(
+		paramsCmd,
+	)
+
+	//Add upgrade commands
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade subcommands",
+	}
+	upgradeCmd.AddCommand(
+		client.GetCommands(
+			upgradecmd.GetCmdQueryPlan("upgrade", cdc),
+			upgradecmd.GetCmdQueryApplied("upgrade", cdc),
+		)...)
+	rootCmd.AddCommand(
+		upgradeCmd,
+	)
+
+	//Add crisis commands
+	crisisCmd := &cobra.Command{
+		Use:   "crisis",
+		Short: "Crisis subcommands",
+	}
+	crisisCmd.AddCommand(
+		client.PostCommands(
+			crisiscmd.GetCmdInvariantBroken(cdc),
+		)...)
+	rootCmd.AddCommand(
+		crisisCmd,
+	)
+
+	//Add distribution commands
+	distrCmd := &cobra.Command{
+		Use:   "distr",
+		Short: "Fee distribution subcommands",
+	}
+	distrCmd.AddCommand(
+		client.PostCommands(
+			distrcmd.GetCmdWithdrawDelegatorReward(cdc),
+			distrcmd.GetCmdWithdrawValidatorCommission(cdc),
+		)...)
+	rootCmd.AddCommand(
+		distrCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
@@ -15,6 +15,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 )
 
+// flag to assert the invariants every N blocks
+const flagInvCheckPeriod = "inv-check-period"
+
+var invCheckPeriod uint
+
 func main() {
 	cdc := app.MakeCodec()
 	ctx := server.NewDefaultContext()
@@ -30,6 +35,8 @@ func main() {
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "gaia"))
 
 	// prepare and add flags
+	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
+		0, "Assert registered invariants every N blocks, never if 0")
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
 	err := executor.Execute()
 	if err != nil {
@@ -39,10 +46,10 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB) abci.Application {
-	return app.NewGaiaApp(logger, db)
+	return app.NewGaiaApp(logger, db, invCheckPeriod)
 }
 
 func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
-	gapp := app.NewGaiaApp(logger, db)
+	gapp := app.NewGaiaApp(logger, db, 0)
 	return gapp.ExportAppStateAndValidators()
 }
@@ -91,7 +91,14 @@ func NewDemocoinApp(logger log.Logger, db dbm.DB) *DemocoinApp {
 	// Initialize BaseApp.
 	app.SetInitChainer(app.initChainerFn(app.coolKeeper, app.powKeeper))
 	app.MountStoresIAVL(app.capKeyMainStore, app.capKeyAccountStore, app.capKeyPowStore, app.capKeyIBCStore, app.capKeyStakingStore)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
+	app.SetAnteHandler(sdk.ChainAnteDecorators(
+		auth.NewSetUpGasMeterDecorator(),
+		auth.NewSigCountDecorator(),
+		auth.NewValidateMemoDecorator(),
+		auth.NewSigVerificationDecorator(app.accountMapper),
+		auth.NewDeductFeeDecorator(app.accountMapper, app.feeCollectionKeeper),
+		auth.NewIncrementSequenceDecorator(app.accountMapper),
+	))
 	err := app.LoadLatestVersion(app.capKeyMainStore)
 	if err != nil {
 		cmn.Exit(err.Error())
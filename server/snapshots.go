@@ -0,0 +1,134 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// stateApp is implemented by the apps whose state pruning and snapshots are
+// set by the server, like the BaseApp
+type stateApp interface {
+	SetPruning(sdk.PruningOptions)
+	SetSnapshotOptions(dir string, options store.SnapshotOptions)
+	SnapshotManager() *store.SnapshotManager
+}
+
+// SnapshotDir returns the directory of the state snapshots of a node home
+func SnapshotDir(home string) string {
+	return filepath.Join(home, "data", "snapshots")
+}
+
+// SnapshotsCmd lists, takes and restores the state snapshots of the app
+func SnapshotsCmd(ctx *Context, cdc *wire.Codec, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "State snapshot subcommands",
+	}
+	cmd.AddCommand(
+		listSnapshotsCmd(),
+		exportSnapshotCmd(ctx, cdc, appCreator),
+		restoreSnapshotCmd(ctx, appCreator),
+	)
+	return cmd
+}
+
+func listSnapshotsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the state snapshots",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshots, err := store.ListSnapshots(SnapshotDir(viper.GetString(cli.HomeFlag)))
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range snapshots {
+				fmt.Printf("height %d: hash %X, %d chunks\n", snapshot.Height, snapshot.Hash, len(snapshot.ChunkHashes))
+			}
+			return nil
+		},
+	}
+}
+
+func exportSnapshotCmd(ctx *Context, cdc *wire.Codec, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export",
+		Short: "Take a snapshot of the latest state, the node must be stopped",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := loadSnapshotManager(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			snapshot, err := manager.Create()
+			if err != nil {
+				return errors.Errorf("error taking the snapshot: %v", err)
+			}
+			output, err := wire.MarshalJSONIndent(cdc, snapshot)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func restoreSnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [height] [app-hash]",
+		Short: "Restore the state from the snapshot of a height",
+		Long: `Restore the state from the snapshot of a height into an empty app
+database. The restored stores are checked against the app hash of the height,
+in hex, which must come from a trusted header of the next height.
+Only the app state is restored, the node still needs the Tendermint block
+store and state of the height to start.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return errors.Errorf("height [%s] is not a valid number", args[0])
+			}
+			appHash, err := hex.DecodeString(args[1])
+			if err != nil || len(appHash) == 0 {
+				return errors.Errorf("app hash [%s] is not a valid hex hash", args[1])
+			}
+			manager, err := loadSnapshotManager(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			err = manager.Restore(height, appHash)
+			if err != nil {
+				return errors.Errorf("error restoring the snapshot: %v", err)
+			}
+			fmt.Printf("restored the state of height %d\n", height)
+			return nil
+		},
+	}
+}
+
+// creates the app of the node home to get a manager of its snapshots, the
+// start command flags aren't read
+func loadSnapshotManager(ctx *Context, appCreator AppCreator) (*store.SnapshotManager, error) {
+	home := viper.GetString(cli.HomeFlag)
+	app, err := appCreator(home, ctx.Logger)
+	if err != nil {
+		return nil, err
+	}
+	sapp, ok := app.(stateApp)
+	if !ok {
+		return nil, errors.New("the app doesn't take state snapshots")
+	}
+	sapp.SetSnapshotOptions(SnapshotDir(home), store.SnapshotOptions{})
+	return sapp.SnapshotManager(), nil
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/server/mock"
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func TestSnapshotsCmd(t *testing.T) {
+	home, err := ioutil.TempDir("", "mock-sdk-cmd")
+	require.Nil(t, err)
+	defer os.RemoveAll(home)
+	restoreHome, err := ioutil.TempDir("", "mock-sdk-cmd")
+	require.Nil(t, err)
+	defer os.RemoveAll(restoreHome)
+	defer viper.Reset()
+	viper.Set(cli.HomeFlag, home)
+
+	logger := log.NewNopLogger()
+	cfg, err := tcmd.ParseConfig()
+	require.Nil(t, err)
+	ctx := NewContext(cfg, logger)
+
+	// commit a few blocks
+	app, err := mock.NewApp(home, logger)
+	require.Nil(t, err)
+	var appHash []byte
+	for height := int64(1); height <= 3; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		res := app.DeliverTx([]byte(fmt.Sprintf("key%d=value%d", height, height)))
+		require.Equal(t, uint32(0), res.Code, res.Log)
+		app.EndBlock(abci.RequestEndBlock{})
+		appHash = app.Commit().Data
+	}
+
+	// the commands don't need the start command flags
+	run := func(appCreator AppCreator, args ...string) error {
+		cmd := SnapshotsCmd(ctx, wire.NewCodec(), appCreator)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	sameApp := func(string, log.Logger) (abci.Application, error) {
+		return app, nil
+	}
+	require.Nil(t, run(sameApp, "export"))
+	snapshots, err := store.ListSnapshots(SnapshotDir(home))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(snapshots))
+	require.Equal(t, int64(3), snapshots[0].Height)
+	require.Nil(t, run(sameApp, "list"))
+
+	// the snapshot is restored into an empty app against the app hash
+	var restored abci.Application
+	restoreApp := func(_ string, logger log.Logger) (abci.Application, error) {
+		if restored == nil {
+			restored, err = mock.NewApp(restoreHome, logger)
+		}
+		return restored, err
+	}
+	require.NotNil(t, run(restoreApp, "restore", "3"))
+	require.NotNil(t, run(restoreApp, "restore", "3", "garbage"))
+	require.NotNil(t, run(restoreApp, "restore", "3", hex.EncodeToString([]byte("wrong hash"))))
+	require.Nil(t, run(restoreApp, "restore", "3", hex.EncodeToString(appHash)))
+
+	res := restored.Query(abci.RequestQuery{Path: "/store/main/key", Data: []byte("key2")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	require.Equal(t, []byte("value2"), res.Value)
+}
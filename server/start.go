@@ -6,17 +6,34 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/abci/server"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/node"
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagWithTendermint    = "with-tendermint"
+	flagAddress           = "address"
+	flagPruning           = "pruning"
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+	flagSnapshotInterval  = "snapshot-interval"
+	flagSnapshotKeep      = "snapshot-keep-recent"
 )
 
+// pruning strategies of the pruning flag
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
+	PruningStrategyDefault    = "default"
+	PruningStrategyNothing    = "nothing"
+	PruningStrategyEverything = "everything"
+	PruningStrategyCustom     = "custom"
 )
 
 // StartCmd runs the service passed in, either
@@ -39,12 +56,74 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	// basic flags for abci app
 	cmd.Flags().Bool(flagWithTendermint, true, "run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
+	cmd.Flags().String(flagPruning, PruningStrategyDefault, "Pruning strategy of the stores: default (keep the last 100 states and every 10000th state), nothing (keep every state), everything (keep only the latest state) or custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, 0, "Number of recent states kept by the custom pruning strategy")
+	cmd.Flags().Int64(flagPruningKeepEvery, 0, "Interval of the older states kept by the custom pruning strategy, none if 0")
+	cmd.Flags().Int64(flagSnapshotInterval, 0, "Height interval of the state snapshots, none if 0")
+	cmd.Flags().Int(flagSnapshotKeep, 2, "Number of recent state snapshots kept, all of them if 0")
 
 	// AddNodeFlags adds support for all tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
 	return cmd
 }
 
+// PruningOptionsFromFlags returns the pruning options of the start command
+// flags
+func PruningOptionsFromFlags() (sdk.PruningOptions, error) {
+	switch strategy := viper.GetString(flagPruning); strategy {
+	case PruningStrategyDefault:
+		return sdk.PruneDefault, nil
+	case PruningStrategyNothing:
+		return sdk.PruneNothing, nil
+	case PruningStrategyEverything:
+		return sdk.PruneEverything, nil
+	case PruningStrategyCustom:
+		pruning := sdk.PruningOptions{
+			KeepRecent: viper.GetInt64(flagPruningKeepRecent),
+			KeepEvery:  viper.GetInt64(flagPruningKeepEvery),
+		}
+		if pruning.KeepRecent < 0 || pruning.KeepEvery < 0 {
+			return pruning, errors.Errorf("invalid custom pruning %d recent states and every %d states, expected non negative values", pruning.KeepRecent, pruning.KeepEvery)
+		}
+		return pruning, nil
+	default:
+		return sdk.PruningOptions{}, errors.Errorf("unknown pruning strategy %s", strategy)
+	}
+}
+
+// SnapshotOptionsFromFlags returns the state snapshot options of the start
+// command flags
+func SnapshotOptionsFromFlags() (store.SnapshotOptions, error) {
+	options := store.SnapshotOptions{
+		Interval:   viper.GetInt64(flagSnapshotInterval),
+		KeepRecent: viper.GetInt(flagSnapshotKeep),
+	}
+	if options.Interval < 0 || options.KeepRecent < 0 {
+		return options, errors.Errorf("invalid snapshot interval %d and %d kept snapshots, expected non negative values", options.Interval, options.KeepRecent)
+	}
+	return options, nil
+}
+
+// sets the pruning and snapshot options of the start command flags on the
+// app, if it is a stateApp
+func setStateOptions(app abci.Application, home string) error {
+	pruning, err := PruningOptionsFromFlags()
+	if err != nil {
+		return err
+	}
+	snapshotOptions, err := SnapshotOptionsFromFlags()
+	if err != nil {
+		return err
+	}
+	sapp, ok := app.(stateApp)
+	if !ok {
+		return nil
+	}
+	sapp.SetPruning(pruning)
+	sapp.SetSnapshotOptions(SnapshotDir(home), snapshotOptions)
+	return nil
+}
+
 func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	// Generate the app in the proper dir
 	addr := viper.GetString(flagAddress)
@@ -53,6 +132,10 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	if err != nil {
 		return err
 	}
+	err = setStateOptions(app, home)
+	if err != nil {
+		return err
+	}
 
 	svr, err := server.NewServer(addr, "socket", app)
 	if err != nil {
@@ -82,6 +165,10 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = setStateOptions(app, home)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create & start tendermint node
 	n, err := node.NewNode(cfg,
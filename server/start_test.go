@@ -6,9 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cosmos/cosmos-sdk/server/mock"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/tendermint/tendermint/abci/server"
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
@@ -50,3 +53,42 @@ func TestStartStandAlone(t *testing.T) {
 		svr.Stop()
 	}
 }
+
+func TestPruningOptionsFromFlags(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set(flagPruning, PruningStrategyNothing)
+	pruning, err := PruningOptionsFromFlags()
+	require.Nil(t, err)
+	require.Equal(t, sdk.PruneNothing, pruning)
+
+	// the custom strategy keeps the states of the flags
+	viper.Set(flagPruning, PruningStrategyCustom)
+	viper.Set(flagPruningKeepRecent, 100000)
+	viper.Set(flagPruningKeepEvery, 10000)
+	pruning, err = PruningOptionsFromFlags()
+	require.Nil(t, err)
+	require.Equal(t, sdk.PruningOptions{KeepRecent: 100000, KeepEvery: 10000}, pruning)
+
+	viper.Set(flagPruningKeepEvery, -1)
+	_, err = PruningOptionsFromFlags()
+	require.NotNil(t, err)
+
+	viper.Set(flagPruning, "sometimes")
+	_, err = PruningOptionsFromFlags()
+	require.NotNil(t, err)
+}
+
+func TestSnapshotOptionsFromFlags(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set(flagSnapshotInterval, 1000)
+	viper.Set(flagSnapshotKeep, 3)
+	options, err := SnapshotOptionsFromFlags()
+	require.Nil(t, err)
+	require.Equal(t, store.SnapshotOptions{Interval: 1000, KeepRecent: 3}, options)
+
+	viper.Set(flagSnapshotInterval, -1)
+	_, err = SnapshotOptionsFromFlags()
+	require.NotNil(t, err)
+}
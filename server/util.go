@@ -116,6 +116,7 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		SnapshotsCmd(ctx, cdc, appCreator),
 		client.LineBreak,
 		version.VersionCmd,
 	)
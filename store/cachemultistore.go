@@ -1,6 +1,8 @@
 package store
 
 import (
+	dbm "github.com/tendermint/tendermint/libs/db"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -18,12 +20,18 @@ type cacheMultiStore struct {
 var _ CacheMultiStore = cacheMultiStore{}
 
 func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
+	return newCacheMultiStoreFromStores(rms.db, rms.stores, rms.keysByName)
+}
+
+func newCacheMultiStoreFromStores(db dbm.DB, stores map[StoreKey]CommitStore,
+	keysByName map[string]StoreKey) cacheMultiStore {
+
 	cms := cacheMultiStore{
-		db:         NewCacheKVStore(dbStoreAdapter{rms.db}),
-		stores:     make(map[StoreKey]CacheWrap, len(rms.stores)),
-		keysByName: rms.keysByName,
+		db:         NewCacheKVStore(dbStoreAdapter{db}),
+		stores:     make(map[StoreKey]CacheWrap, len(stores)),
+		keysByName: keysByName,
 	}
-	for key, store := range rms.stores {
+	for key, store := range stores {
 		cms.stores[key] = store.CacheWrap()
 	}
 	return cms
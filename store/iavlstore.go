@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -14,19 +15,20 @@ import (
 )
 
 const (
-	defaultIAVLCacheSize  = 10000
-	defaultIAVLNumRecent  = 100
-	defaultIAVLStoreEvery = 10000
+	defaultIAVLCacheSize = 10000
+
+	// maximum number of pairs returned by a subspace query
+	maxSubspaceQueryLimit = 1000
 )
 
-// load the iavl store
-func LoadIAVLStore(db dbm.DB, id CommitID) (CommitStore, error) {
+// load the iavl store, pruned according to the pruning options
+func LoadIAVLStore(db dbm.DB, id CommitID, pruning PruningOptions) (CommitStore, error) {
 	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
 	_, err := tree.LoadVersion(id.Version)
 	if err != nil {
 		return nil, err
 	}
-	store := newIAVLStore(tree, defaultIAVLNumRecent, defaultIAVLStoreEvery)
+	store := newIAVLStore(tree, pruning.KeepRecent, pruning.KeepEvery)
 	return store, nil
 }
 
@@ -54,6 +56,12 @@ type iavlStore struct {
 	// so that nodes can know the waypoints their peers store
 	// TODO if set to non-default, signal to peers that the node is not suitable as a state sync source
 	storeEvery int64
+
+	// The versions read by the snapshots being taken aren't released until
+	// the snapshots are done, the released versions are deferred meanwhile.
+	mtx      sync.Mutex
+	retained map[int64]int
+	deferred []int64
 }
 
 // CONTRACT: tree should be fully loaded.
@@ -66,6 +74,12 @@ func newIAVLStore(tree *iavl.VersionedTree, numRecent int64, storeEvery int64) *
 	return st
 }
 
+// Sets the versions released on the next commits.
+func (st *iavlStore) SetPruning(pruning PruningOptions) {
+	st.numRecent = pruning.KeepRecent
+	st.storeEvery = pruning.KeepEvery
+}
+
 // Implements Committer.
 func (st *iavlStore) Commit() CommitID {
 
@@ -76,15 +90,13 @@ func (st *iavlStore) Commit() CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint
+	// Release an old version of history, if not a sync waypoint. The version
+	// may have been released already under other pruning options.
 	previous := version - 1
 	if st.numRecent < previous {
 		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil {
-				panic(err)
-			}
+		if (st.storeEvery == 0 || toRelease%st.storeEvery != 0) && st.tree.VersionExists(toRelease) {
+			st.release(toRelease)
 		}
 	}
 
@@ -94,6 +106,54 @@ func (st *iavlStore) Commit() CommitID {
 	}
 }
 
+// Deletes a version, unless it is retained by a snapshot, and the deferred
+// versions which aren't retained anymore.
+func (st *iavlStore) release(version int64) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	deferred := st.deferred[:0]
+	for _, ver := range append(st.deferred, version) {
+		if st.retained[ver] > 0 {
+			deferred = append(deferred, ver)
+			continue
+		}
+		err := st.tree.DeleteVersion(ver)
+		if err != nil {
+			panic(err)
+		}
+	}
+	st.deferred = deferred
+}
+
+// Retains a committed version until it is released by releaseRetained, it
+// isn't pruned meanwhile. Must be called from the committing goroutine.
+func (st *iavlStore) retain(version int64) error {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	if !st.tree.VersionExists(version) {
+		return fmt.Errorf("version %d doesn't exist", version)
+	}
+	if st.retained == nil {
+		st.retained = make(map[int64]int)
+	}
+	st.retained[version]++
+	return nil
+}
+
+// Releases a retained version, it is pruned on a next commit if it should
+// have been.
+func (st *iavlStore) releaseRetained(version int64) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	st.retained[version]--
+	if st.retained[version] <= 0 {
+		delete(st.retained, version)
+	}
+}
+
 // Implements Committer.
 func (st *iavlStore) LastCommitID() CommitID {
 	return CommitID{
@@ -200,16 +260,50 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		} else {
 			_, res.Value = tree.GetVersioned(key, height)
 		}
-	case "/subspace":
-		subspace := req.Data
-		res.Key = subspace
-		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
-		for ; iterator.Valid(); iterator.Next() {
-			KVs = append(KVs, KVPair{iterator.Key(), iterator.Value()})
+	case "/subspace": // Get a page of the pairs of a subspace
+		var params SubspaceQueryParams
+		err := cdc.UnmarshalBinary(req.Data, &params)
+		if err != nil {
+			msg := fmt.Sprintf("invalid subspace query params: %v", err)
+			return sdk.ErrUnknownRequest(msg).QueryResult()
+		}
+		if err := params.validate(); err != nil {
+			return sdk.ErrUnknownRequest(err.Error()).QueryResult()
+		}
+		res.Key = params.Subspace
+
+		start := params.start()
+		limit := params.Limit
+		if limit <= 0 || limit > maxSubspaceQueryLimit {
+			limit = maxSubspaceQueryLimit
+		}
+
+		// get one more pair, the start of the next page
+		end := sdk.PrefixEndBytes(params.Subspace)
+		keys, values, proof, err := tree.GetVersionedRangeWithProof(start, end, limit+1, height)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
+		result := SubspaceQueryResult{KVs: make([]KVPair, 0, len(keys))}
+		if len(keys) > limit {
+			result.NextKey = keys[limit]
+			keys, values = keys[:limit], values[:limit]
+		}
+		for i := range keys {
+			result.KVs = append(result.KVs, KVPair{keys[i], values[i]})
+		}
+		res.Value = cdc.MustMarshalBinary(result)
+
+		if req.Prove {
+			cdc := amino.NewCodec()
+			p, err := cdc.MarshalBinary(proof)
+			if err != nil {
+				res.Log = err.Error()
+				break
+			}
+			res.Proof = p
 		}
-		iterator.Close()
-		res.Value = cdc.MustMarshalBinary(KVs)
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
 		return sdk.ErrUnknownRequest(msg).QueryResult()
@@ -217,6 +311,38 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// SubspaceQueryParams are the params of a "/subspace" query, which returns
+// the pairs of the subspace from the start key, at most limit of them. The
+// limit is capped by the store.
+type SubspaceQueryParams struct {
+	Subspace []byte
+	StartKey []byte
+	Limit    int
+}
+
+// the start key must be in the subspace, the pages never leave it
+func (params SubspaceQueryParams) validate() error {
+	if len(params.StartKey) > 0 && !bytes.HasPrefix(params.StartKey, params.Subspace) {
+		return fmt.Errorf("start key %X is outside the subspace %X", params.StartKey, params.Subspace)
+	}
+	return nil
+}
+
+// first key of the queried page, the subspace itself without a start key
+func (params SubspaceQueryParams) start() []byte {
+	if len(params.StartKey) > 0 {
+		return params.StartKey
+	}
+	return params.Subspace
+}
+
+// SubspaceQueryResult is the result of a "/subspace" query, the next key is
+// the start key of the next page, nil on the last page.
+type SubspaceQueryResult struct {
+	KVs     []KVPair
+	NextKey []byte
+}
+
 //----------------------------------------
 
 // Implements Iterator.
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/go-amino"
 	"github.com/tendermint/iavl"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -339,6 +340,60 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLSwitchPruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, int64(0), int64(0))
+	for i := 0; i < 10; i++ {
+		nextVersion(iavlStore)
+	}
+	require.False(t, iavlStore.VersionExists(5))
+	require.True(t, iavlStore.VersionExists(10))
+
+	// the versions pruned by the previous options are skipped
+	iavlStore.SetPruning(sdk.PruningOptions{KeepRecent: numRecent, KeepEvery: storeEvery})
+	for i := 0; i < 10; i++ {
+		nextVersion(iavlStore)
+	}
+	for _, ver := range []int64{12, 15, 16, 17, 18, 19, 20} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+	for _, ver := range []int64{9, 10, 11, 13, 14} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+
+	// and back to pruning everything
+	iavlStore.SetPruning(sdk.PruneEverything)
+	for i := 0; i < 10; i++ {
+		nextVersion(iavlStore)
+	}
+	require.True(t, iavlStore.VersionExists(30))
+	for ver := int64(20); ver < 30; ver++ {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+}
+
+func TestIAVLRetainVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, int64(0), int64(0))
+	nextVersion(iavlStore)
+	require.NotNil(t, iavlStore.retain(2))
+	require.Nil(t, iavlStore.retain(1))
+
+	// the retained version isn't pruned until it is released
+	for i := 0; i < 3; i++ {
+		nextVersion(iavlStore)
+	}
+	require.True(t, iavlStore.VersionExists(1))
+	require.False(t, iavlStore.VersionExists(2))
+	iavlStore.releaseRetained(1)
+	nextVersion(iavlStore)
+	require.False(t, iavlStore.VersionExists(1))
+	require.False(t, iavlStore.VersionExists(4))
+	require.True(t, iavlStore.VersionExists(5))
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
@@ -348,7 +403,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 	k2, v2 := []byte("key2"), []byte("val2")
 	v3 := []byte("val3")
 
-	ksub := []byte("key")
+	ksub := cdc.MustMarshalBinary(SubspaceQueryParams{Subspace: []byte("key")})
 	KVs0 := []KVPair{}
 	KVs1 := []KVPair{
 		{k1, v1},
@@ -358,9 +413,9 @@ func TestIAVLStoreQuery(t *testing.T) {
 		{k1, v3},
 		{k2, v2},
 	}
-	valExpSubEmpty := cdc.MustMarshalBinary(KVs0)
-	valExpSub1 := cdc.MustMarshalBinary(KVs1)
-	valExpSub2 := cdc.MustMarshalBinary(KVs2)
+	valExpSubEmpty := cdc.MustMarshalBinary(SubspaceQueryResult{KVs: KVs0})
+	valExpSub1 := cdc.MustMarshalBinary(SubspaceQueryResult{KVs: KVs1})
+	valExpSub2 := cdc.MustMarshalBinary(SubspaceQueryResult{KVs: KVs2})
 
 	cid := iavlStore.Commit()
 	ver := cid.Version
@@ -394,6 +449,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, v1, qres.Value)
 
 	// and for the subspace
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub1, qres.Value)
@@ -416,7 +472,11 @@ func TestIAVLStoreQuery(t *testing.T) {
 	qres = iavlStore.Query(query2)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v2, qres.Value)
-	// and for the subspace
+	// and for the subspace, the old version is kept
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSub1, qres.Value)
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub2, qres.Value)
@@ -427,3 +487,54 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v1, qres.Value)
 }
+
+func TestIAVLStoreQuerySubspacePages(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	var KVs []KVPair
+	for i := 0; i < 5; i++ {
+		kv := KVPair{[]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("val%d", i))}
+		iavlStore.Set(kv.Key, kv.Value)
+		KVs = append(KVs, kv)
+	}
+	iavlStore.Set([]byte("other"), []byte("val"))
+	cid := iavlStore.Commit()
+
+	query := func(startKey []byte, limit int) SubspaceQueryResult {
+		params := SubspaceQueryParams{Subspace: []byte("key"), StartKey: startKey, Limit: limit}
+		req := abci.RequestQuery{Path: "/subspace", Data: cdc.MustMarshalBinary(params), Height: cid.Version, Prove: true}
+		qres := iavlStore.Query(req)
+		require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+
+		var result SubspaceQueryResult
+		cdc.MustUnmarshalBinary(qres.Value, &result)
+
+		// the pairs are proven against the root hash
+		var proof iavl.RangeProof
+		err := amino.NewCodec().UnmarshalBinary(qres.Proof, &proof)
+		require.Nil(t, err)
+		require.Nil(t, proof.Verify(cid.Hash))
+		for _, kv := range result.KVs {
+			require.Nil(t, proof.VerifyItem(kv.Key, kv.Value))
+		}
+		return result
+	}
+
+	// pages of two pairs
+	result := query(nil, 2)
+	require.Equal(t, KVs[:2], result.KVs)
+	require.Equal(t, KVs[2].Key, result.NextKey)
+	result = query(result.NextKey, 2)
+	require.Equal(t, KVs[2:4], result.KVs)
+	require.Equal(t, KVs[4].Key, result.NextKey)
+	result = query(result.NextKey, 2)
+	require.Equal(t, KVs[4:], result.KVs)
+	require.Empty(t, result.NextKey)
+
+	// no limit returns the whole subspace, up to the max limit
+	result = query(nil, 0)
+	require.Equal(t, KVs, result.KVs)
+	require.Empty(t, result.NextKey)
+}
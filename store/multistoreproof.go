@@ -0,0 +1,178 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MultiStoreProof proves a query result of a substore against the commit
+// hash of the multistore, which is the app hash of the header following
+// the queried height. The range proof links the key to the root hash of
+// the substore, and the store infos of the queried version link that root
+// hash to the commit hash.
+type MultiStoreProof struct {
+	StoreInfos []storeInfo
+	RangeProof iavl.RangeProof
+}
+
+// build the multistore proof from the amino encoded range proof returned
+// by an iavl store query
+func buildMultiStoreProof(iavlProof []byte, storeInfos []storeInfo) ([]byte, error) {
+	var rangeProof iavl.RangeProof
+	err := amino.NewCodec().UnmarshalBinary(iavlProof, &rangeProof)
+	if err != nil {
+		return nil, err
+	}
+	proof := MultiStoreProof{
+		StoreInfos: storeInfos,
+		RangeProof: rangeProof,
+	}
+	return cdc.MarshalBinary(proof)
+}
+
+// DecodeMultiStoreProof decodes the proof of a multistore query response
+func DecodeMultiStoreProof(bz []byte) (proof MultiStoreProof, err error) {
+	err = cdc.UnmarshalBinary(bz, &proof)
+	return
+}
+
+// VerifyMultiStoreCommitInfo checks that the store infos hash to the app
+// hash and returns the commit hash of the named substore
+func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHash []byte) ([]byte, error) {
+	var substoreCommitID *CommitID
+	for i := range storeInfos {
+		if storeInfos[i].Name == storeName {
+			substoreCommitID = &storeInfos[i].Core.CommitID
+		}
+	}
+	if substoreCommitID == nil {
+		return nil, fmt.Errorf("store %s is not in the commit info", storeName)
+	}
+
+	ci := commitInfo{
+		Version:    substoreCommitID.Version,
+		StoreInfos: storeInfos,
+	}
+	if !bytes.Equal(appHash, ci.Hash()) {
+		return nil, fmt.Errorf("commit info hash %X doesn't match the app hash %X", ci.Hash(), appHash)
+	}
+	return substoreCommitID.Hash, nil
+}
+
+// VerifyRangeProof checks the value of the key against the commit hash of
+// a substore, a nil value is checked to be absent
+func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+	err := rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return rangeProof.VerifyAbsence(key)
+	}
+	return rangeProof.VerifyItem(key, value)
+}
+
+// VerifyMultiStoreProof chains the range proof and the store infos of the
+// proof to check the value of the key in the named substore against the
+// app hash
+func VerifyMultiStoreProof(proof MultiStoreProof, storeName string, key, value []byte, appHash []byte) error {
+	substoreCommitHash, err := VerifyMultiStoreCommitInfo(storeName, proof.StoreInfos, appHash)
+	if err != nil {
+		return err
+	}
+	return VerifyRangeProof(key, value, substoreCommitHash, &proof.RangeProof)
+}
+
+// VerifyMultiStoreRangeProof checks the page of a subspace query of the
+// named substore against the app hash. The pairs must be proven, inside the
+// subspace, and complete: the proven keys from the start key are exactly
+// the keys of the page followed by its next key, or by the end of the
+// subspace on the last page.
+func VerifyMultiStoreRangeProof(proof MultiStoreProof, storeName string, params SubspaceQueryParams, result SubspaceQueryResult, appHash []byte) error {
+	if err := params.validate(); err != nil {
+		return err
+	}
+	substoreCommitHash, err := VerifyMultiStoreCommitInfo(storeName, proof.StoreInfos, appHash)
+	if err != nil {
+		return err
+	}
+	rangeProof := &proof.RangeProof
+	err = rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return err
+	}
+	if len(rangeProof.Leaves) == 0 {
+		return fmt.Errorf("range proof without leaves")
+	}
+
+	start, end := params.start(), sdk.PrefixEndBytes(params.Subspace)
+	expected := make([][]byte, 0, len(result.KVs)+1)
+	for _, kv := range result.KVs {
+		err = rangeProof.VerifyItem(kv.Key, kv.Value)
+		if err != nil {
+			return err
+		}
+		expected = append(expected, kv.Key)
+	}
+	if len(result.NextKey) > 0 {
+		expected = append(expected, result.NextKey)
+	}
+	for i, key := range expected {
+		if !inRange(key, start, end) {
+			return fmt.Errorf("key %X is outside the queried range of subspace %X", key, params.Subspace)
+		}
+		if i > 0 && bytes.Compare(expected[i-1], key) >= 0 {
+			return fmt.Errorf("keys of the page are not sorted")
+		}
+	}
+
+	// no key is omitted between the start key and the first key
+	if len(expected) == 0 || !bytes.Equal(expected[0], start) {
+		err = rangeProof.VerifyAbsence(start)
+		if err != nil {
+			return fmt.Errorf("start key %X not proven absent: %v", start, err)
+		}
+	}
+
+	// the proven leaves are contiguous, no key is omitted in the page
+	var proven [][]byte
+	for _, leaf := range rangeProof.Leaves {
+		if inRange(leaf.Key, start, end) {
+			proven = append(proven, leaf.Key)
+		}
+	}
+	if len(proven) < len(expected) {
+		return fmt.Errorf("%d keys of the page are not proven", len(expected)-len(proven))
+	}
+	for i := range expected {
+		if !bytes.Equal(proven[i], expected[i]) {
+			return fmt.Errorf("key %X of the page is not proven, key %X is", expected[i], proven[i])
+		}
+	}
+
+	// nothing follows the last page in the subspace, the proof reaches a
+	// key past the subspace or the end of the tree
+	if len(result.NextKey) == 0 {
+		if len(proven) > len(expected) {
+			return fmt.Errorf("key %X is omitted from the last page", proven[len(expected)])
+		}
+		last := rangeProof.Leaves[len(rangeProof.Leaves)-1].Key
+		if end == nil || bytes.Compare(last, end) < 0 {
+			err = rangeProof.VerifyAbsence(append(append([]byte{}, last...), 0x00))
+			if err != nil {
+				return fmt.Errorf("end of the subspace not proven: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// whether the key is within [start, end), a nil end being unbounded
+func inRange(key, start, end []byte) bool {
+	return bytes.Compare(key, start) >= 0 && (end == nil || bytes.Compare(key, end) < 0)
+}
@@ -25,6 +25,7 @@ const (
 type rootMultiStore struct {
 	db           dbm.DB
 	lastCommitID CommitID
+	pruning      PruningOptions
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
@@ -37,6 +38,7 @@ var _ Queryable = (*rootMultiStore)(nil)
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneDefault,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
@@ -64,6 +66,16 @@ func (rs *rootMultiStore) MountStoreWithDB(key StoreKey, typ StoreType, db dbm.D
 	rs.keysByName[key.Name()] = key
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetPruning(pruning PruningOptions) {
+	rs.pruning = pruning
+	for _, store := range rs.stores {
+		if st, ok := store.(*iavlStore); ok {
+			st.SetPruning(pruning)
+		}
+	}
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) GetCommitStore(key StoreKey) CommitStore {
 	return rs.stores[key]
@@ -117,6 +129,11 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 		newStores[key] = store
 	}
 
+	// The transient stores aren't part of the commitInfo.
+	if err := rs.loadTransientStores(newStores); err != nil {
+		return fmt.Errorf("failed to load rootMultiStore: %v", err)
+	}
+
 	// If any CommitStoreLoaders were not used, return error.
 	for key := range rs.storesParams {
 		if _, ok := newStores[key]; !ok {
@@ -173,6 +190,37 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// Implements CommitMultiStore.
+// Every substore is reloaded read-only at the requested version, unless
+// the version is the latest one in which case the live stores are used.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(ver int64) (CacheMultiStore, error) {
+	if ver == rs.lastCommitID.Version {
+		return rs.CacheMultiStore(), nil
+	}
+	if ver <= 0 || ver > rs.lastCommitID.Version {
+		return nil, fmt.Errorf("version %d does not exist, latest is %d", ver, rs.lastCommitID.Version)
+	}
+
+	cInfo, err := getCommitInfo(rs.db, ver)
+	if err != nil {
+		return nil, err
+	}
+
+	var stores = make(map[StoreKey]CommitStore)
+	for _, storeInfo := range cInfo.StoreInfos {
+		key, commitID := rs.nameToKey(storeInfo.Name), storeInfo.Core.CommitID
+		store, err := rs.loadCommitStoreFromParams(commitID, rs.storesParams[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load version %d of store %s: %v", ver, key.Name(), err)
+		}
+		stores[key] = store
+	}
+	if err := rs.loadTransientStores(stores); err != nil {
+		return nil, fmt.Errorf("failed to load version %d: %v", ver, err)
+	}
+	return newCacheMultiStoreFromStores(rs.db, stores, rs.keysByName), nil
+}
+
 // Implements MultiStore.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	return rs.stores[key]
@@ -206,7 +254,8 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Query calls substore.Query with the same `req` where `req.Path` is
 // modified to remove the substore prefix.
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
-// TODO: add proof for `multistore -> substore`.
+// If the substore returns a proof, it is wrapped into a MultiStoreProof
+// which links the substore root hash to the commit hash of the multistore.
 func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	// Query just routes this to a substore.
 	path := req.Path
@@ -229,6 +278,19 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	// trim the path and make the query
 	req.Path = subpath
 	res := queryable.Query(req)
+	if !req.Prove || res.Proof == nil {
+		return res
+	}
+
+	// add the proof of the substore root hash
+	cInfo, proofErr := getCommitInfo(rs.db, res.Height)
+	if proofErr != nil {
+		return sdk.ErrInternal(proofErr.Error()).QueryResult()
+	}
+	res.Proof, proofErr = buildMultiStoreProof(res.Proof, cInfo.StoreInfos)
+	if proofErr != nil {
+		return sdk.ErrInternal(proofErr.Error()).QueryResult()
+	}
 	return res
 }
 
@@ -251,27 +313,54 @@ func parsePath(path string) (storeName string, subpath string, err sdk.Error) {
 //----------------------------------------
 
 func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storeParams) (store CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
-	}
+	db := rs.storeDB(params)
 	switch params.typ {
 	case sdk.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
 		// TODO: id?
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
-		store, err = LoadIAVLStore(db, id)
+		store, err = LoadIAVLStore(db, id, rs.pruning)
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
+	case sdk.StoreTypeTransient:
+		_, ok := params.key.(*sdk.TransientStoreKey)
+		if !ok {
+			err = fmt.Errorf("invalid StoreKey for StoreTypeTransient: %s", params.key.String())
+			return
+		}
+		store = newTransientStore()
+		return
 	default:
 		panic(fmt.Sprintf("unrecognized store type %v", params.typ))
 	}
 }
 
+// returns the db of a store, either its own db or a prefix of the
+// multistore db
+func (rs *rootMultiStore) storeDB(params storeParams) dbm.DB {
+	if params.db != nil {
+		return dbm.NewPrefixDB(params.db, []byte("s/_/"))
+	}
+	return dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+}
+
+// adds new transient stores for the mounted transient store keys
+func (rs *rootMultiStore) loadTransientStores(stores map[StoreKey]CommitStore) error {
+	for key, storeParams := range rs.storesParams {
+		if storeParams.typ != sdk.StoreTypeTransient {
+			continue
+		}
+		store, err := rs.loadCommitStoreFromParams(CommitID{}, storeParams)
+		if err != nil {
+			return err
+		}
+		stores[key] = store
+	}
+	return nil
+}
+
 func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 	for key := range rs.storesParams {
 		if key.Name() == name {
@@ -381,6 +470,11 @@ func commitStores(version int64, storeMap map[StoreKey]CommitStore) commitInfo {
 		// Commit
 		commitID := store.Commit()
 
+		// The transient stores are wiped, their content isn't committed
+		if store.GetStoreType() == sdk.StoreTypeTransient {
+			continue
+		}
+
 		// Record CommitID
 		si := storeInfo{}
 		si.Name = key.Name()
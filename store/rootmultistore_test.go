@@ -1,6 +1,7 @@
 package store
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -152,11 +153,167 @@ func TestMultiStoreQuery(t *testing.T) {
 	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOK), sdk.ABCICodeType(qres.Code))
 	require.Nil(t, qres.Value)
 
+	// The absence of the key is proven against the commit hash.
+	proof, err := DecodeMultiStoreProof(qres.Proof)
+	require.Nil(t, err)
+	err = VerifyMultiStoreProof(proof, "store2", k, nil, cid.Hash)
+	require.Nil(t, err)
+
 	// Test store2 data.
 	query.Data = k2
 	qres = multi.Query(query)
 	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOK), sdk.ABCICodeType(qres.Code))
 	require.Equal(t, v2, qres.Value)
+
+	// The value is proven against the commit hash, but not another value,
+	// another store or another app hash.
+	proof, err = DecodeMultiStoreProof(qres.Proof)
+	require.Nil(t, err)
+	err = VerifyMultiStoreProof(proof, "store2", k2, v2, cid.Hash)
+	require.Nil(t, err)
+	err = VerifyMultiStoreProof(proof, "store2", k2, v, cid.Hash)
+	require.NotNil(t, err)
+	err = VerifyMultiStoreProof(proof, "store1", k2, v2, cid.Hash)
+	require.NotNil(t, err)
+	err = VerifyMultiStoreProof(proof, "store2", k2, v2, []byte("garbage"))
+	require.NotNil(t, err)
+}
+
+func TestMultiStoreQuerySubspaceProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	err := multi.LoadLatestVersion()
+	require.Nil(t, err)
+
+	store1 := multi.getStoreByName("store1").(KVStore)
+	var kvs []KVPair
+	for i := 0; i < 5; i++ {
+		kv := KVPair{[]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("val%d", i))}
+		store1.Set(kv.Key, kv.Value)
+		kvs = append(kvs, kv)
+	}
+	store1.Set([]byte("aaa"), []byte("before"))
+	store1.Set([]byte("zzz"), []byte("after"))
+	cid := multi.Commit()
+
+	query := func(params SubspaceQueryParams) (SubspaceQueryResult, MultiStoreProof) {
+		req := abci.RequestQuery{Path: "/store1/subspace", Data: cdc.MustMarshalBinary(params), Height: cid.Version, Prove: true}
+		qres := multi.Query(req)
+		require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+		var result SubspaceQueryResult
+		cdc.MustUnmarshalBinary(qres.Value, &result)
+		proof, err := DecodeMultiStoreProof(qres.Proof)
+		require.Nil(t, err)
+		return result, proof
+	}
+
+	// a page is proven with its next key, but not a page omitting a pair
+	params := SubspaceQueryParams{Subspace: []byte("key"), Limit: 2}
+	result, proof := query(params)
+	require.Equal(t, kvs[:2], result.KVs)
+	require.Equal(t, kvs[2].Key, result.NextKey)
+	require.Nil(t, VerifyMultiStoreRangeProof(proof, "store1", params, result, cid.Hash))
+	omitted := SubspaceQueryResult{KVs: kvs[:1], NextKey: kvs[2].Key}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, omitted, cid.Hash))
+	omitted = SubspaceQueryResult{KVs: []KVPair{kvs[0], kvs[2]}, NextKey: kvs[3].Key}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, omitted, cid.Hash))
+	truncated := SubspaceQueryResult{KVs: kvs[:2]}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, truncated, cid.Hash))
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store2", params, result, cid.Hash))
+
+	// the whole subspace is proven up to its end, but not with pairs
+	// outside of it
+	params = SubspaceQueryParams{Subspace: []byte("key")}
+	result, proof = query(params)
+	require.Equal(t, kvs, result.KVs)
+	require.Empty(t, result.NextKey)
+	require.Nil(t, VerifyMultiStoreRangeProof(proof, "store1", params, result, cid.Hash))
+	outside := SubspaceQueryResult{KVs: append([]KVPair{{[]byte("aaa"), []byte("before")}}, kvs...)}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, outside, cid.Hash))
+	outside = SubspaceQueryResult{KVs: append(append([]KVPair{}, kvs...), KVPair{[]byte("zzz"), []byte("after")})}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, outside, cid.Hash))
+	truncated = SubspaceQueryResult{KVs: kvs[:4]}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, truncated, cid.Hash))
+
+	// an absent start key is proven absent, the page can't skip the keys
+	// following it
+	params = SubspaceQueryParams{Subspace: []byte("key"), StartKey: []byte("key1a")}
+	result, proof = query(params)
+	require.Equal(t, kvs[2:], result.KVs)
+	require.Nil(t, VerifyMultiStoreRangeProof(proof, "store1", params, result, cid.Hash))
+	skipped := SubspaceQueryResult{KVs: kvs[3:]}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", params, skipped, cid.Hash))
+	earlier := SubspaceQueryParams{Subspace: []byte("key"), StartKey: []byte("key0")}
+	require.NotNil(t, VerifyMultiStoreRangeProof(proof, "store1", earlier, result, cid.Hash))
+
+	// start keys outside the subspace are rejected
+	for _, startKey := range []string{"aaa", "zzz"} {
+		params = SubspaceQueryParams{Subspace: []byte("key"), StartKey: []byte(startKey)}
+		req := abci.RequestQuery{Path: "/store1/subspace", Data: cdc.MustMarshalBinary(params), Height: cid.Version, Prove: true}
+		qres := multi.Query(req)
+		require.NotEqual(t, uint32(sdk.CodeOK), qres.Code)
+	}
+}
+
+func TestMultiStorePruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	multi.SetPruning(sdk.PruneEverything)
+	err := multi.LoadLatestVersion()
+	require.Nil(t, err)
+
+	// only the latest version of every IAVL store is kept
+	for i := 0; i < 3; i++ {
+		multi.Commit()
+	}
+	for _, store := range multi.stores {
+		require.True(t, store.(*iavlStore).VersionExists(3))
+		require.False(t, store.(*iavlStore).VersionExists(2))
+	}
+
+	// the pruning is applied to the loaded stores
+	multi.SetPruning(sdk.PruneNothing)
+	for i := 0; i < 3; i++ {
+		multi.Commit()
+	}
+	for _, store := range multi.stores {
+		for ver := int64(3); ver <= 6; ver++ {
+			require.True(t, store.(*iavlStore).VersionExists(ver))
+		}
+	}
+}
+
+func TestMultiStoreTransient(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	tkey := sdk.NewTransientStoreKey("transient")
+	multi.MountStoreWithDB(tkey, sdk.StoreTypeTransient, nil)
+	err := multi.LoadLatestVersion()
+	require.Nil(t, err)
+
+	k, v := []byte("wind"), []byte("blows")
+	multi.GetKVStore(tkey).Set(k, v)
+
+	// the transient store is wiped and doesn't change the commit hash
+	cid := multi.Commit()
+	require.Nil(t, multi.GetKVStore(tkey).Get(k))
+	require.Equal(t, hashStores(withoutTransientStores(multi.stores)), cid.Hash)
+
+	// the transient store is mounted again on load, but isn't a committed store
+	multi.GetKVStore(tkey).Set(k, v)
+	multi = newMultiStoreWithMounts(db)
+	multi.MountStoreWithDB(tkey, sdk.StoreTypeTransient, nil)
+	err = multi.LoadLatestVersion()
+	require.Nil(t, err)
+	require.Nil(t, multi.GetKVStore(tkey).Get(k))
+	cInfo, err := getCommitInfo(db, cid.Version)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(cInfo.StoreInfos))
+
+	// a transient store must be mounted with a transient key
+	multi = newMultiStoreWithMounts(db)
+	multi.MountStoreWithDB(sdk.NewKVStoreKey("transient"), sdk.StoreTypeTransient, nil)
+	require.NotNil(t, multi.LoadLatestVersion())
 }
 
 //-----------------------------------------------------------------------
@@ -200,3 +357,13 @@ func hashStores(stores map[StoreKey]CommitStore) []byte {
 	}
 	return merkle.SimpleHashFromMap(m)
 }
+
+func withoutTransientStores(stores map[StoreKey]CommitStore) map[StoreKey]CommitStore {
+	committed := make(map[StoreKey]CommitStore, len(stores))
+	for key, store := range stores {
+		if store.GetStoreType() != sdk.StoreTypeTransient {
+			committed[key] = store
+		}
+	}
+	return committed
+}
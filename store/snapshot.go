@@ -0,0 +1,453 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// number of pairs of the range proofs used to verify a restored iavl store
+	snapshotVerifyPageSize = 1000
+
+	// keys of the nodes and of the roots of the versions in the db of an
+	// iavl tree
+	iavlNodeKeyFmt = "n/%X"
+	iavlRootKeyFmt = "r/%010d"
+)
+
+// snapshotItem is a node of the iavl tree of a store at the snapshot version,
+// a leaf holding a key/value pair or an inner node. A snapshot of the
+// multistore is the stream of the nodes of all its stores, ordered by store
+// name, each tree in post-order.
+// The IAVL hashes commit to the version of every node, so the nodes keep
+// their version for the tree to be rebuilt with the same root hash.
+type snapshotItem struct {
+	Store   string
+	Height  int8 // 0 for a leaf
+	Version int64
+	Key     []byte // leaves only
+	Value   []byte // leaves only
+}
+
+//----------------------------------------
+
+// snapshotView is a read-only view of the iavl stores of the multistore at a
+// committed version. The version of the stores is retained, it isn't pruned
+// until the view is closed, so the view can be exported while the
+// multistore keeps committing.
+type snapshotView struct {
+	commitID CommitID
+	stores   []snapshotViewStore
+}
+
+type snapshotViewStore struct {
+	name  string
+	store *iavlStore
+	db    dbm.DB
+	root  []byte
+}
+
+// Returns a view of the stores at a committed version, which must be closed.
+// The transient stores aren't part of the snapshots.
+// CONTRACT: must be called from the committing goroutine.
+func (rs *rootMultiStore) snapshotView(version int64) (*snapshotView, error) {
+	if version <= 0 || version > rs.lastCommitID.Version {
+		return nil, fmt.Errorf("version %d isn't committed, latest is %d", version, rs.lastCommitID.Version)
+	}
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return nil, err
+	}
+	roots := make(map[string][]byte, len(cInfo.StoreInfos))
+	for _, si := range cInfo.StoreInfos {
+		roots[si.Name] = si.Core.CommitID.Hash
+	}
+
+	view := &snapshotView{commitID: cInfo.CommitID()}
+	for _, params := range rs.snapshotStoresParams() {
+		name := params.key.Name()
+		st, ok := rs.stores[params.key].(*iavlStore)
+		if !ok {
+			view.Close()
+			return nil, fmt.Errorf("snapshots of store %s of type %v aren't supported", name, params.typ)
+		}
+		root, ok := roots[name]
+		if !ok {
+			view.Close()
+			return nil, fmt.Errorf("store %s isn't part of version %d", name, version)
+		}
+		err = st.retain(version)
+		if err != nil {
+			view.Close()
+			return nil, fmt.Errorf("failed to retain store %s: %v", name, err)
+		}
+		view.stores = append(view.stores, snapshotViewStore{
+			name:  name,
+			store: st,
+			db:    rs.storeDB(params),
+			root:  root,
+		})
+	}
+	return view, nil
+}
+
+// Export streams the nodes of the trees of the stores at the version of the
+// view, ordered by store name, each tree in post-order.
+func (view *snapshotView) Export(emit func(snapshotItem) error) error {
+	for _, vs := range view.stores {
+		if len(vs.root) == 0 {
+			// empty tree
+			continue
+		}
+		err := exportIAVLNode(vs.db, vs.name, vs.root, emit)
+		if err != nil {
+			return fmt.Errorf("failed to export store %s: %v", vs.name, err)
+		}
+	}
+	return nil
+}
+
+// Close releases the version of the stores.
+func (view *snapshotView) Close() {
+	for _, vs := range view.stores {
+		vs.store.releaseRetained(view.commitID.Version)
+	}
+	view.stores = nil
+}
+
+// emits the children of a node before the node
+func exportIAVLNode(db dbm.DB, store string, hash []byte, emit func(snapshotItem) error) error {
+	bz := db.Get([]byte(fmt.Sprintf(iavlNodeKeyFmt, hash)))
+	if bz == nil {
+		return fmt.Errorf("missing node %X", hash)
+	}
+	node, err := decodeIAVLNode(bz)
+	if err != nil {
+		return fmt.Errorf("invalid node %X: %v", hash, err)
+	}
+
+	item := snapshotItem{
+		Store:   store,
+		Height:  node.height,
+		Version: node.version,
+	}
+	if node.height == 0 {
+		item.Key, item.Value = node.key, node.value
+	} else {
+		err = exportIAVLNode(db, store, node.leftHash, emit)
+		if err == nil {
+			err = exportIAVLNode(db, store, node.rightHash, emit)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return emit(item)
+}
+
+//----------------------------------------
+
+// Restore rebuilds the iavl trees of the stores at the height from the
+// nodes of a snapshot, returned by next until it returns nil, and loads the
+// version if the commit hash of the rebuilt stores is the trusted app hash
+// of the height. The content of every IAVL store is then verified against
+// its root hash.
+// The multistore must be empty, it is left in an undefined state on error.
+func (rs *rootMultiStore) Restore(height int64, appHash []byte, next func() (*snapshotItem, error)) error {
+	if rs.lastCommitID.Version != 0 || getLatestVersion(rs.db) != 0 {
+		return fmt.Errorf("can't restore a snapshot into a non empty multistore")
+	}
+
+	paramsList := rs.snapshotStoresParams()
+	builders := make(map[string]*iavlTreeBuilder, len(paramsList))
+	for _, params := range paramsList {
+		db := rs.storeDB(params)
+		itr := db.Iterator(nil, nil)
+		empty := !itr.Valid()
+		itr.Close()
+		if !empty {
+			return fmt.Errorf("can't restore a snapshot into the non empty store %s", params.key.Name())
+		}
+		builders[params.key.Name()] = &iavlTreeBuilder{db: db}
+	}
+	for {
+		item, err := next()
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			break
+		}
+		builder, ok := builders[item.Store]
+		if !ok {
+			return fmt.Errorf("snapshot of unknown store %s", item.Store)
+		}
+		err = builder.add(*item)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot of store %s: %v", item.Store, err)
+		}
+	}
+
+	// check the rebuilt roots against the app hash
+	storeInfos := make([]storeInfo, 0, len(paramsList))
+	for _, params := range paramsList {
+		root, err := builders[params.key.Name()].finish(height)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot of store %s: %v", params.key.Name(), err)
+		}
+		si := storeInfo{}
+		si.Name = params.key.Name()
+		si.Core.CommitID = CommitID{Version: height, Hash: root}
+		storeInfos = append(storeInfos, si)
+	}
+	cInfo := commitInfo{
+		Version:    height,
+		StoreInfos: storeInfos,
+	}
+	if !bytes.Equal(cInfo.Hash(), appHash) {
+		return fmt.Errorf("commit hash %X of the restored stores doesn't match the app hash %X", cInfo.Hash(), appHash)
+	}
+
+	// load and verify the stores
+	for _, params := range paramsList {
+		store, err := rs.loadCommitStoreFromParams(CommitID{Version: height}, params)
+		if err != nil {
+			return fmt.Errorf("failed to load store %s: %v", params.key.Name(), err)
+		}
+		err = verifyIAVLStore(store.(*iavlStore), height)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot of store %s: %v", params.key.Name(), err)
+		}
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, height, cInfo)
+	setLatestVersion(batch, height)
+	batch.Write()
+	return rs.LoadVersion(height)
+}
+
+// returns the params of the stores which are part of the snapshots, sorted
+// by name
+func (rs *rootMultiStore) snapshotStoresParams() []storeParams {
+	paramsList := make([]storeParams, 0, len(rs.storesParams))
+	for _, params := range rs.storesParams {
+		if params.typ == sdk.StoreTypeTransient {
+			continue
+		}
+		paramsList = append(paramsList, params)
+	}
+	sort.Slice(paramsList, func(i, j int) bool {
+		return paramsList[i].key.Name() < paramsList[j].key.Name()
+	})
+	return paramsList
+}
+
+// verifies all the pairs of a version of an iavl store against its root
+// hash, by pages of range proofs
+func verifyIAVLStore(st *iavlStore, version int64) error {
+	root := st.LastCommitID().Hash
+	if root == nil {
+		// empty tree
+		return nil
+	}
+
+	var start []byte
+	for {
+		keys, values, proof, err := st.tree.GetVersionedRangeWithProof(start, nil, snapshotVerifyPageSize, version)
+		if err != nil {
+			return err
+		}
+		err = proof.Verify(root)
+		if err != nil {
+			return err
+		}
+		for i := range keys {
+			err = proof.VerifyItem(keys[i], values[i])
+			if err != nil {
+				return err
+			}
+		}
+		if len(keys) < snapshotVerifyPageSize {
+			return nil
+		}
+		// the next page starts right after the last key
+		start = append(append([]byte{}, keys[len(keys)-1]...), 0x00)
+	}
+}
+
+//----------------------------------------
+// iavl nodes
+
+// iavlNode is a node of an iavl tree, encoded and hashed like the iavl
+// package does
+type iavlNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte // smallest key of the right subtree of an inner node
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+func decodeIAVLNode(bz []byte) (node iavlNode, err error) {
+	var n int
+	node.height, n, err = amino.DecodeInt8(bz)
+	if err == nil {
+		bz = bz[n:]
+		node.size, n, err = amino.DecodeVarint(bz)
+	}
+	if err == nil {
+		bz = bz[n:]
+		node.version, n, err = amino.DecodeVarint(bz)
+	}
+	if err == nil {
+		bz = bz[n:]
+		node.key, n, err = amino.DecodeByteSlice(bz)
+	}
+	if err != nil {
+		return node, err
+	}
+	bz = bz[n:]
+	if node.height == 0 {
+		node.value, _, err = amino.DecodeByteSlice(bz)
+		return node, err
+	}
+	node.leftHash, n, err = amino.DecodeByteSlice(bz)
+	if err == nil {
+		node.rightHash, _, err = amino.DecodeByteSlice(bz[n:])
+	}
+	return node, err
+}
+
+// the encoding of the node in the db, the writes to a buffer don't fail
+func (node iavlNode) bytes() []byte {
+	var buf bytes.Buffer
+	_ = amino.EncodeInt8(&buf, node.height)
+	_ = amino.EncodeVarint(&buf, node.size)
+	_ = amino.EncodeVarint(&buf, node.version)
+	_ = amino.EncodeByteSlice(&buf, node.key)
+	if node.height == 0 {
+		_ = amino.EncodeByteSlice(&buf, node.value)
+	} else {
+		_ = amino.EncodeByteSlice(&buf, node.leftHash)
+		_ = amino.EncodeByteSlice(&buf, node.rightHash)
+	}
+	return buf.Bytes()
+}
+
+// the hash of the node, which commits to the hash of the value of a leaf
+// but not to the key of an inner node
+func (node iavlNode) hash() []byte {
+	var buf bytes.Buffer
+	_ = amino.EncodeInt8(&buf, node.height)
+	_ = amino.EncodeVarint(&buf, node.size)
+	_ = amino.EncodeVarint(&buf, node.version)
+	if node.height == 0 {
+		_ = amino.EncodeByteSlice(&buf, node.key)
+		_ = amino.EncodeByteSlice(&buf, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(&buf, node.leftHash)
+		_ = amino.EncodeByteSlice(&buf, node.rightHash)
+	}
+	return tmhash.Sum(buf.Bytes())
+}
+
+// iavlTreeBuilder rebuilds an iavl tree in an empty db from its nodes in
+// post-order, the hashes of the nodes are computed from their content
+type iavlTreeBuilder struct {
+	db dbm.DB
+	// the subtrees whose parent isn't added yet
+	stack []builtSubtree
+}
+
+type builtSubtree struct {
+	node   iavlNode
+	hash   []byte
+	minKey []byte
+	maxKey []byte
+}
+
+func (b *iavlTreeBuilder) add(item snapshotItem) error {
+	if item.Version <= 0 {
+		return fmt.Errorf("invalid node version %d", item.Version)
+	}
+
+	var subtree builtSubtree
+	switch {
+	case item.Height < 0:
+		return fmt.Errorf("invalid node height %d", item.Height)
+	case item.Height == 0:
+		if len(item.Key) == 0 {
+			return fmt.Errorf("leaf without a key")
+		}
+		subtree.node = iavlNode{
+			height:  0,
+			size:    1,
+			version: item.Version,
+			key:     item.Key,
+			value:   item.Value,
+		}
+		subtree.minKey, subtree.maxKey = item.Key, item.Key
+	default:
+		if len(b.stack) < 2 {
+			return fmt.Errorf("inner node without children")
+		}
+		left, right := b.stack[len(b.stack)-2], b.stack[len(b.stack)-1]
+		b.stack = b.stack[:len(b.stack)-2]
+		if bytes.Compare(left.maxKey, right.minKey) >= 0 {
+			return fmt.Errorf("unordered keys %X and %X", left.maxKey, right.minKey)
+		}
+		height := left.node.height
+		if right.node.height > height {
+			height = right.node.height
+		}
+		if item.Height != height+1 {
+			return fmt.Errorf("inner node of height %d above nodes of height %d and %d", item.Height, left.node.height, right.node.height)
+		}
+		subtree.node = iavlNode{
+			height:    item.Height,
+			size:      left.node.size + right.node.size,
+			version:   item.Version,
+			key:       right.minKey,
+			leftHash:  left.hash,
+			rightHash: right.hash,
+		}
+		subtree.minKey, subtree.maxKey = left.minKey, right.maxKey
+	}
+
+	subtree.hash = subtree.node.hash()
+	b.db.Set([]byte(fmt.Sprintf(iavlNodeKeyFmt, subtree.hash)), subtree.node.bytes())
+	b.stack = append(b.stack, subtree)
+	return nil
+}
+
+// saves the root of the rebuilt tree as the version and returns its hash
+func (b *iavlTreeBuilder) finish(version int64) ([]byte, error) {
+	var root []byte
+	switch len(b.stack) {
+	case 0:
+		// empty tree
+		root = []byte{}
+	case 1:
+		if b.stack[0].node.version > version {
+			return nil, fmt.Errorf("root of version %d after the snapshot version %d", b.stack[0].node.version, version)
+		}
+		root = b.stack[0].hash
+	default:
+		return nil, fmt.Errorf("%d nodes without a parent", len(b.stack))
+	}
+	b.db.Set([]byte(fmt.Sprintf(iavlRootKeyFmt, version)), root)
+	if len(root) == 0 {
+		return nil, nil
+	}
+	return root, nil
+}
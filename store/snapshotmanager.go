@@ -0,0 +1,360 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+const (
+	// size from which a new chunk is started, a chunk holds whole nodes so
+	// it exceeds this size by at most one node
+	defaultSnapshotChunkSize = 10 << 20
+
+	snapshotMetadataFile = "metadata.json"
+)
+
+// SnapshotOptions are the options of the periodic snapshots of the
+// multistore state
+type SnapshotOptions struct {
+	Interval   int64 // height interval of the snapshots, none if 0
+	KeepRecent int   // number of recent snapshots kept, all of them if 0
+}
+
+// Snapshot describes a snapshot of the multistore state at a height, the
+// chunks are checked against their hash before being restored. The commit
+// hash is informative, a snapshot is restored against a trusted app hash.
+type Snapshot struct {
+	Height      int64          `json:"height"`
+	Hash        cmn.HexBytes   `json:"hash"` // commit hash of the multistore
+	ChunkHashes []cmn.HexBytes `json:"chunk_hashes"`
+}
+
+// SnapshotManager takes snapshots of the multistore state into size bounded
+// chunk files, one directory per height, and restores them.
+// The periodic snapshots are taken in the background from a view of the
+// committed height, one at a time, while the multistore keeps committing.
+type SnapshotManager struct {
+	multistore *rootMultiStore
+	dir        string
+	options    SnapshotOptions
+	chunkSize  int
+	logger     log.Logger
+
+	mtx    sync.Mutex
+	taking bool           // whether a snapshot is being taken
+	wg     sync.WaitGroup // done when the background snapshot is
+}
+
+// NewSnapshotManager creates a manager of the snapshots of the multistore in
+// the directory, the multistore must be a root multistore
+func NewSnapshotManager(ms CommitMultiStore, dir string, options SnapshotOptions) *SnapshotManager {
+	rs, ok := ms.(*rootMultiStore)
+	if !ok {
+		panic(fmt.Sprintf("snapshots of a %T aren't supported", ms))
+	}
+	return &SnapshotManager{
+		multistore: rs,
+		dir:        dir,
+		options:    options,
+		chunkSize:  defaultSnapshotChunkSize,
+		logger:     log.NewNopLogger(),
+	}
+}
+
+// SetLogger sets the logger of the errors of the background snapshots
+func (m *SnapshotManager) SetLogger(logger log.Logger) {
+	m.logger = logger
+}
+
+// SnapshotIfDue starts taking a snapshot of a committed height in the
+// background if it is a multiple of the snapshot interval, the older
+// snapshots are pruned once it is taken. It fails if the previous snapshot
+// is still being taken.
+// CONTRACT: must be called from the committing goroutine, right after the
+// height is committed.
+func (m *SnapshotManager) SnapshotIfDue(height int64) error {
+	if m.options.Interval <= 0 || height%m.options.Interval != 0 {
+		return nil
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.taking {
+		return fmt.Errorf("the previous snapshot is still being taken")
+	}
+	view, err := m.multistore.snapshotView(height)
+	if err != nil {
+		return err
+	}
+	m.taking = true
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+		_, err := m.create(view)
+		if err == nil {
+			err = m.prune()
+		}
+		if err != nil {
+			m.logger.Error("Failed to take a state snapshot", "height", height, "err", err)
+		}
+		m.mtx.Lock()
+		m.taking = false
+		m.mtx.Unlock()
+	}()
+	return nil
+}
+
+// Wait waits for the snapshot being taken in the background, if any
+func (m *SnapshotManager) Wait() {
+	m.wg.Wait()
+}
+
+// Create takes a snapshot of the latest version of the multistore
+func (m *SnapshotManager) Create() (snapshot Snapshot, err error) {
+	view, err := m.multistore.snapshotView(m.multistore.LastCommitID().Version)
+	if err != nil {
+		return snapshot, err
+	}
+	return m.create(view)
+}
+
+// takes the snapshot of a view, and closes it
+func (m *SnapshotManager) create(view *snapshotView) (snapshot Snapshot, err error) {
+	defer view.Close()
+
+	// the background and offline snapshots of a height don't share a
+	// directory
+	tmpDir := filepath.Join(m.dir, fmt.Sprintf("tmp-%d", view.commitID.Version))
+	err = os.RemoveAll(tmpDir)
+	if err == nil {
+		err = os.MkdirAll(tmpDir, 0755)
+	}
+	if err != nil {
+		return snapshot, err
+	}
+
+	cw := &chunkWriter{dir: tmpDir, chunkSize: m.chunkSize}
+	err = view.Export(cw.write)
+	if err == nil {
+		err = cw.flush()
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return snapshot, err
+	}
+
+	snapshot = Snapshot{
+		Height:      view.commitID.Version,
+		Hash:        view.commitID.Hash,
+		ChunkHashes: cw.hashes,
+	}
+	bz, err := wire.MarshalJSONIndent(cdc, snapshot)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(tmpDir, snapshotMetadataFile), bz, 0644)
+	}
+	if err == nil {
+		// replace a previous snapshot of the height
+		snapshotDir := m.snapshotDir(snapshot.Height)
+		err = os.RemoveAll(snapshotDir)
+		if err == nil {
+			err = os.Rename(tmpDir, snapshotDir)
+		}
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// List returns the snapshots of the manager directory
+func (m *SnapshotManager) List() ([]Snapshot, error) {
+	return ListSnapshots(m.dir)
+}
+
+// Restore rebuilds the empty multistore from the snapshot of a height, the
+// chunks are checked against their hash and the restored stores against
+// the trusted app hash of the height, the one of the header of the next
+// height
+func (m *SnapshotManager) Restore(height int64, appHash []byte) error {
+	snapshot, err := readSnapshot(m.snapshotDir(height))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(snapshot.Hash, appHash) {
+		return fmt.Errorf("snapshot hash %X doesn't match the app hash %X", snapshot.Hash, appHash)
+	}
+
+	cr := &chunkReader{dir: m.snapshotDir(height), hashes: snapshot.ChunkHashes}
+	return m.multistore.Restore(snapshot.Height, appHash, cr.next)
+}
+
+// delete the oldest snapshots, keeping the most recent ones
+func (m *SnapshotManager) prune() error {
+	if m.options.KeepRecent <= 0 {
+		return nil
+	}
+	snapshots, err := m.List()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(snapshots)-m.options.KeepRecent; i++ {
+		err = os.RemoveAll(m.snapshotDir(snapshots[i].Height))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *SnapshotManager) snapshotDir(height int64) string {
+	return filepath.Join(m.dir, strconv.FormatInt(height, 10))
+}
+
+// ListSnapshots returns the snapshots of a directory ordered by height
+func ListSnapshots(dir string) ([]Snapshot, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, file := range files {
+		// skip the snapshot being taken
+		if _, err := strconv.ParseInt(file.Name(), 10, 64); err != nil || !file.IsDir() {
+			continue
+		}
+		snapshot, err := readSnapshot(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Height < snapshots[j].Height
+	})
+	return snapshots, nil
+}
+
+func readSnapshot(snapshotDir string) (snapshot Snapshot, err error) {
+	bz, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotMetadataFile))
+	if err != nil {
+		return snapshot, err
+	}
+	err = cdc.UnmarshalJSON(bz, &snapshot)
+	if err != nil {
+		return snapshot, fmt.Errorf("invalid snapshot metadata in %s: %v", snapshotDir, err)
+	}
+	return snapshot, nil
+}
+
+func chunkFile(dir string, index int) string {
+	return filepath.Join(dir, strconv.Itoa(index))
+}
+
+//----------------------------------------
+
+// chunkWriter writes the nodes of a snapshot into chunk files of whole
+// length prefixed nodes, and records their hash
+type chunkWriter struct {
+	dir       string
+	chunkSize int
+	buf       bytes.Buffer
+	hashes    []cmn.HexBytes
+}
+
+func (cw *chunkWriter) write(item snapshotItem) error {
+	bz, err := cdc.MarshalBinaryBare(item)
+	if err != nil {
+		return err
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(bz)))
+	cw.buf.Write(prefix[:n])
+	cw.buf.Write(bz)
+
+	if cw.buf.Len() >= cw.chunkSize {
+		return cw.flush()
+	}
+	return nil
+}
+
+// writes the buffered nodes to the next chunk file
+func (cw *chunkWriter) flush() error {
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	err := ioutil.WriteFile(chunkFile(cw.dir, len(cw.hashes)), cw.buf.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(cw.buf.Bytes())
+	cw.hashes = append(cw.hashes, hash[:])
+	cw.buf.Reset()
+	return nil
+}
+
+// chunkReader reads the nodes of the chunk files of a snapshot, checking
+// every chunk against its hash before decoding it
+type chunkReader struct {
+	dir    string
+	hashes []cmn.HexBytes
+	index  int
+	chunk  *bytes.Reader
+}
+
+// returns the next node, nil after the last one
+func (cr *chunkReader) next() (*snapshotItem, error) {
+	for cr.chunk == nil || cr.chunk.Len() == 0 {
+		if cr.index == len(cr.hashes) {
+			return nil, nil
+		}
+		bz, err := ioutil.ReadFile(chunkFile(cr.dir, cr.index))
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(bz)
+		if !bytes.Equal(hash[:], cr.hashes[cr.index]) {
+			return nil, fmt.Errorf("hash of chunk %d doesn't match the snapshot metadata", cr.index)
+		}
+		cr.chunk = bytes.NewReader(bz)
+		cr.index++
+	}
+
+	size, err := binary.ReadUvarint(cr.chunk)
+	if err != nil {
+		return nil, err
+	}
+	if size > uint64(cr.chunk.Len()) {
+		return nil, fmt.Errorf("truncated node in chunk %d", cr.index-1)
+	}
+	bz := make([]byte, size)
+	_, err = io.ReadFull(cr.chunk, bz)
+	if err != nil {
+		return nil, err
+	}
+	var item snapshotItem
+	err = cdc.UnmarshalBinaryBare(bz, &item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func newMultiStoreForSnapshots(db dbm.DB) *rootMultiStore {
+	store := NewCommitMultiStore(db)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewTransientStoreKey("transient"), sdk.StoreTypeTransient, nil)
+	return store
+}
+
+func TestSnapshotManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	multi := newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	manager := NewSnapshotManager(multi, dir, SnapshotOptions{Interval: 2, KeepRecent: 2})
+	manager.chunkSize = 100
+
+	// nothing to snapshot before the first commit
+	_, err = manager.Create()
+	require.NotNil(t, err)
+
+	// a snapshot is taken in the background every 2 heights, the 2 latest
+	// ones are kept
+	var cid6 CommitID
+	for h := int64(1); h <= 6; h++ {
+		store1 := multi.getStoreByName("store1").(KVStore)
+		store2 := multi.getStoreByName("store2").(KVStore)
+		for i := 0; i < 5; i++ {
+			store1.Set([]byte(fmt.Sprintf("key%d-%d", h, i)), []byte(fmt.Sprintf("value%d", i)))
+		}
+		store1.Delete([]byte(fmt.Sprintf("key%d-0", h-1)))
+		store2.Set([]byte("height"), []byte(fmt.Sprintf("%d", h)))
+		cid6 = multi.Commit()
+		require.Nil(t, manager.SnapshotIfDue(h))
+		manager.Wait()
+	}
+	snapshots, err := manager.List()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(snapshots))
+	require.Equal(t, int64(4), snapshots[0].Height)
+	require.Equal(t, int64(6), snapshots[1].Height)
+	require.Equal(t, cid6.Hash, []byte(snapshots[1].Hash))
+	require.True(t, len(snapshots[1].ChunkHashes) > 1, "the snapshot is split into chunks")
+
+	// a single snapshot is taken at a time
+	manager.taking = true
+	require.NotNil(t, manager.SnapshotIfDue(8))
+	manager.taking = false
+
+	// the snapshot of a height is the state of the height, its version isn't
+	// pruned while the multistore keeps committing
+	store2 := multi.getStoreByName("store2").(KVStore)
+	store2.Set([]byte("height"), []byte("7"))
+	cid7 := multi.Commit()
+	store2.Set([]byte("height"), []byte("8"))
+	cid8 := multi.Commit()
+	require.Nil(t, manager.SnapshotIfDue(8))
+	store2.Set([]byte("height"), []byte("9"))
+	multi.Commit()
+	manager.Wait()
+	snapshots, err = manager.List()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(snapshots))
+	require.Equal(t, int64(8), snapshots[1].Height)
+	require.Equal(t, cid8.Hash, []byte(snapshots[1].Hash))
+
+	// a snapshot is only restored against the app hash of its height
+	restored := newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, restored.LoadLatestVersion())
+	restoredManager := NewSnapshotManager(restored, dir, SnapshotOptions{})
+	require.NotNil(t, restoredManager.Restore(6, cid8.Hash))
+
+	// restore the snapshot of height 6 into a new multistore
+	restored = newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, restored.LoadLatestVersion())
+	restoredManager = NewSnapshotManager(restored, dir, SnapshotOptions{})
+	require.Nil(t, restoredManager.Restore(6, cid6.Hash))
+	require.Equal(t, cid6, restored.LastCommitID())
+	store1 := restored.getStoreByName("store1").(KVStore)
+	require.Equal(t, []byte("value3"), store1.Get([]byte("key2-3")))
+	require.Nil(t, store1.Get([]byte("key5-0")))
+	restoredStore2 := restored.getStoreByName("store2").(KVStore)
+	require.Equal(t, []byte("6"), restoredStore2.Get([]byte("height")))
+
+	// the restored multistore keeps committing the same hashes
+	restoredStore2.Set([]byte("height"), []byte("7"))
+	require.Equal(t, cid7, restored.Commit())
+
+	// only an empty multistore can be restored
+	require.NotNil(t, restoredManager.Restore(8, cid8.Hash))
+
+	// the snapshot taken while committing restores the state of its height
+	restored = newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, restored.LoadLatestVersion())
+	require.Nil(t, NewSnapshotManager(restored, dir, SnapshotOptions{}).Restore(8, cid8.Hash))
+	require.Equal(t, cid8, restored.LastCommitID())
+	require.Equal(t, []byte("8"), restored.getStoreByName("store2").(KVStore).Get([]byte("height")))
+}
+
+func TestSnapshotManagerInvalidChunk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	multi := newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	multi.getStoreByName("store1").(KVStore).Set([]byte("key"), []byte("value"))
+	multi.Commit()
+	manager := NewSnapshotManager(multi, dir, SnapshotOptions{})
+	snapshot, err := manager.Create()
+	require.Nil(t, err)
+	require.Equal(t, 1, len(snapshot.ChunkHashes))
+
+	// tamper with the chunk
+	chunk := chunkFile(manager.snapshotDir(snapshot.Height), 0)
+	bz, err := ioutil.ReadFile(chunk)
+	require.Nil(t, err)
+	bz[len(bz)-1]++
+	require.Nil(t, ioutil.WriteFile(chunk, bz, 0644))
+
+	restored := newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, restored.LoadLatestVersion())
+	err = NewSnapshotManager(restored, dir, SnapshotOptions{}).Restore(snapshot.Height, snapshot.Hash)
+	require.NotNil(t, err)
+
+	// the rebuilt stores don't match the app hash even if the chunk hash of
+	// the metadata is updated
+	hash := sha256.Sum256(bz)
+	snapshot.ChunkHashes[0] = hash[:]
+	metadata, err := wire.MarshalJSONIndent(cdc, snapshot)
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile(filepath.Join(manager.snapshotDir(snapshot.Height), snapshotMetadataFile), metadata, 0644))
+	restored = newMultiStoreForSnapshots(dbm.NewMemDB())
+	require.Nil(t, restored.LoadLatestVersion())
+	err = NewSnapshotManager(restored, dir, SnapshotOptions{}).Restore(snapshot.Height, snapshot.Hash)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "doesn't match the app hash")
+}
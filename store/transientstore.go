@@ -0,0 +1,39 @@
+package store
+
+import (
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ KVStore = (*transientStore)(nil)
+var _ CommitStore = (*transientStore)(nil)
+
+// transientStore is a KVStore backed by an in-memory DB, wiped on every
+// Commit. It holds the state which only matters within a block, its content
+// isn't part of the multistore commit hash.
+type transientStore struct {
+	dbStoreAdapter
+}
+
+// Constructs a new empty transientStore.
+func newTransientStore() *transientStore {
+	return &transientStore{dbStoreAdapter{dbm.NewMemDB()}}
+}
+
+// Implements Store.
+func (ts *transientStore) GetStoreType() StoreType {
+	return sdk.StoreTypeTransient
+}
+
+// Implements Committer.
+// The content of the store is dropped, the commit ID is always empty.
+func (ts *transientStore) Commit() (id CommitID) {
+	ts.dbStoreAdapter = dbStoreAdapter{dbm.NewMemDB()}
+	return
+}
+
+// Implements Committer.
+func (ts *transientStore) LastCommitID() (id CommitID) {
+	return
+}
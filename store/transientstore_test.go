@@ -0,0 +1,28 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransientStore(t *testing.T) {
+	tstore := newTransientStore()
+	k, v := []byte("hello"), []byte("world")
+
+	require.Nil(t, tstore.Get(k))
+	tstore.Set(k, v)
+	require.Equal(t, v, tstore.Get(k))
+
+	// the cache wraps write to the current content
+	cstore := tstore.CacheWrap().(CacheKVStore)
+	cstore.Delete(k)
+	cstore.Write()
+	require.Nil(t, tstore.Get(k))
+	tstore.Set(k, v)
+
+	// the content is dropped on commit
+	require.Equal(t, CommitID{}, tstore.Commit())
+	require.Nil(t, tstore.Get(k))
+	require.Equal(t, CommitID{}, tstore.LastCommitID())
+}
@@ -23,3 +23,4 @@ type CommitID = types.CommitID
 type StoreKey = types.StoreKey
 type StoreType = types.StoreType
 type Queryable = types.Queryable
+type PruningOptions = types.PruningOptions
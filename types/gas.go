@@ -12,6 +12,8 @@ type ErrorOutOfGas struct {
 type GasMeter interface {
 	GasConsumed() Gas
 	ConsumeGas(amount Gas, descriptor string)
+	Limit() Gas
+	IsOutOfGas() bool
 }
 
 type basicGasMeter struct {
@@ -37,6 +39,15 @@ func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	}
 }
 
+func (g *basicGasMeter) Limit() Gas {
+	return g.limit
+}
+
+// true once all the gas has been used, ie. any further consumption panics
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
 type infiniteGasMeter struct {
 	consumed Gas
 }
@@ -54,3 +65,12 @@ func (g *infiniteGasMeter) GasConsumed() Gas {
 func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
 }
+
+// an infinite gas meter reports a limit of 0
+func (g *infiniteGasMeter) Limit() Gas {
+	return 0
+}
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
+}
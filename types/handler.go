@@ -4,5 +4,29 @@ package types
 type Handler func(ctx Context, msg Msg) Result
 
 // AnteHandler authenticates transactions, before their internal messages are handled.
-// If newCtx.IsZero(), ctx is used instead.
+// If newCtx.IsZero(), ctx is used instead. The block is charged for the gas
+// consumed on the gas meter of newCtx, an AnteHandler setting its own gas meter
+// should recover from running out of gas and abort with that meter.
 type AnteHandler func(ctx Context, tx Tx) (newCtx Context, result Result, abort bool)
+
+// AnteDecorator wraps the next AnteHandler to perform a single check or
+// state change, so that ante handlers can be composed from reusable parts.
+// A decorator calls next to continue the chain, or returns with abort set.
+type AnteDecorator interface {
+	AnteHandle(ctx Context, tx Tx, next AnteHandler) (newCtx Context, result Result, abort bool)
+}
+
+// ChainAnteDecorators chains the AnteDecorators into a single AnteHandler,
+// each decorator wrapping the ones after it. The first decorator runs first.
+// The end of the chain returns the context as passed to it with an OK result.
+func ChainAnteDecorators(chain ...AnteDecorator) AnteHandler {
+	if len(chain) == 0 {
+		return func(ctx Context, tx Tx) (Context, Result, bool) {
+			return ctx, Result{}, false
+		}
+	}
+	next := ChainAnteDecorators(chain[1:]...)
+	return func(ctx Context, tx Tx) (Context, Result, bool) {
+		return chain[0].AnteHandle(ctx, tx, next)
+	}
+}
@@ -0,0 +1,57 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// records its name when run, aborting the chain if abort is set
+type recordingDecorator struct {
+	name  string
+	calls *[]string
+	abort bool
+}
+
+func (rd recordingDecorator) AnteHandle(ctx types.Context, tx types.Tx, next types.AnteHandler) (types.Context, types.Result, bool) {
+	*rd.calls = append(*rd.calls, rd.name)
+	if rd.abort {
+		return ctx, types.ErrUnauthorized(rd.name).Result(), true
+	}
+	return next(ctx, tx)
+}
+
+func TestChainAnteDecorators(t *testing.T) {
+	var calls []string
+	ctx := types.Context{}
+
+	// an empty chain passes
+	_, res, abort := types.ChainAnteDecorators()(ctx, nil)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	// decorators run in order
+	anteHandler := types.ChainAnteDecorators(
+		recordingDecorator{name: "first", calls: &calls},
+		recordingDecorator{name: "second", calls: &calls},
+		recordingDecorator{name: "third", calls: &calls},
+	)
+	_, res, abort = anteHandler(ctx, nil)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+	require.Equal(t, []string{"first", "second", "third"}, calls)
+
+	// an aborting decorator stops the chain
+	calls = nil
+	anteHandler = types.ChainAnteDecorators(
+		recordingDecorator{name: "first", calls: &calls},
+		recordingDecorator{name: "second", calls: &calls, abort: true},
+		recordingDecorator{name: "third", calls: &calls},
+	)
+	_, res, abort = anteHandler(ctx, nil)
+	require.True(t, abort)
+	require.Equal(t, types.ToABCICode(types.CodespaceRoot, types.CodeUnauthorized), res.Code)
+	require.Equal(t, []string{"first", "second"}, calls)
+}
@@ -0,0 +1,12 @@
+package types
+
+// An Invariant is a function which tests a particular invariant of the
+// state. It returns an error describing the breakage if the invariant is
+// broken, nil otherwise.
+type Invariant func(ctx Context) error
+
+// InvariantRouter registers the invariants of the modules by module name
+// and route, see x/crisis.
+type InvariantRouter interface {
+	RegisterRoute(moduleName, route string, invar Invariant)
+}
@@ -0,0 +1,237 @@
+// Package module defines the AppModule interface, implemented by every
+// module of an application, and the Manager, which runs the modules'
+// routes, genesis and block hooks in an explicit order.
+//
+// An application registers its modules once:
+//
+//	mm := module.NewManager(
+//		bank.NewAppModule(coinKeeper),
+//		stake.NewAppModule(stakeKeeper),
+//	)
+//	mm.SetOrderEndBlockers("stake")
+//	mm.RegisterRoutes(app.Router(), app.QueryRouter())
+//
+// and delegates InitChain, BeginBlock, EndBlock and the genesis export to
+// the manager.
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AppModule is the interface all the modules of an application implement.
+// Modules without messages, queries, genesis or block hooks return empty
+// values, see the individual methods.
+type AppModule interface {
+	// Name of the module, also used as its key in the genesis state
+	Name() string
+
+	// Route of the module messages and its handler, no handler is
+	// registered if the route is empty
+	Route() string
+	NewHandler() sdk.Handler
+
+	// Route of the module queries and its querier, no querier is
+	// registered if the route is empty
+	QuerierRoute() string
+	NewQuerierHandler() sdk.Querier
+
+	// Registers the invariants of the module
+	RegisterInvariants(ir sdk.InvariantRouter)
+
+	// Genesis state of the module as JSON, nil if the module has none
+	DefaultGenesis() json.RawMessage
+	ValidateGenesis(data json.RawMessage) error
+	InitGenesis(ctx sdk.Context, data json.RawMessage) error
+	ExportGenesis(ctx sdk.Context) json.RawMessage
+
+	// Block hooks, called in the order set on the Manager
+	BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) sdk.Tags
+	EndBlock(ctx sdk.Context, req abci.RequestEndBlock) ([]abci.Validator, sdk.Tags)
+}
+
+// Manager runs the hooks of a set of modules in a defined order. By
+// default, every order is the order in which the modules were passed to
+// NewManager.
+type Manager struct {
+	Modules            map[string]AppModule
+	OrderInitGenesis   []string
+	OrderExportGenesis []string
+	OrderBeginBlockers []string
+	OrderEndBlockers   []string
+
+	// registration order of the modules
+	moduleNames []string
+}
+
+// NewManager creates a new Manager, panics on duplicate module names
+func NewManager(modules ...AppModule) *Manager {
+	moduleMap := make(map[string]AppModule, len(modules))
+	order := make([]string, 0, len(modules))
+	for _, module := range modules {
+		name := module.Name()
+		if _, ok := moduleMap[name]; ok {
+			panic(fmt.Sprintf("module %s registered twice", name))
+		}
+		moduleMap[name] = module
+		order = append(order, name)
+	}
+
+	return &Manager{
+		Modules:            moduleMap,
+		OrderInitGenesis:   order,
+		OrderExportGenesis: order,
+		OrderBeginBlockers: order,
+		OrderEndBlockers:   order,
+		moduleNames:        order,
+	}
+}
+
+// SetOrderInitGenesis sets the order in which the genesis of the modules is
+// initialized
+func (m *Manager) SetOrderInitGenesis(moduleNames ...string) {
+	m.assertRegistered(moduleNames)
+	m.OrderInitGenesis = moduleNames
+}
+
+// SetOrderExportGenesis sets the order in which the genesis of the modules
+// is exported
+func (m *Manager) SetOrderExportGenesis(moduleNames ...string) {
+	m.assertRegistered(moduleNames)
+	m.OrderExportGenesis = moduleNames
+}
+
+// SetOrderBeginBlockers sets the order of the modules BeginBlock calls
+func (m *Manager) SetOrderBeginBlockers(moduleNames ...string) {
+	m.assertRegistered(moduleNames)
+	m.OrderBeginBlockers = moduleNames
+}
+
+// SetOrderEndBlockers sets the order of the modules EndBlock calls
+func (m *Manager) SetOrderEndBlockers(moduleNames ...string) {
+	m.assertRegistered(moduleNames)
+	m.OrderEndBlockers = moduleNames
+}
+
+func (m *Manager) assertRegistered(moduleNames []string) {
+	for _, name := range moduleNames {
+		if _, ok := m.Modules[name]; !ok {
+			panic(fmt.Sprintf("module %s is not registered", name))
+		}
+	}
+}
+
+// RegisterRoutes registers the handlers and queriers of all the modules
+func (m *Manager) RegisterRoutes(router baseapp.Router, queryRouter baseapp.QueryRouter) {
+	for _, name := range m.moduleNames {
+		module := m.Modules[name]
+		if module.Route() != "" {
+			router.AddRoute(module.Route(), module.NewHandler())
+		}
+		if module.QuerierRoute() != "" {
+			queryRouter.AddRoute(module.QuerierRoute(), module.NewQuerierHandler())
+		}
+	}
+}
+
+// RegisterInvariants registers the invariants of all the modules
+func (m *Manager) RegisterInvariants(ir sdk.InvariantRouter) {
+	for _, name := range m.moduleNames {
+		m.Modules[name].RegisterInvariants(ir)
+	}
+}
+
+// DefaultGenesis returns the default genesis state of all the modules
+// which have one, keyed by module name
+func (m *Manager) DefaultGenesis() map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for _, name := range m.moduleNames {
+		if data := m.Modules[name].DefaultGenesis(); data != nil {
+			genesis[name] = data
+		}
+	}
+	return genesis
+}
+
+// ValidateGenesis validates the genesis state of every module. Modules
+// missing from the genesis are skipped, they are initialized with their
+// default genesis.
+func (m *Manager) ValidateGenesis(genesis map[string]json.RawMessage) error {
+	for _, name := range m.moduleNames {
+		data, ok := genesis[name]
+		if !ok {
+			continue
+		}
+		if err := m.Modules[name].ValidateGenesis(data); err != nil {
+			return fmt.Errorf("invalid %s genesis state: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// InitGenesis initializes the state of every module from its genesis
+// state, or its default genesis state if the module is missing from the
+// genesis. Keys of the genesis which are not modules are ignored.
+func (m *Manager) InitGenesis(ctx sdk.Context, genesis map[string]json.RawMessage) error {
+	for _, name := range m.OrderInitGenesis {
+		module := m.Modules[name]
+		data, ok := genesis[name]
+		if !ok {
+			data = module.DefaultGenesis()
+		}
+		if data == nil {
+			continue
+		}
+		if err := module.InitGenesis(ctx, data); err != nil {
+			return fmt.Errorf("could not initialize %s genesis state: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// ExportGenesis exports the state of all the modules which have a genesis
+// state, keyed by module name
+func (m *Manager) ExportGenesis(ctx sdk.Context) map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for _, name := range m.OrderExportGenesis {
+		if data := m.Modules[name].ExportGenesis(ctx); data != nil {
+			genesis[name] = data
+		}
+	}
+	return genesis
+}
+
+// BeginBlock runs the BeginBlock of every module and collects their tags
+func (m *Manager) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	tags := sdk.EmptyTags()
+	for _, name := range m.OrderBeginBlockers {
+		tags = tags.AppendTags(m.Modules[name].BeginBlock(ctx, req))
+	}
+
+	return abci.ResponseBeginBlock{
+		Tags: tags.ToKVPairs(),
+	}
+}
+
+// EndBlock runs the EndBlock of every module and collects their tags and
+// validator updates
+func (m *Manager) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	tags := sdk.EmptyTags()
+	var validatorUpdates []abci.Validator
+	for _, name := range m.OrderEndBlockers {
+		updates, moduleTags := m.Modules[name].EndBlock(ctx, req)
+		validatorUpdates = append(validatorUpdates, updates...)
+		tags = tags.AppendTags(moduleTags)
+	}
+
+	return abci.ResponseEndBlock{
+		ValidatorUpdates: validatorUpdates,
+		Tags:             tags.ToKVPairs(),
+	}
+}
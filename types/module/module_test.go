@@ -0,0 +1,131 @@
+package module
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// records the calls of its hooks
+type testModule struct {
+	name    string
+	calls   *[]string
+	genesis json.RawMessage
+}
+
+func (tm testModule) record(hook string) { *tm.calls = append(*tm.calls, tm.name+":"+hook) }
+
+// nolint
+func (tm testModule) Name() string                   { return tm.name }
+func (tm testModule) Route() string                  { return tm.name }
+func (tm testModule) NewHandler() sdk.Handler        { return nil }
+func (tm testModule) QuerierRoute() string           { return "" }
+func (tm testModule) NewQuerierHandler() sdk.Querier { return nil }
+func (tm testModule) RegisterInvariants(ir sdk.InvariantRouter) {
+	ir.RegisterRoute(tm.name, "invariant", func(_ sdk.Context) error { return nil })
+}
+func (tm testModule) DefaultGenesis() json.RawMessage {
+	return tm.genesis
+}
+func (tm testModule) ValidateGenesis(data json.RawMessage) error {
+	if string(data) == `"invalid"` {
+		return errors.New("invalid genesis")
+	}
+	return nil
+}
+func (tm testModule) InitGenesis(_ sdk.Context, data json.RawMessage) error {
+	tm.record("init=" + string(data))
+	return nil
+}
+func (tm testModule) ExportGenesis(_ sdk.Context) json.RawMessage {
+	tm.record("export")
+	return tm.genesis
+}
+func (tm testModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	tm.record("begin")
+	return sdk.NewTags(tm.name, []byte("begin"))
+}
+func (tm testModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	tm.record("end")
+	return []abci.Validator{{Power: 1}}, sdk.NewTags(tm.name, []byte("end"))
+}
+
+func TestManagerOrder(t *testing.T) {
+	var calls []string
+	mm := NewManager(
+		testModule{name: "a", calls: &calls, genesis: json.RawMessage(`"a"`)},
+		testModule{name: "b", calls: &calls},
+		testModule{name: "c", calls: &calls, genesis: json.RawMessage(`"c"`)},
+	)
+	ctx := sdk.Context{}
+
+	// default order is the registration order
+	res := mm.BeginBlock(ctx, abci.RequestBeginBlock{})
+	require.Equal(t, []string{"a:begin", "b:begin", "c:begin"}, calls)
+	require.Equal(t, 3, len(res.Tags))
+
+	// only the listed modules run, in the given order
+	calls = nil
+	mm.SetOrderEndBlockers("c", "a")
+	endRes := mm.EndBlock(ctx, abci.RequestEndBlock{})
+	require.Equal(t, []string{"c:end", "a:end"}, calls)
+	require.Equal(t, 2, len(endRes.ValidatorUpdates))
+	require.Equal(t, "c", string(endRes.Tags[0].Key))
+
+	// unknown modules can't be ordered
+	require.Panics(t, func() { mm.SetOrderBeginBlockers("d") })
+
+	// modules can't be registered twice
+	require.Panics(t, func() {
+		NewManager(testModule{name: "a", calls: &calls}, testModule{name: "a", calls: &calls})
+	})
+}
+
+func TestManagerGenesis(t *testing.T) {
+	var calls []string
+	mm := NewManager(
+		testModule{name: "a", calls: &calls, genesis: json.RawMessage(`"a"`)},
+		testModule{name: "b", calls: &calls},
+		testModule{name: "c", calls: &calls, genesis: json.RawMessage(`"c"`)},
+	)
+	mm.SetOrderInitGenesis("c", "b", "a")
+	ctx := sdk.Context{}
+
+	// modules without a genesis are left out
+	require.Equal(t, map[string]json.RawMessage{"a": json.RawMessage(`"a"`), "c": json.RawMessage(`"c"`)}, mm.DefaultGenesis())
+
+	require.Nil(t, mm.ValidateGenesis(map[string]json.RawMessage{"a": json.RawMessage(`"a"`)}))
+	require.NotNil(t, mm.ValidateGenesis(map[string]json.RawMessage{"a": json.RawMessage(`"invalid"`)}))
+
+	// missing modules are initialized with their default genesis
+	genesis := map[string]json.RawMessage{"a": json.RawMessage(`"custom"`), "other": json.RawMessage(`{}`)}
+	require.Nil(t, mm.InitGenesis(ctx, genesis))
+	require.Equal(t, []string{`c:init="c"`, `a:init="custom"`}, calls)
+
+	calls = nil
+	exported := mm.ExportGenesis(ctx)
+	require.Equal(t, []string{"a:export", "b:export", "c:export"}, calls)
+	require.Equal(t, mm.DefaultGenesis(), exported)
+}
+
+type testInvariantRouter struct {
+	routes []string
+}
+
+func (ir *testInvariantRouter) RegisterRoute(moduleName, route string, _ sdk.Invariant) {
+	ir.routes = append(ir.routes, moduleName+"/"+route)
+}
+
+func TestManagerRegisterInvariants(t *testing.T) {
+	var calls []string
+	mm := NewManager(testModule{name: "a", calls: &calls}, testModule{name: "b", calls: &calls})
+
+	ir := &testInvariantRouter{}
+	mm.RegisterInvariants(ir)
+	require.Equal(t, []string{"a/invariant", "b/invariant"}, ir.routes)
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	wire "github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Querier is implemented by module keepers to answer custom queries routed
+// through "/custom/<module>/<path...>". The path passed in has the "custom"
+// and module prefixes removed. Results are returned as JSON.
+type Querier = func(ctx Context, path []string, req abci.RequestQuery) (res []byte, err Error)
+
+// UnmarshalQueryParams decodes the JSON params of a custom query
+func UnmarshalQueryParams(cdc *wire.Codec, data []byte, params interface{}) Error {
+	if err := cdc.UnmarshalJSON(data, params); err != nil {
+		return ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err.Error()))
+	}
+	return nil
+}
+
+// MarshalQueryResult encodes the result of a custom query as indented JSON
+func MarshalQueryResult(cdc *wire.Codec, result interface{}) ([]byte, Error) {
+	bz, err := wire.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		return nil, ErrInternal(fmt.Sprintf("could not marshal result to JSON - %s", err.Error()))
+	}
+	return bz, nil
+}
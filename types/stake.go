@@ -60,8 +60,9 @@ type ValidatorSet interface {
 	IterateValidatorsBonded(Context,
 		func(index int64, validator Validator) (stop bool))
 
-	Validator(Context, Address) Validator // get a particular validator by owner address
-	TotalPower(Context) Rat               // total power of the validator set
+	Validator(Context, Address) Validator               // get a particular validator by owner address
+	ValidatorByPubKey(Context, crypto.PubKey) Validator // get a particular validator by pubkey
+	TotalPower(Context) Rat                             // total power of the validator set
 
 	// slash the validator and delegators of the validator, specifying offence height, offence power, and slash fraction
 	Slash(Context, crypto.PubKey, int64, int64, Rat)
@@ -87,3 +88,86 @@ type DelegationSet interface {
 	IterateDelegations(ctx Context, delegator Address,
 		fn func(index int64, delegation Delegation) (stop bool))
 }
+
+// burner of the coins a module takes out of the accounts for good, such as
+// the fees or deposits it destroys, so that the staking keeper removes them
+// from the loose tokens of its pool
+type TokenBurner interface {
+	BurnLooseTokens(ctx Context, coins Coins)
+}
+
+//_______________________________________________________________________________
+
+// event hooks for the staking keeper, called around the changes of the
+// validators and delegations so that other modules can keep their own
+// state in sync
+type StakingHooks interface {
+	// called after a validator is created
+	OnValidatorCreated(ctx Context, valAddr Address)
+
+	// called after a validator is bonded, the address is the one of its
+	// pubkey, which signs the blocks
+	OnValidatorBonded(ctx Context, address Address, valAddr Address)
+
+	// called after a bonded validator starts unbonding
+	OnValidatorBeginUnbonding(ctx Context, address Address, valAddr Address)
+
+	// called before a validator is removed
+	OnValidatorRemoved(ctx Context, valAddr Address)
+
+	// called after a delegation is created
+	OnDelegationCreated(ctx Context, delAddr Address, valAddr Address)
+
+	// called before the shares of a delegation are modified
+	OnDelegationSharesModified(ctx Context, delAddr Address, valAddr Address)
+
+	// called before a delegation is removed
+	OnDelegationRemoved(ctx Context, delAddr Address, valAddr Address)
+}
+
+// combines the hooks of several modules, each hook is called on all of
+// them in order
+type MultiStakingHooks []StakingHooks
+
+var _ StakingHooks = MultiStakingHooks{}
+
+func NewMultiStakingHooks(hooks ...StakingHooks) MultiStakingHooks {
+	return hooks
+}
+
+// nolint
+func (mh MultiStakingHooks) OnValidatorCreated(ctx Context, valAddr Address) {
+	for _, h := range mh {
+		h.OnValidatorCreated(ctx, valAddr)
+	}
+}
+func (mh MultiStakingHooks) OnValidatorBonded(ctx Context, address Address, valAddr Address) {
+	for _, h := range mh {
+		h.OnValidatorBonded(ctx, address, valAddr)
+	}
+}
+func (mh MultiStakingHooks) OnValidatorBeginUnbonding(ctx Context, address Address, valAddr Address) {
+	for _, h := range mh {
+		h.OnValidatorBeginUnbonding(ctx, address, valAddr)
+	}
+}
+func (mh MultiStakingHooks) OnValidatorRemoved(ctx Context, valAddr Address) {
+	for _, h := range mh {
+		h.OnValidatorRemoved(ctx, valAddr)
+	}
+}
+func (mh MultiStakingHooks) OnDelegationCreated(ctx Context, delAddr Address, valAddr Address) {
+	for _, h := range mh {
+		h.OnDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+func (mh MultiStakingHooks) OnDelegationSharesModified(ctx Context, delAddr Address, valAddr Address) {
+	for _, h := range mh {
+		h.OnDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+func (mh MultiStakingHooks) OnDelegationRemoved(ctx Context, delAddr Address, valAddr Address) {
+	for _, h := range mh {
+		h.OnDelegationRemoved(ctx, delAddr, valAddr)
+	}
+}
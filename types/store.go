@@ -82,6 +82,15 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Set the pruning of the IAVL stores, applied to the stores already
+	// loaded and to the stores loaded afterwards.
+	SetPruning(pruning PruningOptions)
+
+	// Cache wrap the substores as they were at a persisted version,
+	// used to serve queries against past state.
+	// NOTE: The returned store must never be written back.
+	CacheMultiStoreWithVersion(ver int64) (CacheMultiStore, error)
 }
 
 //---------subsp-------------------------------
@@ -201,6 +210,27 @@ func (cid CommitID) String() string {
 	return fmt.Sprintf("CommitID{%v:%X}", cid.Hash, cid.Version)
 }
 
+//----------------------------------------
+// Pruning
+
+// PruningOptions define which versions of the IAVL stores are kept: the
+// KeepRecent latest versions, and every KeepEvery-th older version as state
+// sync waypoints, none if KeepEvery is zero.
+type PruningOptions struct {
+	KeepRecent int64
+	KeepEvery  int64
+}
+
+// nolint
+var (
+	// keeps the last 100 versions and a waypoint every 10000 versions
+	PruneDefault = PruningOptions{KeepRecent: 100, KeepEvery: 10000}
+	// keeps every version, for archive nodes
+	PruneNothing = PruningOptions{KeepRecent: 0, KeepEvery: 1}
+	// keeps only the latest version
+	PruneEverything = PruningOptions{KeepRecent: 0, KeepEvery: 0}
+)
+
 //----------------------------------------
 // Store types
 
@@ -213,6 +243,7 @@ const (
 	StoreTypeDB
 	StoreTypeIAVL
 	StoreTypePrefix
+	StoreTypeTransient
 )
 
 //----------------------------------------
@@ -251,6 +282,36 @@ func (key *KVStoreKey) KVStore(ctx Context) KVStore {
 	return ctx.KVStore(key)
 }
 
+// TransientStoreKey is used for accessing transient substores, which are
+// wiped on every commit.
+// Only the pointer value should ever be used - it functions as a capabilities key.
+type TransientStoreKey struct {
+	name string
+}
+
+// NewTransientStoreKey returns a new pointer to a TransientStoreKey.
+// Use a pointer so keys don't collide.
+func NewTransientStoreKey(name string) *TransientStoreKey {
+	return &TransientStoreKey{
+		name: name,
+	}
+}
+
+// Implements StoreKey
+func (key *TransientStoreKey) Name() string {
+	return key.name
+}
+
+// Implements StoreKey
+func (key *TransientStoreKey) String() string {
+	return fmt.Sprintf("TransientStoreKey{%p, %s}", key, key.name)
+}
+
+// Implements KVStoreGetter
+func (key *TransientStoreKey) KVStore(ctx Context) KVStore {
+	return ctx.KVStore(key)
+}
+
 // PrefixEndBytes returns the []byte that would end a
 // range query for all []byte with a certain prefix
 // Deals with last byte of prefix being FF without overflowing
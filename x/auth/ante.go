@@ -17,104 +17,279 @@ const (
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
+// Apps needing extra checks can chain their own decorators with these.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(
+		NewSetUpGasMeterDecorator(),
+		NewSigCountDecorator(),
+		NewValidateMemoDecorator(),
+		NewSigVerificationDecorator(am),
+		NewDeductFeeDecorator(am, fck),
+		NewIncrementSequenceDecorator(am),
+	)
+}
 
-	return func(
-		ctx sdk.Context, tx sdk.Tx,
-	) (_ sdk.Context, _ sdk.Result, abort bool) {
+// This AnteHandler requires Txs to be StdTxs
+func getStdTx(tx sdk.Tx) (StdTx, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return stdTx, sdk.ErrInternal("tx must be StdTx").Result(), false
+	}
+	return stdTx, sdk.Result{}, true
+}
 
-		// This AnteHandler requires Txs to be StdTxs
-		stdTx, ok := tx.(StdTx)
-		if !ok {
-			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
-		}
+//______________________________________________________________________
 
-		// Assert that there are signatures.
-		var sigs = stdTx.GetSignatures()
-		if len(sigs) == 0 {
-			return ctx,
-				sdk.ErrUnauthorized("no signers").Result(),
-				true
-		}
+// SetUpGasMeterDecorator sets the gas meter of the context to the gas
+// limit of the tx fee. It should be the first decorator of the chain
+// so that all the following ones are metered. If they run out of gas,
+// it aborts with the context of the gas meter, so that the gas used is
+// still charged to the block.
+type SetUpGasMeterDecorator struct{}
 
-		memo := stdTx.GetMemo()
+func NewSetUpGasMeterDecorator() SetUpGasMeterDecorator {
+	return SetUpGasMeterDecorator{}
+}
+
+// nolint
+func (sud SetUpGasMeterDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	stdTx, res, ok := getStdTx(tx)
+	if !ok {
+		return ctx, res, true
+	}
 
-		if len(memo) > maxMemoCharacters {
-			return ctx,
-				sdk.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters", maxMemoCharacters, len(memo))).Result(),
-				true
+	newCtx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
+	defer func() {
+		if r := recover(); r != nil {
+			outOfGas, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			log := fmt.Sprintf("out of gas in location: %v", outOfGas.Descriptor)
+			res = sdk.ErrOutOfGas(log).Result()
+			res.GasUsed = newCtx.GasMeter().GasConsumed()
+			abort = true
 		}
+	}()
+	return next(newCtx, tx)
+}
 
-		// set the gas meter
-		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
+//______________________________________________________________________
 
-		// charge gas for the memo
-		ctx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(memo)), "memo")
+// ValidateMemoDecorator checks the memo size and charges gas for it.
+type ValidateMemoDecorator struct{}
 
-		msgs := tx.GetMsgs()
+func NewValidateMemoDecorator() ValidateMemoDecorator {
+	return ValidateMemoDecorator{}
+}
 
-		// Assert that number of signatures is correct.
-		var signerAddrs = stdTx.GetSigners()
-		if len(sigs) != len(signerAddrs) {
-			return ctx,
-				sdk.ErrUnauthorized("wrong number of signers").Result(),
-				true
-		}
+// nolint
+func (vmd ValidateMemoDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+	stdTx, res, ok := getStdTx(tx)
+	if !ok {
+		return ctx, res, true
+	}
 
-		// Get the sign bytes (requires all account & sequence numbers and the fee)
-		sequences := make([]int64, len(signerAddrs))
-		for i := 0; i < len(signerAddrs); i++ {
-			sequences[i] = sigs[i].Sequence
-		}
-		accNums := make([]int64, len(signerAddrs))
-		for i := 0; i < len(signerAddrs); i++ {
-			accNums[i] = sigs[i].AccountNumber
+	memo := stdTx.GetMemo()
+	if len(memo) > maxMemoCharacters {
+		return ctx,
+			sdk.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters", maxMemoCharacters, len(memo))).Result(),
+			true
+	}
+
+	// charge gas for the memo
+	ctx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(memo)), "memo")
+	return next(ctx, tx)
+}
+
+//______________________________________________________________________
+
+// SigCountDecorator checks that there is exactly one signature per signer.
+type SigCountDecorator struct{}
+
+func NewSigCountDecorator() SigCountDecorator {
+	return SigCountDecorator{}
+}
+
+// nolint
+func (scd SigCountDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+	stdTx, res, ok := getStdTx(tx)
+	if !ok {
+		return ctx, res, true
+	}
+
+	// Assert that there are signatures.
+	var sigs = stdTx.GetSignatures()
+	if len(sigs) == 0 {
+		return ctx,
+			sdk.ErrUnauthorized("no signers").Result(),
+			true
+	}
+
+	// Assert that number of signatures is correct.
+	var signerAddrs = stdTx.GetSigners()
+	if len(sigs) != len(signerAddrs) {
+		return ctx,
+			sdk.ErrUnauthorized("wrong number of signers").Result(),
+			true
+	}
+	return next(ctx, tx)
+}
+
+//______________________________________________________________________
+
+// SigVerificationDecorator checks the account number, sequence and
+// signature of every signer. Accounts without a pubkey get the one of
+// their signature. The signer accounts are cached in the context, see
+// WithSigners, so that the following decorators work on the same accounts.
+// NOTE: The accounts are not saved, this is up to the IncrementSequenceDecorator.
+type SigVerificationDecorator struct {
+	am AccountMapper
+}
+
+func NewSigVerificationDecorator(am AccountMapper) SigVerificationDecorator {
+	return SigVerificationDecorator{am: am}
+}
+
+// nolint
+func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+	stdTx, res, ok := getStdTx(tx)
+	if !ok {
+		return ctx, res, true
+	}
+
+	sigs := stdTx.GetSignatures()
+	signerAddrs := stdTx.GetSigners()
+	if len(sigs) != len(signerAddrs) {
+		return ctx,
+			sdk.ErrUnauthorized("wrong number of signers").Result(),
+			true
+	}
+
+	// Check sig and nonce and collect signer accounts.
+	var signerAccs = make([]Account, len(signerAddrs))
+	for i := 0; i < len(sigs); i++ {
+		signerAddr, sig := signerAddrs[i], sigs[i]
+
+		// the sign bytes require the account & sequence numbers and the fee
+		signBytes := StdSignBytes(ctx.ChainID(), sig.AccountNumber, sig.Sequence, stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo())
+		signerAcc, res := processSig(
+			ctx, svd.am,
+			signerAddr, sig, signBytes,
+		)
+		if !res.IsOK() {
+			return ctx, res, true
 		}
-		fee := stdTx.Fee
-
-		// Check sig and nonce and collect signer accounts.
-		var signerAccs = make([]Account, len(signerAddrs))
-		for i := 0; i < len(sigs); i++ {
-			signerAddr, sig := signerAddrs[i], sigs[i]
-
-			// check signature, return account with incremented nonce
-			signBytes := StdSignBytes(ctx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo())
-			signerAcc, res := processSig(
-				ctx, am,
-				signerAddr, sig, signBytes,
-			)
-			if !res.IsOK() {
-				return ctx, res, true
-			}
+		signerAccs[i] = signerAcc
+	}
 
-			// first sig pays the fees
-			if i == 0 {
-				// TODO: min fee
-				if !fee.Amount.IsZero() {
-					ctx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
-					signerAcc, res = deductFees(signerAcc, fee)
-					if !res.IsOK() {
-						return ctx, res, true
-					}
-					fck.addCollectedFees(ctx, fee.Amount)
-				}
-			}
+	// cache the signer accounts in the context
+	ctx = WithSigners(ctx, signerAccs)
+	return next(ctx, tx)
+}
+
+//______________________________________________________________________
+
+// DeductFeeDecorator deducts the fee from the first signer and adds it to
+// the collected fees.
+type DeductFeeDecorator struct {
+	am  AccountMapper
+	fck FeeCollectionKeeper
+}
+
+func NewDeductFeeDecorator(am AccountMapper, fck FeeCollectionKeeper) DeductFeeDecorator {
+	return DeductFeeDecorator{am: am, fck: fck}
+}
+
+// nolint
+func (dfd DeductFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+	stdTx, res, ok := getStdTx(tx)
+	if !ok {
+		return ctx, res, true
+	}
+
+	// TODO: min fee
+	fee := stdTx.Fee
+	if fee.Amount.IsZero() {
+		return next(ctx, tx)
+	}
+
+	// first sig pays the fees
+	signerAccs, res := getSignerAccs(ctx, dfd.am, stdTx.GetSigners())
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+	ctx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
+	feePayer, res := deductFees(signerAccs[0], fee)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+	dfd.am.SetAccount(ctx, feePayer)
+	dfd.fck.AddCollectedFees(ctx, fee.Amount)
+
+	ctx = WithSigners(ctx, signerAccs)
+	return next(ctx, tx)
+}
 
-			// Save the account.
-			am.SetAccount(ctx, signerAcc)
-			signerAccs[i] = signerAcc
+//______________________________________________________________________
+
+// IncrementSequenceDecorator increments the sequence of every signer and
+// saves the signer accounts. It should come after the decorators which
+// modify the signer accounts.
+type IncrementSequenceDecorator struct {
+	am AccountMapper
+}
+
+func NewIncrementSequenceDecorator(am AccountMapper) IncrementSequenceDecorator {
+	return IncrementSequenceDecorator{am: am}
+}
+
+// nolint
+func (isd IncrementSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+	stdTx, res, ok := getStdTx(tx)
+	if !ok {
+		return ctx, res, true
+	}
+
+	signerAccs, res := getSignerAccs(ctx, isd.am, stdTx.GetSigners())
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+	for _, signerAcc := range signerAccs {
+		err := signerAcc.SetSequence(signerAcc.GetSequence() + 1)
+		if err != nil {
+			// Handle w/ #870
+			panic(err)
 		}
+		// Save the account.
+		isd.am.SetAccount(ctx, signerAcc)
+	}
 
-		// cache the signer accounts in the context
-		ctx = WithSigners(ctx, signerAccs)
+	ctx = WithSigners(ctx, signerAccs)
+	return next(ctx, tx)
+}
 
-		// TODO: tx tags (?)
+//______________________________________________________________________
 
-		return ctx, sdk.Result{}, false // continue...
+// get the signer accounts cached in the context by a previous decorator,
+// or load them from the store
+func getSignerAccs(ctx sdk.Context, am AccountMapper, signerAddrs []sdk.Address) ([]Account, sdk.Result) {
+	if signerAccs := GetSigners(ctx); len(signerAccs) == len(signerAddrs) {
+		return signerAccs, sdk.Result{}
+	}
+
+	signerAccs := make([]Account, len(signerAddrs))
+	for i, addr := range signerAddrs {
+		acc := am.GetAccount(ctx, addr)
+		if acc == nil {
+			return nil, sdk.ErrUnknownAddress(addr.String()).Result()
+		}
+		signerAccs[i] = acc
 	}
+	return signerAccs, sdk.Result{}
 }
 
-// verify the signature and increment the sequence.
+// verify the signature and check the sequence.
 // if the account doesn't have a pubkey, set it.
 func processSig(
 	ctx sdk.Context, am AccountMapper,
@@ -134,17 +309,12 @@ func processSig(
 			fmt.Sprintf("Invalid account number. Got %d, expected %d", sig.AccountNumber, accnum)).Result()
 	}
 
-	// Check and increment sequence number.
+	// Check sequence number, it is incremented by the IncrementSequenceDecorator.
 	seq := acc.GetSequence()
 	if seq != sig.Sequence {
 		return nil, sdk.ErrInvalidSequence(
 			fmt.Sprintf("Invalid sequence. Got %d, expected %d", sig.Sequence, seq)).Result()
 	}
-	err := acc.SetSequence(seq + 1)
-	if err != nil {
-		// Handle w/ #870
-		panic(err)
-	}
 	// If pubkey is not known for account,
 	// set it from the StdSignature.
 	pubKey := acc.GetPubKey()
@@ -157,7 +327,7 @@ func processSig(
 			return nil, sdk.ErrInvalidPubKey(
 				fmt.Sprintf("PubKey does not match Signer address %v", addr)).Result()
 		}
-		err = acc.SetPubKey(pubKey)
+		err := acc.SetPubKey(pubKey)
 		if err != nil {
 			return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 		}
@@ -567,3 +567,238 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	acc2 = mapper.GetAccount(ctx, addr2)
 	require.Nil(t, acc2.GetPubKey())
 }
+
+//______________________________________________________________________
+
+// set up the stores, mapper and fee keeper of the decorator tests
+func setupDecoratorTest() (sdk.Context, AccountMapper, FeeCollectionKeeper) {
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	return ctx, mapper, feeCollector
+}
+
+// run the tx through a single decorator, check the result code and return
+// the context passed to the next AnteHandler, nil if it wasn't called
+func runDecorator(t *testing.T, decorator sdk.AnteDecorator, ctx sdk.Context, tx sdk.Tx, code sdk.CodeType) *sdk.Context {
+	var nextCtx *sdk.Context
+	next := func(ctx sdk.Context, tx sdk.Tx) (sdk.Context, sdk.Result, bool) {
+		nextCtx = &ctx
+		return ctx, sdk.Result{}, false
+	}
+	_, result, abort := decorator.AnteHandle(ctx, tx, next)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, code), result.Code, result.Log)
+	require.Equal(t, code != sdk.CodeOK, abort)
+	require.Equal(t, code == sdk.CodeOK, nextCtx != nil, "the next AnteHandler is called iff the tx passes")
+	return nextCtx
+}
+
+func TestSetUpGasMeterDecorator(t *testing.T) {
+	ctx, _, _ := setupDecoratorTest()
+	_, addr1 := privAndAddr()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	cases := []struct {
+		name string
+		tx   sdk.Tx
+		code sdk.CodeType
+	}{
+		{"no gas", NewStdTx(msgs, NewStdFee(0), nil, ""), sdk.CodeOK},
+		{"some gas", NewStdTx(msgs, NewStdFee(5000), nil, ""), sdk.CodeOK},
+		{"gas and fee", NewStdTx(msgs, newStdFee(), nil, ""), sdk.CodeOK},
+		{"not a StdTx", nil, sdk.CodeInternal},
+	}
+	for _, tc := range cases {
+		nextCtx := runDecorator(t, NewSetUpGasMeterDecorator(), ctx, tc.tx, tc.code)
+		if tc.code != sdk.CodeOK {
+			continue
+		}
+		// the following decorators are metered up to the tx gas limit
+		gas := tc.tx.(StdTx).Fee.Gas
+		meter := nextCtx.GasMeter()
+		require.Equal(t, gas, meter.Limit(), tc.name)
+		require.Equal(t, sdk.Gas(0), meter.GasConsumed(), tc.name)
+		meter.ConsumeGas(gas, "test")
+		require.Panics(t, func() { meter.ConsumeGas(1, "test") }, tc.name)
+	}
+
+	// running out of gas in the following decorators aborts with the gas
+	// meter of the tx, so that its gas is charged to the block
+	outOfGas := func(ctx sdk.Context, tx sdk.Tx) (sdk.Context, sdk.Result, bool) {
+		ctx.GasMeter().ConsumeGas(150, "test")
+		return ctx, sdk.Result{}, false
+	}
+	tx := NewStdTx(msgs, NewStdFee(100), nil, "")
+	newCtx, result, abort := NewSetUpGasMeterDecorator().AnteHandle(ctx, tx, outOfGas)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), result.Code)
+	require.Equal(t, sdk.Gas(150), result.GasUsed)
+	require.Equal(t, sdk.Gas(100), newCtx.GasMeter().Limit())
+	require.Equal(t, sdk.Gas(150), newCtx.GasMeter().GasConsumed())
+}
+
+func TestSigVerificationDecorator(t *testing.T) {
+	ctx, mapper, _ := setupDecoratorTest()
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	priv3, addr3 := privAndAddr()
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addr1))
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetSequence(3)
+	mapper.SetAccount(ctx, acc2)
+
+	fee := newStdFee()
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2)}
+	privs := []crypto.PrivKey{priv1, priv2}
+	otherChainSignBytes := StdSignBytes("otherchain", 0, 0, fee, msgs, "")
+
+	cases := []struct {
+		name string
+		tx   sdk.Tx
+		code sdk.CodeType
+	}{
+		{"valid", newTestTx(ctx, msgs, privs, []int64{0, 1}, []int64{0, 3}, fee), sdk.CodeOK},
+		{"missing signature", newTestTx(ctx, msgs, privs[:1], []int64{0}, []int64{0}, fee), sdk.CodeUnauthorized},
+		{"wrong account number", newTestTx(ctx, msgs, privs, []int64{0, 0}, []int64{0, 3}, fee), sdk.CodeInvalidSequence},
+		{"sequence too low", newTestTx(ctx, msgs, privs, []int64{0, 1}, []int64{0, 2}, fee), sdk.CodeInvalidSequence},
+		{"sequence too high", newTestTx(ctx, msgs, privs, []int64{0, 1}, []int64{1, 3}, fee), sdk.CodeInvalidSequence},
+		{"signed for another chain", newTestTxWithSignBytes(msgs, privs, []int64{0, 1}, []int64{0, 3}, fee, otherChainSignBytes, ""), sdk.CodeUnauthorized},
+		{"wrong signer key", newTestTx(ctx, msgs, []crypto.PrivKey{priv1, priv3}, []int64{0, 1}, []int64{0, 3}, fee), sdk.CodeInvalidPubKey},
+		{"unknown signer", newTestTx(ctx, []sdk.Msg{newTestMsg(addr3)}, []crypto.PrivKey{priv3}, []int64{2}, []int64{0}, fee), sdk.CodeUnknownAddress},
+		{"not a StdTx", nil, sdk.CodeInternal},
+	}
+	for _, tc := range cases {
+		ctx := ctx.WithGasMeter(sdk.NewGasMeter(10000))
+		nextCtx := runDecorator(t, NewSigVerificationDecorator(mapper), ctx, tc.tx, tc.code)
+		if tc.code != sdk.CodeOK {
+			continue
+		}
+		// every signature is charged
+		require.Equal(t, sdk.Gas(2*verifyCost), nextCtx.GasMeter().GasConsumed(), tc.name)
+
+		// the signer accounts are cached with their pubkey, but neither
+		// saved nor incremented
+		signers := GetSigners(*nextCtx)
+		require.Equal(t, 2, len(signers), tc.name)
+		require.Equal(t, addr1, signers[0].GetAddress(), tc.name)
+		require.Equal(t, priv1.PubKey(), signers[0].GetPubKey(), tc.name)
+		require.Equal(t, int64(0), signers[0].GetSequence(), tc.name)
+		require.Equal(t, addr2, signers[1].GetAddress(), tc.name)
+		require.Equal(t, int64(3), signers[1].GetSequence(), tc.name)
+		require.Nil(t, mapper.GetAccount(ctx, addr1).GetPubKey(), tc.name)
+	}
+}
+
+func TestDeductFeeDecorator(t *testing.T) {
+	priv1, addr1 := privAndAddr()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+
+	cases := []struct {
+		name      string
+		coins     sdk.Coins // coins of the stored payer account, none if nil
+		cached    sdk.Coins // coins of the cached payer account, none if nil
+		fee       StdFee
+		code      sdk.CodeType
+		remaining sdk.Coins
+		collected sdk.Coins
+		gas       sdk.Gas
+	}{
+		{"no fee", sdk.Coins{}, nil, NewStdFee(5000), sdk.CodeOK, sdk.Coins{}, emptyCoins, 0},
+		{"no fee, unknown payer", nil, nil, NewStdFee(5000), sdk.CodeOK, nil, emptyCoins, 0},
+		{"exact fee", sdk.Coins{sdk.NewCoin("atom", 150)}, nil, newStdFee(), sdk.CodeOK,
+			sdk.Coins{}, sdk.Coins{sdk.NewCoin("atom", 150)}, deductFeesCost},
+		{"fee and change", newCoins(), nil, newStdFee(), sdk.CodeOK,
+			sdk.Coins{sdk.NewCoin("atom", 9999850)}, sdk.Coins{sdk.NewCoin("atom", 150)}, deductFeesCost},
+		{"insufficient funds", sdk.Coins{sdk.NewCoin("atom", 149)}, nil, newStdFee(), sdk.CodeInsufficientFunds,
+			sdk.Coins{sdk.NewCoin("atom", 149)}, emptyCoins, deductFeesCost},
+		{"wrong denom", sdk.Coins{sdk.NewCoin("photon", 150)}, nil, newStdFee(), sdk.CodeInsufficientFunds,
+			sdk.Coins{sdk.NewCoin("photon", 150)}, emptyCoins, deductFeesCost},
+		{"unknown payer", nil, nil, newStdFee(), sdk.CodeUnknownAddress, nil, emptyCoins, 0},
+		{"cached payer", sdk.Coins{}, sdk.Coins{sdk.NewCoin("atom", 200)}, newStdFee(), sdk.CodeOK,
+			sdk.Coins{sdk.NewCoin("atom", 50)}, sdk.Coins{sdk.NewCoin("atom", 150)}, deductFeesCost},
+	}
+	for _, tc := range cases {
+		ctx, mapper, feeCollector := setupDecoratorTest()
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(10000))
+		if tc.coins != nil {
+			acc := mapper.NewAccountWithAddress(ctx, addr1)
+			acc.SetCoins(tc.coins)
+			mapper.SetAccount(ctx, acc)
+		}
+		if tc.cached != nil {
+			acc := mapper.NewAccountWithAddress(ctx, addr1)
+			acc.SetCoins(tc.cached)
+			ctx = WithSigners(ctx, []Account{acc})
+		}
+
+		tx := newTestTx(ctx, msgs, privs, accnums, seqs, tc.fee)
+		nextCtx := runDecorator(t, NewDeductFeeDecorator(mapper, feeCollector), ctx, tx, tc.code)
+		require.Equal(t, tc.gas, ctx.GasMeter().GasConsumed(), tc.name)
+		require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(tc.collected), tc.name)
+		acc := mapper.GetAccount(ctx, addr1)
+		if tc.remaining == nil {
+			require.Nil(t, acc, tc.name)
+			continue
+		}
+		require.True(t, acc.GetCoins().IsEqual(tc.remaining), tc.name)
+		if tc.code == sdk.CodeOK && !tc.fee.Amount.IsZero() {
+			// the following decorators work on the charged account
+			require.True(t, GetSigners(*nextCtx)[0].GetCoins().IsEqual(tc.remaining), tc.name)
+		}
+	}
+}
+
+func TestIncrementSequenceDecorator(t *testing.T) {
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	fee := newStdFee()
+
+	cases := []struct {
+		name   string
+		msgs   []sdk.Msg
+		privs  []crypto.PrivKey
+		runs   int
+		code   sdk.CodeType
+		seqs   []int64 // sequences of the signers after the runs
+		cached bool    // the signers are cached by a previous decorator
+	}{
+		{"single signer", []sdk.Msg{newTestMsg(addr1)}, []crypto.PrivKey{priv1}, 1, sdk.CodeOK, []int64{1}, false},
+		{"several txs", []sdk.Msg{newTestMsg(addr1)}, []crypto.PrivKey{priv1}, 3, sdk.CodeOK, []int64{3}, false},
+		{"multiple signers", []sdk.Msg{newTestMsg(addr1, addr2)}, []crypto.PrivKey{priv1, priv2}, 2, sdk.CodeOK, []int64{2, 2}, false},
+		{"cached signers", []sdk.Msg{newTestMsg(addr1, addr2)}, []crypto.PrivKey{priv1, priv2}, 1, sdk.CodeOK, []int64{1, 1}, true},
+		{"unknown signer", []sdk.Msg{newTestMsg(addr1, addr2)}, []crypto.PrivKey{priv1, priv2}, 1, sdk.CodeUnknownAddress, []int64{0}, false},
+	}
+	for _, tc := range cases {
+		ctx, mapper, _ := setupDecoratorTest()
+		acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+		mapper.SetAccount(ctx, acc1)
+		signers := []Account{acc1}
+		if tc.code != sdk.CodeUnknownAddress {
+			acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+			mapper.SetAccount(ctx, acc2)
+			signers = append(signers, acc2)
+		}
+		if tc.cached {
+			// the cached accounts are the ones saved
+			signers[0].SetPubKey(priv1.PubKey())
+			ctx = WithSigners(ctx, signers)
+		}
+
+		accnums, seqs := make([]int64, len(tc.privs)), make([]int64, len(tc.privs))
+		tx := newTestTx(ctx, tc.msgs, tc.privs, accnums, seqs, fee)
+		for i := 0; i < tc.runs; i++ {
+			runDecorator(t, NewIncrementSequenceDecorator(mapper), ctx, tx, tc.code)
+		}
+		for i, seq := range tc.seqs {
+			acc := mapper.GetAccount(ctx, tc.msgs[0].GetSigners()[i])
+			require.Equal(t, seq, acc.GetSequence(), tc.name)
+		}
+		if tc.cached {
+			require.Equal(t, priv1.PubKey(), mapper.GetAccount(ctx, addr1).GetPubKey(), tc.name)
+		}
+	}
+}
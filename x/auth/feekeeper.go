@@ -49,7 +49,7 @@ func (fck FeeCollectionKeeper) setCollectedFees(ctx sdk.Context, coins sdk.Coins
 }
 
 // Adds to Collected Fee Pool
-func (fck FeeCollectionKeeper) addCollectedFees(ctx sdk.Context, coins sdk.Coins) sdk.Coins {
+func (fck FeeCollectionKeeper) AddCollectedFees(ctx sdk.Context, coins sdk.Coins) sdk.Coins {
 	newCoins := fck.GetCollectedFees(ctx).Plus(coins)
 	fck.setCollectedFees(ctx, newCoins)
 
@@ -60,3 +60,9 @@ func (fck FeeCollectionKeeper) addCollectedFees(ctx sdk.Context, coins sdk.Coins
 func (fck FeeCollectionKeeper) ClearCollectedFees(ctx sdk.Context) {
 	fck.setCollectedFees(ctx, sdk.Coins{})
 }
+
+// Returns the amount of a denom held as collected fees, implements the
+// token holder of the stake supply invariant
+func (fck FeeCollectionKeeper) HeldTokens(ctx sdk.Context, denom string) sdk.Rat {
+	return sdk.NewRatFromInt(fck.GetCollectedFees(ctx).AmountOf(denom))
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the fee collector
+func RegisterInvariants(ir sdk.InvariantRouter, fck FeeCollectionKeeper) {
+	ir.RegisterRoute("auth", "collected-fees", CollectedFeesInvariant(fck))
+}
+
+// CollectedFeesInvariant checks that the collected fees are non-negative
+func CollectedFeesInvariant(fck FeeCollectionKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		fees := fck.GetCollectedFees(ctx)
+		if !fees.IsNotNegative() {
+			return fmt.Errorf("negative collected fees: %v", fees)
+		}
+		return nil
+	}
+}
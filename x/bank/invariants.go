@@ -0,0 +1,29 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// RegisterInvariants registers the bank invariants
+func RegisterInvariants(ir sdk.InvariantRouter, am auth.AccountMapper) {
+	ir.RegisterRoute(ModuleName, "nonnegative-outstanding", NonnegativeBalanceInvariant(am))
+}
+
+// NonnegativeBalanceInvariant checks that no account holds a negative balance
+func NonnegativeBalanceInvariant(am auth.AccountMapper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		var err error
+		am.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+			coins := acc.GetCoins()
+			if !coins.IsNotNegative() {
+				err = fmt.Errorf("negative balance for account %v: %v", acc.GetAddress(), coins)
+				return true
+			}
+			return false
+		})
+		return err
+	}
+}
@@ -0,0 +1,41 @@
+package bank
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name of the bank module, used for its routes
+const ModuleName = "bank"
+
+// AppModule implements module.AppModule for the bank module,
+// which has no genesis state and no block hooks
+type AppModule struct {
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// nolint
+func (AppModule) Name() string                                       { return ModuleName }
+func (AppModule) Route() string                                      { return ModuleName }
+func (am AppModule) NewHandler() sdk.Handler                         { return NewHandler(am.keeper) }
+func (AppModule) QuerierRoute() string                               { return ModuleName }
+func (am AppModule) NewQuerierHandler() sdk.Querier                  { return NewQuerier(am.keeper) }
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter)       { RegisterInvariants(ir, am.keeper.am) }
+func (AppModule) DefaultGenesis() json.RawMessage                    { return nil }
+func (AppModule) ValidateGenesis(_ json.RawMessage) error            { return nil }
+func (AppModule) InitGenesis(_ sdk.Context, _ json.RawMessage) error { return nil }
+func (AppModule) ExportGenesis(_ sdk.Context) json.RawMessage        { return nil }
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return nil
+}
+func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return nil, nil
+}
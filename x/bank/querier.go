@@ -0,0 +1,44 @@
+package bank
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the bank Querier
+const (
+	QueryBalance = "balance"
+)
+
+// Params for query 'custom/bank/balance'
+type QueryBalanceParams struct {
+	Address sdk.Address
+}
+
+// NewQuerier returns the querier for the bank module
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no bank query endpoint specified")
+		}
+		switch path[0] {
+		case QueryBalance:
+			return queryBalance(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown bank query endpoint %s", path[0]))
+		}
+	}
+}
+
+func queryBalance(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryBalanceParams
+	if err := sdk.UnmarshalQueryParams(msgCdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	coins := keeper.GetCoins(ctx, params.Address)
+	return sdk.MarshalQueryResult(msgCdc, coins)
+}
@@ -0,0 +1,41 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+)
+
+// SimulateSingleInputMsgSend sends a random amount of a random coin of a
+// random account to another random account
+func SimulateSingleInputMsgSend(mapper auth.AccountMapper) simulation.Operation {
+	handler := bank.NewHandler(bank.NewKeeper(mapper))
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		from := simulation.RandomAcc(r, accs)
+		to := simulation.RandomAcc(r, accs)
+
+		acc := mapper.GetAccount(ctx, from.Address)
+		if acc == nil || len(acc.GetCoins()) == 0 {
+			return simulation.Noop("bank/MsgSend", fmt.Sprintf("account %v has no coins", from.Address), event)
+		}
+		coin := acc.GetCoins()[r.Intn(len(acc.GetCoins()))]
+		amount := simulation.RandomAmount(r, coin.Amount)
+		if amount.IsZero() {
+			return simulation.Noop("bank/MsgSend", "random amount is zero", event)
+		}
+		coins := sdk.Coins{sdk.Coin{Denom: coin.Denom, Amount: amount}}
+
+		msg := bank.MsgSend{
+			Inputs:  []bank.Input{bank.NewInput(from.Address, coins)},
+			Outputs: []bank.Output{bank.NewOutput(to.Address, coins)},
+		}
+		return simulation.RunMsg(ctx, handler, msg, "bank/MsgSend", event)
+	}
+}
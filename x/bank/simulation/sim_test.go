@@ -0,0 +1,61 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+)
+
+var denoms = []string{"foocoin", "barcoin"}
+
+func TestBankWithRandomMessages(t *testing.T) {
+	mapp := mock.NewApp()
+	bank.RegisterWire(mapp.Cdc)
+	mapp.Router().AddRoute("bank", bank.NewHandler(bank.NewKeeper(mapp.AccountMapper)))
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{}))
+
+	var totalSupply sdk.Coins
+	appStateFn := func(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+		mapp.GenesisAccounts = simulation.RandomGenesisAccounts(r, accs, denoms, 1000)
+		for _, acc := range mapp.GenesisAccounts {
+			totalSupply = totalSupply.Plus(acc.GetCoins())
+		}
+		return json.RawMessage("{}")
+	}
+
+	invariants := simulation.NewInvariants()
+	bank.RegisterInvariants(invariants, mapp.AccountMapper)
+	invariants.RegisterRoute("bank", "total-supply", totalSupplyInvariant(mapp.AccountMapper, &totalSupply))
+
+	simulation.Simulate(
+		t, mapp.BaseApp, appStateFn,
+		[]simulation.WeightedOperation{
+			{Weight: 1, Op: SimulateSingleInputMsgSend(mapp.AccountMapper)},
+		},
+		invariants, 100, 20,
+	)
+}
+
+// sends only move coins, the total supply is constant
+func totalSupplyInvariant(mapper auth.AccountMapper, totalSupply *sdk.Coins) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		var supply sdk.Coins
+		mapper.IterateAccounts(ctx, func(acc auth.Account) bool {
+			supply = supply.Plus(acc.GetCoins())
+			return false
+		})
+		if !supply.IsEqual(*totalSupply) {
+			return fmt.Errorf("total supply %v differs from the genesis supply %v", supply, *totalSupply)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+// command to verify an invariant, halting the chain if it is broken
+func GetCmdInvariantBroken(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invariant-broken [module-name] [invariant-route]",
+		Args:  cobra.ExactArgs(2),
+		Short: "submit proof that an invariant is broken to halt the chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			sender, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := crisis.NewMsgVerifyInvariant(sender, args[0], args[1])
+
+			// build and sign the transaction, then broadcast to Tendermint
+			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	return cmd
+}
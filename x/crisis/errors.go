@@ -0,0 +1,23 @@
+// nolint
+package crisis
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default crisis codespace
+	DefaultCodespace sdk.CodespaceType = 7
+
+	CodeInvalidInput CodeType = 101
+)
+
+func ErrNilSender(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "sender address is nil")
+}
+func ErrUnknownInvariant(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "unknown invariant")
+}
@@ -0,0 +1,43 @@
+package crisis
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all crisis state that must be provided at genesis
+type GenesisState struct {
+	ConstantFee sdk.Coin `json:"constant_fee"`
+}
+
+func NewGenesisState(constantFee sdk.Coin) GenesisState {
+	return GenesisState{
+		ConstantFee: constantFee,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		ConstantFee: sdk.NewCoin("steak", 1000),
+	}
+}
+
+// ValidateGenesis - check the constant fee is valid
+func ValidateGenesis(data GenesisState) error {
+	if data.ConstantFee.Denom == "" || !data.ConstantFee.IsPositive() {
+		return fmt.Errorf("invalid constant fee %v, must be positive", data.ConstantFee)
+	}
+	return nil
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	k.SetConstantFee(ctx, data.ConstantFee)
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return NewGenesisState(k.GetConstantFee(ctx))
+}
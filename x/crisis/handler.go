@@ -0,0 +1,70 @@
+package crisis
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "crisis" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgVerifyInvariant:
+			return handleMsgVerifyInvariant(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in crisis module").Result()
+		}
+	}
+}
+
+// Any account can verify an invariant by paying the constant fee, which is
+// burned. If the invariant is broken, the chain halts at the end of the block.
+func handleMsgVerifyInvariant(ctx sdk.Context, msg MsgVerifyInvariant, k Keeper) sdk.Result {
+	invarRoute, found := k.getRoute(msg.FullInvariantRoute())
+	if !found {
+		return ErrUnknownInvariant(k.codespace).Result()
+	}
+
+	constantFee := k.GetConstantFee(ctx)
+	_, _, err := k.coinKeeper.SubtractCoins(ctx, msg.Sender, sdk.Coins{constantFee})
+	if err != nil {
+		return err.Result()
+	}
+	k.burner.BurnLooseTokens(ctx, sdk.Coins{constantFee})
+
+	// the invariant must not modify the state, and isn't metered as its cost
+	// is covered by the constant fee
+	cacheCtx, _ := ctx.CacheContext()
+	invarErr := invarRoute.Invar(cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter()))
+
+	tags := sdk.NewTags(
+		"action", []byte("verifyInvariant"),
+		"sender", []byte(msg.Sender.String()),
+		"invariant", []byte(invarRoute.FullRoute()),
+	)
+	if invarErr == nil {
+		return sdk.Result{Tags: tags}
+	}
+
+	ctx.Logger().With("module", "x/crisis").Error(fmt.Sprintf(
+		"invariant %s broken, reported by %v: %v", invarRoute.FullRoute(), msg.Sender, invarErr))
+	if !ctx.IsCheckTx() {
+		k.setBrokenInvariant(ctx, invarRoute.FullRoute())
+	}
+	return sdk.Result{Tags: tags.AppendTag("broken", []byte(invarRoute.FullRoute()))}
+}
+
+// EndBlocker halts the chain if an invariant was found broken during the
+// block, and asserts all the invariants every invCheckPeriod blocks if
+// invCheckPeriod is not zero
+func EndBlocker(ctx sdk.Context, k Keeper, invCheckPeriod uint) {
+	if fullRoute, found := k.getBrokenInvariant(ctx); found {
+		panic(fmt.Sprintf("invariant %s broken at height %d, halting the chain", fullRoute, ctx.BlockHeight()))
+	}
+
+	if invCheckPeriod > 0 && ctx.BlockHeight()%int64(invCheckPeriod) == 0 {
+		k.AssertInvariants(ctx)
+	}
+}
@@ -0,0 +1,134 @@
+package crisis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+var (
+	sender     = sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address())
+	errBroken  = errors.New("broken")
+	initCoins  = sdk.Coins{sdk.NewCoin("steak", 10000)}
+	passing    = func(_ sdk.Context) error { return nil }
+	failing    = func(_ sdk.Context) error { return errBroken }
+	testFee    = sdk.NewCoin("steak", 1000)
+	testModule = "test"
+)
+
+// records the burned coins in place of the staking keeper
+type testBurner struct {
+	burned sdk.Coins
+}
+
+func (tb *testBurner) BurnLooseTokens(_ sdk.Context, coins sdk.Coins) {
+	tb.burned = tb.burned.Plus(coins)
+}
+
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyCrisis := sdk.NewKVStoreKey("crisis")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyCrisis, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, log.NewNopLogger())
+
+	cdc := wire.NewCodec()
+	auth.RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	ck := bank.NewKeeper(auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{}))
+	_, _, err := ck.AddCoins(ctx, sender, initCoins)
+	require.Nil(t, err)
+
+	keeper := NewKeeper(cdc, keyCrisis, ck, &testBurner{}, DefaultCodespace)
+	InitGenesis(ctx, keeper, NewGenesisState(testFee))
+	keeper.RegisterRoute(testModule, "passing", passing)
+	keeper.RegisterRoute(testModule, "failing", failing)
+	return ctx, ck, keeper
+}
+
+func TestRegisterRoute(t *testing.T) {
+	_, _, keeper := createTestInput(t)
+
+	routes := keeper.Routes()
+	require.Equal(t, 2, len(routes))
+	require.Equal(t, "test/passing", routes[0].FullRoute())
+	require.Equal(t, "test/failing", routes[1].FullRoute())
+
+	// copies of the keeper share the routes
+	keeperCopy := keeper
+	keeperCopy.RegisterRoute(testModule, "other", passing)
+	require.Equal(t, 3, len(keeper.Routes()))
+
+	// duplicate route
+	require.Panics(t, func() { keeper.RegisterRoute(testModule, "passing", passing) })
+}
+
+func TestHandleMsgVerifyInvariant(t *testing.T) {
+	ctx, ck, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	// unknown invariant
+	res := handler(ctx, NewMsgVerifyInvariant(sender, testModule, "unknown"))
+	require.False(t, res.IsOK())
+	require.True(t, ck.GetCoins(ctx, sender).IsEqual(initCoins))
+	burner := keeper.burner.(*testBurner)
+	require.True(t, burner.burned.IsZero())
+
+	// the constant fee is charged and burned
+	res = handler(ctx, NewMsgVerifyInvariant(sender, testModule, "passing"))
+	require.True(t, res.IsOK())
+	require.True(t, ck.GetCoins(ctx, sender).IsEqual(initCoins.Minus(sdk.Coins{testFee})))
+	require.True(t, burner.burned.IsEqual(sdk.Coins{testFee}))
+	require.NotPanics(t, func() { EndBlocker(ctx, keeper, 0) })
+
+	// a broken invariant halts the chain at the end of the block
+	res = handler(ctx, NewMsgVerifyInvariant(sender, testModule, "failing"))
+	require.True(t, res.IsOK())
+	require.Panics(t, func() { EndBlocker(ctx, keeper, 0) })
+}
+
+func TestHandleMsgVerifyInvariantCheckTx(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	// a broken invariant in CheckTx doesn't halt the chain
+	res := handler(ctx.WithIsCheckTx(true), NewMsgVerifyInvariant(sender, testModule, "failing"))
+	require.True(t, res.IsOK())
+	require.NotPanics(t, func() { EndBlocker(ctx, keeper, 0) })
+}
+
+func TestEndBlockerInvCheckPeriod(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+
+	// only the passing invariant
+	passingKeeper := NewKeeper(keeper.cdc, keeper.storeKey, keeper.coinKeeper, keeper.burner, DefaultCodespace)
+	passingKeeper.RegisterRoute(testModule, "passing", passing)
+	require.NotPanics(t, func() { EndBlocker(ctx.WithBlockHeight(10), passingKeeper, 5) })
+
+	// the invariants are asserted every 5 blocks
+	require.NotPanics(t, func() { EndBlocker(ctx.WithBlockHeight(9), keeper, 5) })
+	require.Panics(t, func() { EndBlocker(ctx.WithBlockHeight(10), keeper, 5) })
+	require.NotPanics(t, func() { EndBlocker(ctx.WithBlockHeight(10), keeper, 0) })
+}
+
+func TestMsgVerifyInvariantValidateBasic(t *testing.T) {
+	require.Nil(t, NewMsgVerifyInvariant(sender, testModule, "passing").ValidateBasic())
+	require.NotNil(t, NewMsgVerifyInvariant(nil, testModule, "passing").ValidateBasic())
+	require.NotNil(t, NewMsgVerifyInvariant(sender, "", "passing").ValidateBasic())
+	require.NotNil(t, NewMsgVerifyInvariant(sender, testModule, "").ValidateBasic())
+}
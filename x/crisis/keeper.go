@@ -0,0 +1,114 @@
+package crisis
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+var (
+	// key for the constant fee of MsgVerifyInvariant
+	ConstantFeeKey = []byte{0x00}
+	// key for the route of an invariant found broken in the current block
+	BrokenInvariantKey = []byte{0x01}
+)
+
+// Keeper - crisis keeper, holds the registered invariants
+type Keeper struct {
+	routes *[]InvarRoute // shared by the copies of the keeper
+
+	storeKey   sdk.StoreKey
+	cdc        *wire.Codec
+	coinKeeper bank.Keeper
+	burner     sdk.TokenBurner // removes the burned fees from the supply
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a new crisis Keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, burner sdk.TokenBurner, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		routes:     &[]InvarRoute{},
+		storeKey:   key,
+		cdc:        cdc,
+		coinKeeper: ck,
+		burner:     burner,
+		codespace:  codespace,
+	}
+}
+
+// RegisterRoute registers an invariant, implements sdk.InvariantRouter.
+// It panics on a duplicate route.
+func (k Keeper) RegisterRoute(moduleName, route string, invar sdk.Invariant) {
+	invarRoute := NewInvarRoute(moduleName, route, invar)
+	if _, found := k.getRoute(invarRoute.FullRoute()); found {
+		panic(fmt.Sprintf("invariant %s registered twice", invarRoute.FullRoute()))
+	}
+	*k.routes = append(*k.routes, invarRoute)
+}
+
+// Routes returns the registered invariants, in registration order
+func (k Keeper) Routes() []InvarRoute {
+	routes := make([]InvarRoute, len(*k.routes))
+	copy(routes, *k.routes)
+	return routes
+}
+
+func (k Keeper) getRoute(fullRoute string) (InvarRoute, bool) {
+	for _, invarRoute := range *k.routes {
+		if invarRoute.FullRoute() == fullRoute {
+			return invarRoute, true
+		}
+	}
+	return InvarRoute{}, false
+}
+
+// AssertInvariants checks all the registered invariants and panics on the
+// first broken one
+func (k Keeper) AssertInvariants(ctx sdk.Context) {
+	for _, invarRoute := range *k.routes {
+		// don't let the invariants modify the state
+		cacheCtx, _ := ctx.CacheContext()
+		if err := invarRoute.Invar(cacheCtx); err != nil {
+			panic(fmt.Sprintf("invariant %s broken at height %d: %v", invarRoute.FullRoute(), ctx.BlockHeight(), err))
+		}
+	}
+	ctx.Logger().With("module", "x/crisis").Info(fmt.Sprintf("asserted all %d invariants", len(*k.routes)))
+}
+
+// GetConstantFee - the fee to pay to verify an invariant
+func (k Keeper) GetConstantFee(ctx sdk.Context) (constantFee sdk.Coin) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(ConstantFeeKey)
+	if bz == nil {
+		panic("stored constant fee should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(bz, &constantFee)
+	return
+}
+
+// SetConstantFee - set the fee to pay to verify an invariant
+func (k Keeper) SetConstantFee(ctx sdk.Context, constantFee sdk.Coin) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(constantFee)
+	store.Set(ConstantFeeKey, bz)
+}
+
+// get the route of the invariant found broken, if any
+func (k Keeper) getBrokenInvariant(ctx sdk.Context) (fullRoute string, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(BrokenInvariantKey)
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// record a broken invariant, the chain halts at the end of the block
+func (k Keeper) setBrokenInvariant(ctx sdk.Context, fullRoute string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(BrokenInvariantKey, []byte(fullRoute))
+}
@@ -0,0 +1,80 @@
+package crisis
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name of the crisis module, used for its routes and genesis state
+const ModuleName = "crisis"
+
+// AppModule implements module.AppModule for the crisis module
+type AppModule struct {
+	keeper         Keeper
+	invCheckPeriod uint
+}
+
+// NewAppModule creates a new AppModule, asserting all the invariants every
+// invCheckPeriod blocks if invCheckPeriod is not zero
+func NewAppModule(keeper Keeper, invCheckPeriod uint) AppModule {
+	return AppModule{keeper: keeper, invCheckPeriod: invCheckPeriod}
+}
+
+// nolint
+func (AppModule) Name() string                             { return ModuleName }
+func (AppModule) Route() string                            { return ModuleName }
+func (am AppModule) NewHandler() sdk.Handler               { return NewHandler(am.keeper) }
+func (AppModule) QuerierRoute() string                     { return "" }
+func (AppModule) NewQuerierHandler() sdk.Querier           { return nil }
+func (AppModule) RegisterInvariants(_ sdk.InvariantRouter) {}
+
+// DefaultGenesis returns the default crisis genesis state
+func (am AppModule) DefaultGenesis() json.RawMessage {
+	return am.mustMarshalGenesis(DefaultGenesisState())
+}
+
+// ValidateGenesis validates the crisis genesis state
+func (am AppModule) ValidateGenesis(data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	return ValidateGenesis(genesisState)
+}
+
+// InitGenesis initializes the crisis state from its genesis state
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	InitGenesis(ctx, am.keeper, genesisState)
+	return nil
+}
+
+// ExportGenesis exports the crisis state as its genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return am.mustMarshalGenesis(WriteGenesis(ctx, am.keeper))
+}
+
+// BeginBlock is a no-op for the crisis module
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return nil
+}
+
+// EndBlock halts the chain on a broken invariant
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	EndBlocker(ctx, am.keeper, am.invCheckPeriod)
+	return nil, nil
+}
+
+func (am AppModule) mustMarshalGenesis(genesisState GenesisState) json.RawMessage {
+	bz, err := am.keeper.cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
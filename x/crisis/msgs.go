@@ -0,0 +1,64 @@
+package crisis
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "crisis"
+
+// verify interface at compile time
+var _ sdk.Msg = MsgVerifyInvariant{}
+
+// MsgVerifyInvariant - message to verify a registered invariant, halting
+// the chain if it is broken
+type MsgVerifyInvariant struct {
+	Sender              sdk.Address `json:"sender"`
+	InvariantModuleName string      `json:"invariant_module_name"`
+	InvariantRoute      string      `json:"invariant_route"`
+}
+
+func NewMsgVerifyInvariant(sender sdk.Address, invariantModuleName, invariantRoute string) MsgVerifyInvariant {
+	return MsgVerifyInvariant{
+		Sender:              sender,
+		InvariantModuleName: invariantModuleName,
+		InvariantRoute:      invariantRoute,
+	}
+}
+
+// nolint
+func (msg MsgVerifyInvariant) Type() string              { return MsgType }
+func (msg MsgVerifyInvariant) GetSigners() []sdk.Address { return []sdk.Address{msg.Sender} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgVerifyInvariant) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Sender              string `json:"sender"`
+		InvariantModuleName string `json:"invariant_module_name"`
+		InvariantRoute      string `json:"invariant_route"`
+	}{
+		Sender:              sdk.MustBech32ifyAcc(msg.Sender),
+		InvariantModuleName: msg.InvariantModuleName,
+		InvariantRoute:      msg.InvariantRoute,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgVerifyInvariant) ValidateBasic() sdk.Error {
+	if msg.Sender == nil {
+		return ErrNilSender(DefaultCodespace)
+	}
+	if msg.InvariantModuleName == "" || msg.InvariantRoute == "" {
+		return ErrUnknownInvariant(DefaultCodespace)
+	}
+	return nil
+}
+
+// FullInvariantRoute - the route of the invariant as registered, see InvarRoute
+func (msg MsgVerifyInvariant) FullInvariantRoute() string {
+	return msg.InvariantModuleName + "/" + msg.InvariantRoute
+}
@@ -0,0 +1,26 @@
+package crisis
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InvarRoute - an invariant registered by a module under a route
+type InvarRoute struct {
+	ModuleName string
+	Route      string
+	Invar      sdk.Invariant
+}
+
+// NewInvarRoute - create an InvarRoute object
+func NewInvarRoute(moduleName, route string, invar sdk.Invariant) InvarRoute {
+	return InvarRoute{
+		ModuleName: moduleName,
+		Route:      route,
+		Invar:      invar,
+	}
+}
+
+// FullRoute - get the full invariance route
+func (i InvarRoute) FullRoute() string {
+	return i.ModuleName + "/" + i.Route
+}
@@ -0,0 +1,40 @@
+package distribution
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker distributes the rewards of the previous block and records
+// the proposer of the current one, who is rewarded at the next block once
+// the precommits it included are known
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) sdk.Tags {
+	var signedPower, totalPower int64
+	for _, signingValidator := range req.Validators {
+		totalPower += signingValidator.Validator.Power
+		if signingValidator.SignedLastBlock {
+			signedPower += signingValidator.Validator.Power
+		}
+	}
+	signedFraction := sdk.ZeroRat()
+	if totalPower > 0 {
+		signedFraction = sdk.NewRat(signedPower, totalPower)
+	}
+	k.AllocateFees(ctx, k.GetPreviousProposer(ctx), signedFraction)
+
+	var proposer sdk.Address
+	if len(req.Header.Proposer.PubKey.Data) > 0 {
+		pubkey, err := tmtypes.PB2TM.PubKey(req.Header.Proposer.PubKey)
+		if err != nil {
+			panic(err)
+		}
+		if validator, found := k.stakeKeeper.GetValidatorByPubKey(ctx, pubkey); found {
+			proposer = validator.Owner
+		}
+	}
+	k.SetPreviousProposer(ctx, proposer)
+
+	return sdk.EmptyTags()
+}
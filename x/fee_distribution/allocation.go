@@ -0,0 +1,91 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// AllocateFees distributes the rewards of the block, the collected fees and
+// the inflation provisions, between the proposer of the previous block, the
+// community pool and the bonded validators by power. The proposer reward
+// grows with the fraction of the voting power whose precommits it
+// included. The rounding remainders go to the community pool.
+func (k Keeper) AllocateFees(ctx sdk.Context, proposer sdk.Address, signedFraction sdk.Rat) {
+	rewards := k.takeBlockRewards(ctx)
+	if rewards.IsZero() {
+		return
+	}
+	allocated := DecCoins{}
+
+	// reward the proposer
+	toValidators := rewards.MulRat(sdk.OneRat().Sub(k.CommunityTax(ctx)))
+	if proposer != nil {
+		if validator, found := k.stakeKeeper.GetValidator(ctx, proposer); found {
+			fraction := k.BaseProposerReward(ctx).Add(k.BonusProposerReward(ctx).Mul(signedFraction))
+			proposerReward := rewards.MulRat(fraction)
+			allocated = allocated.Plus(k.allocateToValidator(ctx, validator, proposerReward))
+			toValidators = toValidators.Minus(proposerReward)
+		}
+	}
+
+	// split the rest between the bonded validators by power
+	validators := k.stakeKeeper.GetValidatorsBonded(ctx)
+	totalPower := sdk.ZeroRat()
+	for _, validator := range validators {
+		totalPower = totalPower.Add(validator.GetPower())
+	}
+	if totalPower.GT(sdk.ZeroRat()) {
+		for _, validator := range validators {
+			reward := toValidators.MulRat(validator.GetPower().Quo(totalPower))
+			allocated = allocated.Plus(k.allocateToValidator(ctx, validator, reward))
+		}
+	}
+
+	// the community tax and the remainders go to the community pool
+	k.addToCommunityPool(ctx, rewards.Minus(allocated))
+}
+
+// take the collected fees and the provisions not yet distributed
+func (k Keeper) takeBlockRewards(ctx sdk.Context) DecCoins {
+	rewards := NewDecCoins(k.feeCollectionKeeper.GetCollectedFees(ctx))
+	k.feeCollectionKeeper.ClearCollectedFees(ctx)
+
+	pool := k.stakeKeeper.GetPool(ctx)
+	if pool.UndistributedProvisions > 0 {
+		provisions := sdk.NewCoin(k.stakeKeeper.GetParams(ctx).BondDenom, pool.UndistributedProvisions)
+		rewards = rewards.Plus(NewDecCoins(sdk.Coins{provisions}))
+		pool.UndistributedProvisions = 0
+		k.stakeKeeper.SetPool(ctx, pool)
+	}
+	return rewards
+}
+
+// give a reward to a validator, the commission goes to its owner and the
+// rest to its delegators, returns the part of the reward allocated
+func (k Keeper) allocateToValidator(ctx sdk.Context, validator stake.Validator, reward DecCoins) DecCoins {
+	if reward.IsZero() {
+		return DecCoins{}
+	}
+	info := k.GetValidatorDistInfo(ctx, validator.Owner)
+
+	// the delegators get everything if no commission is set, the owner if
+	// there are no delegators left
+	commission := DecCoins{}
+	switch {
+	case validator.DelegatorShares.IsZero():
+		commission = reward
+	case validator.Commission.Rat != nil:
+		commission = reward.MulRat(validator.Commission)
+	}
+	info.Commission = info.Commission.Plus(commission)
+	allocated := commission
+
+	if !validator.DelegatorShares.IsZero() {
+		rewardPerShare := reward.Minus(commission).QuoRat(validator.DelegatorShares)
+		info.RewardPerShare = info.RewardPerShare.Plus(rewardPerShare)
+		allocated = allocated.Plus(rewardPerShare.MulRat(validator.DelegatorShares))
+	}
+
+	k.SetValidatorDistInfo(ctx, validator.Owner, info)
+	return allocated
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	distr "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+)
+
+// nolint
+const (
+	FlagAddressDelegator = "address-delegator"
+	FlagAddressValidator = "address-validator"
+)
+
+// withdraw delegator reward command
+func GetCmdWithdrawDelegatorReward(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-rewards",
+		Short: "withdraw the rewards of a delegation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delegatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
+			if err != nil {
+				return err
+			}
+			validatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidator))
+			if err != nil {
+				return err
+			}
+
+			msg := distr.NewMsgWithdrawDelegatorReward(delegatorAddr, validatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+			return ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+		},
+	}
+
+	cmd.Flags().String(FlagAddressDelegator, "", "bech address of the delegator")
+	cmd.Flags().String(FlagAddressValidator, "", "bech address of the validator")
+	return cmd
+}
+
+// withdraw validator commission command
+func GetCmdWithdrawValidatorCommission(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-commission [validator-address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "withdraw the commission of a validator to its owner",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := distr.NewMsgWithdrawValidatorCommission(validatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+			return ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+		},
+	}
+	return cmd
+}
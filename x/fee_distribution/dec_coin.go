@@ -0,0 +1,179 @@
+package distribution
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// precision to which the reward amounts are kept, the fractions smaller
+// than 10^-12 go to the community pool
+var precision = big.NewInt(1000000000000)
+
+// DecCoin holds a fractional amount of one currency
+type DecCoin struct {
+	Denom  string  `json:"denom"`
+	Amount sdk.Rat `json:"amount"`
+}
+
+// NewDecCoin creates a DecCoin from a coin
+func NewDecCoin(coin sdk.Coin) DecCoin {
+	return DecCoin{
+		Denom:  coin.Denom,
+		Amount: sdk.NewRatFromInt(coin.Amount),
+	}
+}
+
+// String provides a human-readable representation of a coin
+func (coin DecCoin) String() string {
+	return fmt.Sprintf("%v%v", coin.Amount.FloatString(), coin.Denom)
+}
+
+//----------------------------------------
+
+// DecCoins is a set of DecCoin, one per currency, sorted by denom
+type DecCoins []DecCoin
+
+// NewDecCoins creates DecCoins from coins
+func NewDecCoins(coins sdk.Coins) DecCoins {
+	decCoins := make(DecCoins, 0, len(coins))
+	for _, coin := range coins {
+		if !coin.IsZero() {
+			decCoins = append(decCoins, NewDecCoin(coin))
+		}
+	}
+	return decCoins
+}
+
+// String provides a human-readable representation of the coins
+func (coins DecCoins) String() string {
+	if len(coins) == 0 {
+		return ""
+	}
+
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = coin.String()
+	}
+	return strings.Join(out, ",")
+}
+
+// Plus combines two sets of coins, the zero amounts are left out
+func (coins DecCoins) Plus(coinsB DecCoins) DecCoins {
+	sum := DecCoins{}
+	i, j := 0, 0
+	for i < len(coins) || j < len(coinsB) {
+		var coin DecCoin
+		switch {
+		case j == len(coinsB) || (i < len(coins) && coins[i].Denom < coinsB[j].Denom):
+			coin = coins[i]
+			i++
+		case i == len(coins) || coinsB[j].Denom < coins[i].Denom:
+			coin = coinsB[j]
+			j++
+		default:
+			coin = DecCoin{coins[i].Denom, coins[i].Amount.Add(coinsB[j].Amount)}
+			i++
+			j++
+		}
+		if !coin.Amount.IsZero() {
+			sum = append(sum, coin)
+		}
+	}
+	return sum
+}
+
+// Minus subtracts a set of coins
+func (coins DecCoins) Minus(coinsB DecCoins) DecCoins {
+	return coins.Plus(coinsB.Negative())
+}
+
+// Negative returns the coins with negated amounts
+func (coins DecCoins) Negative() DecCoins {
+	res := make(DecCoins, len(coins))
+	for i, coin := range coins {
+		res[i] = DecCoin{coin.Denom, sdk.ZeroRat().Sub(coin.Amount)}
+	}
+	return res
+}
+
+// MulRat multiplies all the amounts by a rational, rounded down to the
+// distribution precision
+func (coins DecCoins) MulRat(r sdk.Rat) DecCoins {
+	res := DecCoins{}
+	for _, coin := range coins {
+		amount := truncate(coin.Amount.Mul(r))
+		if !amount.IsZero() {
+			res = append(res, DecCoin{coin.Denom, amount})
+		}
+	}
+	return res
+}
+
+// QuoRat divides all the amounts by a rational, rounded down to the
+// distribution precision
+func (coins DecCoins) QuoRat(r sdk.Rat) DecCoins {
+	return coins.MulRat(sdk.OneRat().Quo(r))
+}
+
+// TruncateDecimal splits the coins into whole coins and their fractional
+// change
+func (coins DecCoins) TruncateDecimal() (sdk.Coins, DecCoins) {
+	whole := sdk.Coins{}
+	change := DecCoins{}
+	for _, coin := range coins {
+		amount := new(big.Int).Div(coin.Amount.Num().BigInt(), coin.Amount.Denom().BigInt())
+		if amount.Sign() != 0 {
+			whole = append(whole, sdk.Coin{Denom: coin.Denom, Amount: sdk.NewIntFromBigInt(amount)})
+		}
+		fraction := coin.Amount.Sub(sdk.NewRatFromBigInt(amount))
+		if !fraction.IsZero() {
+			change = append(change, DecCoin{coin.Denom, fraction})
+		}
+	}
+	return whole, change
+}
+
+// AmountOf returns the amount of a denom
+func (coins DecCoins) AmountOf(denom string) sdk.Rat {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return sdk.ZeroRat()
+}
+
+// IsZero returns whether all the amounts are zero
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNotNegative returns whether none of the amounts are negative
+func (coins DecCoins) IsNotNegative() bool {
+	for _, coin := range coins {
+		if coin.Amount.LT(sdk.ZeroRat()) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEqual returns whether two sets of coins have the same amounts
+func (coins DecCoins) IsEqual(coinsB DecCoins) bool {
+	return coins.Minus(coinsB).IsZero()
+}
+
+// round a rational down to the distribution precision
+func truncate(r sdk.Rat) sdk.Rat {
+	scaled := new(big.Int).Mul(r.Num().BigInt(), precision)
+	scaled.Div(scaled, r.Denom().BigInt())
+	return sdk.NewRatFromBigInt(scaled, precision)
+}
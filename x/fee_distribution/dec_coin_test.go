@@ -0,0 +1,33 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestDecCoinsArithmetic(t *testing.T) {
+	a := NewDecCoins(sdk.Coins{sdk.NewCoin("atom", 3), sdk.NewCoin("steak", 10)})
+	b := DecCoins{{"btc", sdk.NewRat(1, 2)}, {"steak", sdk.NewRat(-10)}}
+
+	// zero amounts are left out of the sum, denoms stay sorted
+	sum := a.Plus(b)
+	require.Equal(t, 2, len(sum))
+	require.Equal(t, "atom", sum[0].Denom)
+	require.Equal(t, "btc", sum[1].Denom)
+	require.True(t, sum.Minus(b).IsEqual(a))
+	require.False(t, b.IsNotNegative())
+
+	// products are rounded down to the precision
+	third := a.MulRat(sdk.NewRat(1, 3))
+	require.True(t, third.AmountOf("atom").Equal(sdk.OneRat()))
+	require.True(t, third.AmountOf("steak").Equal(sdk.NewRat(3333333333333, 1000000000000)))
+	require.True(t, a.QuoRat(sdk.NewRat(3)).IsEqual(third))
+
+	// the fractions are split from the whole coins
+	coins, change := DecCoins{{"atom", sdk.NewRat(7, 2)}, {"btc", sdk.NewRat(1, 3)}}.TruncateDecimal()
+	require.Equal(t, sdk.Coins{sdk.NewCoin("atom", 3)}, coins)
+	require.True(t, change.IsEqual(DecCoins{{"atom", sdk.NewRat(1, 2)}, {"btc", sdk.NewRat(1, 3)}}))
+}
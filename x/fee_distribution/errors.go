@@ -0,0 +1,31 @@
+// nolint
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default distribution codespace
+	DefaultCodespace sdk.CodespaceType = 9
+
+	CodeInvalidAddress CodeType = 101
+	CodeNoDelegation   CodeType = 102
+	CodeNoValidator    CodeType = 103
+)
+
+func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "delegator address is nil")
+}
+func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "validator address is nil")
+}
+func ErrNoDelegation(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoDelegation, "no delegation for this (address, validator) pair")
+}
+func ErrNoValidator(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoValidator, "validator does not exist for that address")
+}
@@ -0,0 +1,122 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all distribution state that must be provided at genesis
+type GenesisState struct {
+	Params              Params                      `json:"params"`
+	FeePool             FeePool                     `json:"fee_pool"`
+	ValidatorDistInfos  []GenesisValidatorDistInfo  `json:"validator_dist_infos"`
+	DelegationDistInfos []GenesisDelegationDistInfo `json:"delegation_dist_infos"`
+	PreviousProposer    sdk.Address                 `json:"previous_proposer"`
+}
+
+// GenesisValidatorDistInfo - the distribution info of a validator, by
+// validator address
+type GenesisValidatorDistInfo struct {
+	ValidatorAddr sdk.Address       `json:"validator_addr"`
+	DistInfo      ValidatorDistInfo `json:"dist_info"`
+}
+
+// GenesisDelegationDistInfo - the distribution info of a delegation, by
+// delegator and validator address
+type GenesisDelegationDistInfo struct {
+	DelegatorAddr sdk.Address        `json:"delegator_addr"`
+	ValidatorAddr sdk.Address        `json:"validator_addr"`
+	DistInfo      DelegationDistInfo `json:"dist_info"`
+}
+
+func NewGenesisState(params Params, feePool FeePool, validatorDistInfos []GenesisValidatorDistInfo,
+	delegationDistInfos []GenesisDelegationDistInfo, previousProposer sdk.Address) GenesisState {
+
+	return GenesisState{
+		Params:              params,
+		FeePool:             feePool,
+		ValidatorDistInfos:  validatorDistInfos,
+		DelegationDistInfos: delegationDistInfos,
+		PreviousProposer:    previousProposer,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:              DefaultParams(),
+		FeePool:             InitialFeePool(),
+		ValidatorDistInfos:  []GenesisValidatorDistInfo{},
+		DelegationDistInfos: []GenesisDelegationDistInfo{},
+	}
+}
+
+// ValidateGenesis - check the parameters are valid and the pools and
+// accumulated rewards are not negative
+func ValidateGenesis(data GenesisState) error {
+	if err := validateParams(data.Params); err != nil {
+		return err
+	}
+	if !data.FeePool.CommunityPool.IsNotNegative() {
+		return fmt.Errorf("invalid community pool %v, must not be negative", data.FeePool.CommunityPool)
+	}
+	for _, info := range data.ValidatorDistInfos {
+		if !info.DistInfo.RewardPerShare.IsNotNegative() || !info.DistInfo.Commission.IsNotNegative() {
+			return fmt.Errorf("invalid distribution info in genesis state: validator %v", info.ValidatorAddr)
+		}
+	}
+	for _, info := range data.DelegationDistInfos {
+		if !info.DistInfo.RewardPerShare.IsNotNegative() {
+			return fmt.Errorf("invalid distribution info in genesis state: delegation from %v to %v",
+				info.DelegatorAddr, info.ValidatorAddr)
+		}
+	}
+	return nil
+}
+
+// InitGenesis - store the genesis parameters and distribution state
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	keeper.SetFeePool(ctx, data.FeePool)
+	for _, info := range data.ValidatorDistInfos {
+		keeper.SetValidatorDistInfo(ctx, info.ValidatorAddr, info.DistInfo)
+	}
+	for _, info := range data.DelegationDistInfos {
+		keeper.SetDelegationDistInfo(ctx, info.DelegatorAddr, info.ValidatorAddr, info.DistInfo)
+	}
+	keeper.SetPreviousProposer(ctx, data.PreviousProposer)
+}
+
+// WriteGenesis - output the parameters and the distribution state
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	validatorDistInfos := []GenesisValidatorDistInfo{}
+	keeper.IterateValidatorDistInfos(ctx, func(validatorAddr sdk.Address, info ValidatorDistInfo) (stop bool) {
+		validatorDistInfos = append(validatorDistInfos, GenesisValidatorDistInfo{validatorAddr, info})
+		return false
+	})
+	delegationDistInfos := []GenesisDelegationDistInfo{}
+	keeper.IterateDelegationDistInfos(ctx, func(delegatorAddr, validatorAddr sdk.Address, info DelegationDistInfo) (stop bool) {
+		delegationDistInfos = append(delegationDistInfos, GenesisDelegationDistInfo{delegatorAddr, validatorAddr, info})
+		return false
+	})
+	return NewGenesisState(keeper.GetParams(ctx), keeper.GetFeePool(ctx),
+		validatorDistInfos, delegationDistInfos, keeper.GetPreviousProposer(ctx))
+}
+
+func validateParams(params Params) error {
+	for name, fraction := range map[string]sdk.Rat{
+		"community tax":         params.CommunityTax,
+		"base proposer reward":  params.BaseProposerReward,
+		"bonus proposer reward": params.BonusProposerReward,
+	} {
+		if fraction.Rat == nil || fraction.LT(sdk.ZeroRat()) || fraction.GT(sdk.OneRat()) {
+			return fmt.Errorf("invalid %s %v, must be between 0 and 1", name, fraction)
+		}
+	}
+	total := params.CommunityTax.Add(params.BaseProposerReward).Add(params.BonusProposerReward)
+	if total.GT(sdk.OneRat()) {
+		return fmt.Errorf("invalid community tax and proposer rewards, their sum %v exceeds 1", total)
+	}
+	return nil
+}
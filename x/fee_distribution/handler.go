@@ -0,0 +1,61 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+var (
+	ActionWithdrawDelegatorReward     = []byte("withdraw-delegator-reward")
+	ActionWithdrawValidatorCommission = []byte("withdraw-validator-commission")
+
+	TagValidator = "validator"
+	TagAmount    = "amount"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgWithdrawDelegatorReward:
+			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
+		case MsgWithdrawValidatorCommission:
+			return handleMsgWithdrawValidatorCommission(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
+		}
+	}
+}
+
+func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg MsgWithdrawDelegatorReward, k Keeper) sdk.Result {
+	coins, err := k.WithdrawDelegatorReward(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		sdk.TagAction, ActionWithdrawDelegatorReward,
+		sdk.TagDelegator, []byte(msg.DelegatorAddr.String()),
+		TagValidator, []byte(msg.ValidatorAddr.String()),
+		TagAmount, []byte(coins.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgWithdrawValidatorCommission(ctx sdk.Context, msg MsgWithdrawValidatorCommission, k Keeper) sdk.Result {
+	coins, err := k.WithdrawValidatorCommission(ctx, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		sdk.TagAction, ActionWithdrawValidatorCommission,
+		TagValidator, []byte(msg.ValidatorAddr.String()),
+		TagAmount, []byte(coins.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
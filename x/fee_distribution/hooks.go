@@ -0,0 +1,58 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Hooks settles the rewards of the delegations before the staking keeper
+// changes their shares, as the rewards are accounted per share
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the distribution keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// OnValidatorCreated starts the distribution info of the validator
+func (h Hooks) OnValidatorCreated(ctx sdk.Context, valAddr sdk.Address) {
+	h.k.SetValidatorDistInfo(ctx, valAddr, NewValidatorDistInfo())
+}
+
+// nolint - unused hooks
+func (h Hooks) OnValidatorBonded(_ sdk.Context, _ sdk.Address, _ sdk.Address)         {}
+func (h Hooks) OnValidatorBeginUnbonding(_ sdk.Context, _ sdk.Address, _ sdk.Address) {}
+
+// OnValidatorRemoved pays out the remaining commission of the validator,
+// the change goes to the community pool
+func (h Hooks) OnValidatorRemoved(ctx sdk.Context, valAddr sdk.Address) {
+	info := h.k.GetValidatorDistInfo(ctx, valAddr)
+	coins, change := info.Commission.TruncateDecimal()
+	h.k.addToCommunityPool(ctx, change)
+	h.k.addCoins(ctx, valAddr, coins)
+	h.k.RemoveValidatorDistInfo(ctx, valAddr)
+}
+
+// OnDelegationCreated starts the delegation at the current reward per share
+// of its validator
+func (h Hooks) OnDelegationCreated(ctx sdk.Context, delAddr sdk.Address, valAddr sdk.Address) {
+	valInfo := h.k.GetValidatorDistInfo(ctx, valAddr)
+	h.k.SetDelegationDistInfo(ctx, delAddr, valAddr, NewDelegationDistInfo(valInfo.RewardPerShare))
+}
+
+// OnDelegationSharesModified withdraws the reward earned with the previous
+// shares
+func (h Hooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.Address, valAddr sdk.Address) {
+	if _, err := h.k.WithdrawDelegatorReward(ctx, delAddr, valAddr); err != nil {
+		panic(err)
+	}
+}
+
+// OnDelegationRemoved withdraws the last reward of the delegation
+func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.Address, valAddr sdk.Address) {
+	h.OnDelegationSharesModified(ctx, delAddr, valAddr)
+	h.k.RemoveDelegationDistInfo(ctx, delAddr, valAddr)
+}
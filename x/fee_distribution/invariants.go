@@ -0,0 +1,35 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the distribution invariants under the given
+// module name
+func RegisterInvariants(ir sdk.InvariantRouter, moduleName string, k Keeper) {
+	ir.RegisterRoute(moduleName, "nonnegative-rewards", NonNegativeRewardsInvariant(k))
+}
+
+// NonNegativeRewardsInvariant checks that the community pool and the
+// rewards and commissions of the validators are not negative
+func NonNegativeRewardsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		communityPool := k.GetFeePool(ctx).CommunityPool
+		if !communityPool.IsNotNegative() {
+			return fmt.Errorf("negative community pool: %v", communityPool)
+		}
+
+		var err error
+		k.IterateValidatorDistInfos(ctx, func(validatorAddr sdk.Address, info ValidatorDistInfo) (stop bool) {
+			if !info.RewardPerShare.IsNotNegative() || !info.Commission.IsNotNegative() {
+				err = fmt.Errorf("negative rewards of validator %v: reward per share %v, commission %v",
+					validatorAddr, info.RewardPerShare, info.Commission)
+				return true
+			}
+			return false
+		})
+		return err
+	}
+}
@@ -1,91 +1,214 @@
-package stake
-
-//// keeper of the staking store
-//type Keeper struct {
-//storeKey   sdk.StoreKey
-//cdc        *wire.Codec
-//coinKeeper bank.Keeper
-
-//// codespace
-//codespace sdk.CodespaceType
-//}
-
-//func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
-//keeper := Keeper{
-//storeKey:   key,
-//cdc:        cdc,
-//coinKeeper: ck,
-//codespace:  codespace,
-//}
-//return keeper
-//}
-
-////_________________________________________________________________________
-
-//// cummulative power of the non-absent prevotes
-//func (k Keeper) GetTotalPrecommitVotingPower(ctx sdk.Context) sdk.Rat {
-//store := ctx.KVStore(k.storeKey)
-
-//// get absent prevote indexes
-//absents := ctx.AbsentValidators()
-
-//TotalPower := sdk.ZeroRat()
-//i := int32(0)
-//iterator := store.SubspaceIterator(ValidatorsBondedKey)
-//for ; iterator.Valid(); iterator.Next() {
-
-//skip := false
-//for j, absentIndex := range absents {
-//if absentIndex > i {
-//break
-//}
-
-//// if non-voting validator found, skip adding its power
-//if absentIndex == i {
-//absents = append(absents[:j], absents[j+1:]...) // won't need again
-//skip = true
-//break
-//}
-//}
-//if skip {
-//continue
-//}
-
-//bz := iterator.Value()
-//var validator Validator
-//k.cdc.MustUnmarshalBinary(bz, &validator)
-//TotalPower = TotalPower.Add(validator.Power)
-//i++
-//}
-//iterator.Close()
-//return TotalPower
-//}
-
-////_______________________________________________________________________
-
-//// XXX TODO trim functionality
-
-//// retrieve all the power changes which occur after a height
-//func (k Keeper) GetPowerChangesAfterHeight(ctx sdk.Context, earliestHeight int64) (pcs []PowerChange) {
-//store := ctx.KVStore(k.storeKey)
-
-//iterator := store.SubspaceIterator(PowerChangeKey) //smallest to largest
-//for ; iterator.Valid(); iterator.Next() {
-//pcBytes := iterator.Value()
-//var pc PowerChange
-//k.cdc.MustUnmarshalBinary(pcBytes, &pc)
-//if pc.Height < earliestHeight {
-//break
-//}
-//pcs = append(pcs, pc)
-//}
-//iterator.Close()
-//return
-//}
-
-//// set a power change
-//func (k Keeper) setPowerChange(ctx sdk.Context, pc PowerChange) {
-//store := ctx.KVStore(k.storeKey)
-//b := k.cdc.MustMarshalBinary(pc)
-//store.Set(GetPowerChangeKey(pc.Height), b)
-//}
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// nolint
+var (
+	FeePoolKey            = []byte{0x00} // key for the global fee pool
+	ValidatorDistInfoKey  = []byte{0x01} // prefix for the distribution infos of the validators
+	DelegationDistInfoKey = []byte{0x02} // prefix for the distribution infos of the delegations
+	PreviousProposerKey   = []byte{0x03} // key for the proposer of the previous block
+)
+
+// get the key for the distribution info of a validator
+func GetValidatorDistInfoKey(validatorAddr sdk.Address) []byte {
+	return append(ValidatorDistInfoKey, validatorAddr.Bytes()...)
+}
+
+// get the key for the distribution info of a delegation
+func GetDelegationDistInfoKey(delegatorAddr, validatorAddr sdk.Address) []byte {
+	return append(GetDelegationDistInfosKey(delegatorAddr), validatorAddr.Bytes()...)
+}
+
+// get the prefix for the distribution infos of all the delegations of a
+// delegator
+func GetDelegationDistInfosKey(delegatorAddr sdk.Address) []byte {
+	return append(DelegationDistInfoKey, delegatorAddr.Bytes()...)
+}
+
+// Keeper of the distribution store, the block rewards are allocated
+// eagerly to the validators and lazily to their delegators, who withdraw
+// them when their delegation changes or on request
+type Keeper struct {
+	storeKey            sdk.StoreKey
+	cdc                 *wire.Codec
+	paramspace          params.Subspace
+	stakeKeeper         stake.Keeper
+	coinKeeper          bank.Keeper
+	feeCollectionKeeper auth.FeeCollectionKeeper
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a distribution keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, paramspace params.Subspace, sk stake.Keeper,
+	ck bank.Keeper, fck auth.FeeCollectionKeeper, codespace sdk.CodespaceType) Keeper {
+
+	return Keeper{
+		storeKey:            key,
+		cdc:                 cdc,
+		paramspace:          paramspace.WithKeyTable(ParamKeyTable()),
+		stakeKeeper:         sk,
+		coinKeeper:          ck,
+		feeCollectionKeeper: fck,
+		codespace:           codespace,
+	}
+}
+
+//______________________________________________________________________
+
+// get the global fee pool
+func (k Keeper) GetFeePool(ctx sdk.Context) (feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(FeePoolKey)
+	if bz == nil {
+		return InitialFeePool()
+	}
+	k.cdc.MustUnmarshalBinary(bz, &feePool)
+	return
+}
+
+// set the global fee pool
+func (k Keeper) SetFeePool(ctx sdk.Context, feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(FeePoolKey, k.cdc.MustMarshalBinary(feePool))
+}
+
+// add coins to the community pool
+func (k Keeper) addToCommunityPool(ctx sdk.Context, coins DecCoins) {
+	if coins.IsZero() {
+		return
+	}
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(coins)
+	k.SetFeePool(ctx, feePool)
+}
+
+// get the distribution info of a validator, a validator without info
+// hasn't received any reward yet
+func (k Keeper) GetValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address) (info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorDistInfoKey(validatorAddr))
+	if bz == nil {
+		return NewValidatorDistInfo()
+	}
+	k.cdc.MustUnmarshalBinary(bz, &info)
+	return
+}
+
+// set the distribution info of a validator
+func (k Keeper) SetValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address, info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorDistInfoKey(validatorAddr), k.cdc.MustMarshalBinary(info))
+}
+
+// remove the distribution info of a validator
+func (k Keeper) RemoveValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorDistInfoKey(validatorAddr))
+}
+
+// iterate over the distribution infos of all the validators
+func (k Keeper) IterateValidatorDistInfos(ctx sdk.Context,
+	fn func(validatorAddr sdk.Address, info ValidatorDistInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, ValidatorDistInfoKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var info ValidatorDistInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &info)
+		if fn(iter.Key()[1:], info) {
+			break
+		}
+	}
+}
+
+// get the distribution info of a delegation, a delegation without info
+// was created before any reward of its validator
+func (k Keeper) GetDelegationDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (info DelegationDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDelegationDistInfoKey(delegatorAddr, validatorAddr))
+	if bz == nil {
+		return NewDelegationDistInfo(DecCoins{})
+	}
+	k.cdc.MustUnmarshalBinary(bz, &info)
+	return
+}
+
+// set the distribution info of a delegation
+func (k Keeper) SetDelegationDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address, info DelegationDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDelegationDistInfoKey(delegatorAddr, validatorAddr), k.cdc.MustMarshalBinary(info))
+}
+
+// remove the distribution info of a delegation
+func (k Keeper) RemoveDelegationDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegationDistInfoKey(delegatorAddr, validatorAddr))
+}
+
+// iterate over the distribution infos of all the delegations
+func (k Keeper) IterateDelegationDistInfos(ctx sdk.Context,
+	fn func(delegatorAddr, validatorAddr sdk.Address, info DelegationDistInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, DelegationDistInfoKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var info DelegationDistInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &info)
+		key := iter.Key()[1:]
+		if fn(key[:sdk.AddrLen], key[sdk.AddrLen:], info) {
+			break
+		}
+	}
+}
+
+// get the owner of the validator which proposed the previous block, nil at
+// the first block
+func (k Keeper) GetPreviousProposer(ctx sdk.Context) sdk.Address {
+	store := ctx.KVStore(k.storeKey)
+	return store.Get(PreviousProposerKey)
+}
+
+// set the owner of the validator which proposed the previous block
+func (k Keeper) SetPreviousProposer(ctx sdk.Context, proposer sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	if proposer == nil {
+		store.Delete(PreviousProposerKey)
+		return
+	}
+	store.Set(PreviousProposerKey, proposer)
+}
+
+//______________________________________________________________________
+
+// HeldTokens returns the amount of a denom held as rewards not yet
+// withdrawn: the community pool, the commissions of the validators and the
+// rewards of the delegations. Implements the token holder of the stake
+// supply invariant.
+func (k Keeper) HeldTokens(ctx sdk.Context, denom string) sdk.Rat {
+	held := k.GetFeePool(ctx).CommunityPool.AmountOf(denom)
+	k.IterateValidatorDistInfos(ctx, func(_ sdk.Address, info ValidatorDistInfo) (stop bool) {
+		held = held.Add(info.Commission.AmountOf(denom))
+		return false
+	})
+	k.IterateDelegationDistInfos(ctx, func(delegatorAddr, validatorAddr sdk.Address, _ DelegationDistInfo) (stop bool) {
+		reward, err := k.GetDelegationReward(ctx, delegatorAddr, validatorAddr)
+		if err != nil {
+			panic(err)
+		}
+		held = held.Add(reward.AmountOf(denom))
+		return false
+	})
+	return held
+}
@@ -1,31 +1,173 @@
-package stake
-
-//// test if is a gotValidator from the last update
-//func TestGetTotalPrecommitVotingPower(t *testing.T) {
-//ctx, _, keeper := createTestInput(t, false, 0)
-
-//amts := []int64{10000, 1000, 100, 10, 1}
-//var candidatesIn [5]Candidate
-//for i, amt := range amts {
-//candidatesIn[i] = NewCandidate(addrVals[i], pks[i], Description{})
-//candidatesIn[i].BondedShares = sdk.NewRat(amt)
-//candidatesIn[i].DelegatorShares = sdk.NewRat(amt)
-//keeper.setCandidate(ctx, candidatesIn[i])
-//}
-
-//// test that an empty gotValidator set doesn't have any gotValidators
-//gotValidators := keeper.GetValidators(ctx)
-//require.Equal(t, 5, len(gotValidators))
-
-//totPow := keeper.GetTotalPrecommitVotingPower(ctx)
-//exp := sdk.NewRat(11111)
-//require.True(t, exp.Equal(totPow), "exp %v, got %v", exp, totPow)
-
-//// set absent gotValidators to be the 1st and 3rd record sorted by pubKey address
-//ctx = ctx.WithAbsentValidators([]int32{1, 3})
-//totPow = keeper.GetTotalPrecommitVotingPower(ctx)
-
-//// XXX verify that this order should infact exclude these two records
-//exp = sdk.NewRat(11100)
-//require.True(t, exp.Equal(totPow), "exp %v, got %v", exp, totPow)
-//}
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestAllocateFees(t *testing.T) {
+	ctx, ck, sk, fck, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+
+	// two validators of equal power, the first with a 10% commission
+	require.True(t, stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 100, sdk.NewRat(1, 10))).IsOK())
+	require.True(t, stakeHandler(ctx, newTestMsgCreateValidator(addrs[1], pks[1], 100, sdk.ZeroRat())).IsOK())
+
+	// the first validator proposed a block with all the precommits
+	fck.AddCollectedFees(ctx, sdk.Coins{sdk.NewCoin("steak", 1000)})
+	keeper.AllocateFees(ctx, addrs[0], sdk.OneRat())
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+
+	// the proposer gets 5%, the community 2% and the rest is split by power
+	info := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, info.Commission.IsEqual(DecCoins{{"steak", sdk.NewRat(103, 2)}}))
+	require.True(t, info.RewardPerShare.IsEqual(DecCoins{{"steak", sdk.NewRat(927, 200)}}))
+	info = keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(t, info.Commission.IsZero())
+	require.True(t, info.RewardPerShare.IsEqual(DecCoins{{"steak", sdk.NewRat(93, 20)}}))
+	require.True(t, keeper.GetFeePool(ctx).CommunityPool.IsEqual(DecCoins{{"steak", sdk.NewRat(20)}}))
+	require.True(t, keeper.HeldTokens(ctx, "steak").Equal(sdk.NewRat(1000)))
+
+	// the delegator reward is paid in whole coins, the change goes to the
+	// community pool
+	coins, err := keeper.WithdrawDelegatorReward(ctx, addrs[0], addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 463)}, coins)
+	require.Equal(t, int64(563), ck.GetCoins(ctx, addrs[0]).AmountOf("steak").Int64())
+	require.True(t, keeper.GetFeePool(ctx).CommunityPool.IsEqual(DecCoins{{"steak", sdk.NewRat(41, 2)}}))
+	require.True(t, keeper.HeldTokens(ctx, "steak").Equal(sdk.NewRat(537)))
+
+	// the commission change is kept by the validator
+	coins, err = keeper.WithdrawValidatorCommission(ctx, addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 51)}, coins)
+	require.Equal(t, int64(614), ck.GetCoins(ctx, addrs[0]).AmountOf("steak").Int64())
+	info = keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, info.Commission.IsEqual(DecCoins{{"steak", sdk.NewRat(1, 2)}}))
+	require.True(t, keeper.HeldTokens(ctx, "steak").Equal(sdk.NewRat(486)))
+
+	// nothing is left to withdraw
+	coins, err = keeper.WithdrawDelegatorReward(ctx, addrs[0], addrs[0])
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	_, err = keeper.WithdrawDelegatorReward(ctx, addrs[2], addrs[0])
+	require.NotNil(t, err)
+
+	// no commission is taken once the validator edited it to zero
+	rate := sdk.ZeroRat()
+	require.True(t, stakeHandler(ctx, stake.NewMsgEditValidator(addrs[0], stake.Description{}, &rate)).IsOK())
+	fck.AddCollectedFees(ctx, sdk.Coins{sdk.NewCoin("steak", 1000)})
+	keeper.AllocateFees(ctx, nil, sdk.ZeroRat())
+	info = keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, info.Commission.IsEqual(DecCoins{{"steak", sdk.NewRat(1, 2)}}))
+}
+
+func TestAllocateProvisions(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	require.True(t, stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 100, sdk.ZeroRat())).IsOK())
+
+	pool := sk.GetPool(ctx)
+	pool.UndistributedProvisions = 100
+	sk.SetPool(ctx, pool)
+
+	// the provisions are distributed once
+	keeper.AllocateFees(ctx, nil, sdk.ZeroRat())
+	require.Equal(t, int64(0), sk.GetPool(ctx).UndistributedProvisions)
+	reward, err := keeper.GetDelegationReward(ctx, addrs[0], addrs[0])
+	require.Nil(t, err)
+	require.True(t, reward.IsEqual(DecCoins{{"steak", sdk.NewRat(98)}}))
+}
+
+func TestRewardsThroughDelegationChanges(t *testing.T) {
+	ctx, ck, sk, fck, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	handler := NewHandler(keeper)
+
+	// everything goes to the validators by power
+	keeper.SetParams(ctx, Params{sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat()})
+	totalFees := int64(0)
+	allocate := func(amt int64) {
+		fck.AddCollectedFees(ctx, sdk.Coins{sdk.NewCoin("steak", amt)})
+		keeper.AllocateFees(ctx, nil, sdk.ZeroRat())
+		totalFees += amt
+	}
+	requireReward := func(delAddr, valAddr sdk.Address, expected int64) {
+		reward, err := keeper.GetDelegationReward(ctx, delAddr, valAddr)
+		require.Nil(t, err)
+		require.True(t, reward.IsEqual(DecCoins{{"steak", sdk.NewRat(expected)}}), "%v", reward)
+	}
+
+	require.True(t, stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 100, sdk.ZeroRat())).IsOK())
+	require.True(t, stakeHandler(ctx, newTestMsgCreateValidator(addrs[1], pks[1], 200, sdk.ZeroRat())).IsOK())
+	msgDelegate := stake.NewMsgDelegate(addrs[2], addrs[0], sdk.NewCoin("steak", 100))
+	require.True(t, stakeHandler(ctx, msgDelegate).IsOK())
+	allocate(400)
+	requireReward(addrs[2], addrs[0], 100)
+
+	// the reward is withdrawn before the delegation grows
+	msgDelegate = stake.NewMsgDelegate(addrs[2], addrs[0], sdk.NewCoin("steak", 50))
+	require.True(t, stakeHandler(ctx, msgDelegate).IsOK())
+	require.Equal(t, int64(150), ck.GetCoins(ctx, addrs[2]).AmountOf("steak").Int64())
+	requireReward(addrs[2], addrs[0], 0)
+	allocate(450)
+	requireReward(addrs[2], addrs[0], 150)
+
+	// slashing changes the power of the validator, not the rewards
+	sk.Slash(ctx, pks[0], ctx.BlockHeight(), 250, sdk.NewRat(1, 10))
+	requireReward(addrs[2], addrs[0], 150)
+
+	// the reward is withdrawn before a redelegation
+	msgRedelegate := stake.NewMsgBeginRedelegate(addrs[2], addrs[0], addrs[1], sdk.NewRat(50))
+	require.True(t, stakeHandler(ctx, msgRedelegate).IsOK())
+	require.Equal(t, int64(300), ck.GetCoins(ctx, addrs[2]).AmountOf("steak").Int64())
+	requireReward(addrs[2], addrs[0], 0)
+	requireReward(addrs[2], addrs[1], 0)
+	allocate(1000)
+
+	// and before the delegation is removed
+	msgUnbond := stake.NewMsgBeginUnbonding(addrs[2], addrs[0], sdk.NewRat(100))
+	require.True(t, stakeHandler(ctx, msgUnbond).IsOK())
+	store := ctx.KVStore(keeper.storeKey)
+	require.False(t, store.Has(GetDelegationDistInfoKey(addrs[2], addrs[0])))
+
+	// withdraw through the handler
+	msgWithdraw := NewMsgWithdrawDelegatorReward(addrs[2], addrs[1])
+	require.True(t, handler(ctx, msgWithdraw).IsOK())
+	requireReward(addrs[2], addrs[1], 0)
+
+	// the rewards withdrawn, outstanding and in the community pool add up
+	// to the fees, up to the precision of the distribution
+	total := keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")
+	total = total.Add(sdk.NewRat(ck.GetCoins(ctx, addrs[2]).AmountOf("steak").Int64() - 50))
+	for _, addr := range addrs[:2] {
+		reward, err := keeper.GetDelegationReward(ctx, addr, addr)
+		require.Nil(t, err)
+		total = total.Add(reward.AmountOf("steak"))
+	}
+	diff := sdk.NewRat(totalFees).Sub(total)
+	require.True(t, diff.GTE(sdk.ZeroRat()), "%v", diff)
+	require.True(t, diff.LT(sdk.NewRat(1, 1000000000)), "%v", diff)
+}
+
+func TestValidatorRemoved(t *testing.T) {
+	ctx, ck, sk, fck, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	require.True(t, stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 100, sdk.NewRat(1, 2))).IsOK())
+
+	fck.AddCollectedFees(ctx, sdk.Coins{sdk.NewCoin("steak", 100)})
+	keeper.AllocateFees(ctx, nil, sdk.ZeroRat())
+
+	// the owner gets the reward and the commission when the validator goes
+	msgUnbond := stake.NewMsgBeginUnbonding(addrs[0], addrs[0], sdk.NewRat(100))
+	require.True(t, stakeHandler(ctx, msgUnbond).IsOK())
+	_, found := sk.GetValidator(ctx, addrs[0])
+	require.False(t, found)
+	require.Equal(t, int64(198), ck.GetCoins(ctx, addrs[0]).AmountOf("steak").Int64())
+	store := ctx.KVStore(keeper.storeKey)
+	require.False(t, store.Has(GetValidatorDistInfoKey(addrs[0])))
+}
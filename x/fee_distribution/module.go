@@ -0,0 +1,79 @@
+package distribution
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name of the distribution module, used for its routes and genesis state
+const ModuleName = "distr"
+
+// AppModule implements module.AppModule for the distribution module
+type AppModule struct {
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// nolint
+func (AppModule) Name() string                   { return ModuleName }
+func (AppModule) Route() string                  { return ModuleName }
+func (am AppModule) NewHandler() sdk.Handler     { return NewHandler(am.keeper) }
+func (AppModule) QuerierRoute() string           { return "" }
+func (AppModule) NewQuerierHandler() sdk.Querier { return nil }
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter) {
+	RegisterInvariants(ir, ModuleName, am.keeper)
+}
+
+// DefaultGenesis returns the default distribution genesis state
+func (am AppModule) DefaultGenesis() json.RawMessage {
+	return am.mustMarshalGenesis(DefaultGenesisState())
+}
+
+// ValidateGenesis validates the distribution genesis state
+func (am AppModule) ValidateGenesis(data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	return ValidateGenesis(genesisState)
+}
+
+// InitGenesis initializes the distribution state from its genesis state
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	InitGenesis(ctx, am.keeper, genesisState)
+	return nil
+}
+
+// ExportGenesis exports the distribution state as its genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return am.mustMarshalGenesis(WriteGenesis(ctx, am.keeper))
+}
+
+// BeginBlock distributes the rewards of the previous block
+func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) sdk.Tags {
+	return BeginBlocker(ctx, req, am.keeper)
+}
+
+// EndBlock is a no-op for the distribution module
+func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return nil, nil
+}
+
+func (am AppModule) mustMarshalGenesis(genesisState GenesisState) json.RawMessage {
+	bz, err := am.keeper.cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,90 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// default name of the distribution subspace of the params store
+const DefaultParamspace = "distribution"
+
+// keys of the distribution parameters in the params store
+// nolint
+var (
+	KeyCommunityTax        = []byte("CommunityTax")
+	KeyBaseProposerReward  = []byte("BaseProposerReward")
+	KeyBonusProposerReward = []byte("BonusProposerReward")
+)
+
+// ParamKeyTable registers the types of the distribution parameters and their
+// validation
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().
+		RegisterParamSet(&Params{}).
+		RegisterValidation(func(ctx sdk.Context, space params.Subspace) error {
+			var p Params
+			space.GetParamSet(ctx, &p)
+			return validateParams(p)
+		})
+}
+
+// Params of the distribution module
+type Params struct {
+	CommunityTax        sdk.Rat `json:"community_tax"`         // fraction of the block rewards going to the community pool
+	BaseProposerReward  sdk.Rat `json:"base_proposer_reward"`  // fraction of the block rewards always going to the proposer
+	BonusProposerReward sdk.Rat `json:"bonus_proposer_reward"` // extra fraction of the block rewards for a proposer including all the precommits
+}
+
+var _ params.ParamSet = (*Params)(nil)
+
+// KeyValuePairs implements params.ParamSet
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{Key: KeyCommunityTax, Value: &p.CommunityTax},
+		{Key: KeyBaseProposerReward, Value: &p.BaseProposerReward},
+		{Key: KeyBonusProposerReward, Value: &p.BonusProposerReward},
+	}
+}
+
+// DefaultParams returns the default distribution parameters
+func DefaultParams() Params {
+	return Params{
+		// 2%
+		CommunityTax: sdk.NewRat(2, 100),
+
+		// 1% to 5% depending on the precommits included
+		BaseProposerReward:  sdk.NewRat(1, 100),
+		BonusProposerReward: sdk.NewRat(4, 100),
+	}
+}
+
+// CommunityTax - fraction of the block rewards going to the community pool
+func (k Keeper) CommunityTax(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeyCommunityTax, &res)
+	return
+}
+
+// BaseProposerReward - fraction of the block rewards always going to the
+// proposer
+func (k Keeper) BaseProposerReward(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeyBaseProposerReward, &res)
+	return
+}
+
+// BonusProposerReward - maximum extra fraction of the block rewards for the
+// proposer, scaled by the fraction of the voting power it included
+func (k Keeper) BonusProposerReward(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeyBonusProposerReward, &res)
+	return
+}
+
+// GetParams returns all the distribution parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return
+}
+
+// SetParams sets all the distribution parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
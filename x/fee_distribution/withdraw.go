@@ -0,0 +1,63 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetDelegationReward returns the reward a delegation can withdraw, the
+// increase of the reward per share of its validator since its last
+// withdrawal times its shares
+func (k Keeper) GetDelegationReward(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (DecCoins, sdk.Error) {
+	delegation, found := k.stakeKeeper.GetDelegation(ctx, delegatorAddr, validatorAddr)
+	if !found {
+		return nil, ErrNoDelegation(k.codespace)
+	}
+	valInfo := k.GetValidatorDistInfo(ctx, validatorAddr)
+	delInfo := k.GetDelegationDistInfo(ctx, delegatorAddr, validatorAddr)
+	return valInfo.RewardPerShare.Minus(delInfo.RewardPerShare).MulRat(delegation.Shares), nil
+}
+
+// WithdrawDelegatorReward pays out the reward of a delegation in whole
+// coins, the fractional change goes to the community pool
+func (k Keeper) WithdrawDelegatorReward(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (sdk.Coins, sdk.Error) {
+	reward, err := k.GetDelegationReward(ctx, delegatorAddr, validatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the delegation is now even with its validator
+	valInfo := k.GetValidatorDistInfo(ctx, validatorAddr)
+	k.SetDelegationDistInfo(ctx, delegatorAddr, validatorAddr, NewDelegationDistInfo(valInfo.RewardPerShare))
+
+	coins, change := reward.TruncateDecimal()
+	k.addToCommunityPool(ctx, change)
+	k.addCoins(ctx, delegatorAddr, coins)
+	return coins, nil
+}
+
+// WithdrawValidatorCommission pays out the commission of a validator to its
+// owner in whole coins, the fractional change is kept for the next
+// withdrawal
+func (k Keeper) WithdrawValidatorCommission(ctx sdk.Context, validatorAddr sdk.Address) (sdk.Coins, sdk.Error) {
+	if _, found := k.stakeKeeper.GetValidator(ctx, validatorAddr); !found {
+		return nil, ErrNoValidator(k.codespace)
+	}
+
+	info := k.GetValidatorDistInfo(ctx, validatorAddr)
+	coins, change := info.Commission.TruncateDecimal()
+	info.Commission = change
+	k.SetValidatorDistInfo(ctx, validatorAddr, info)
+	k.addCoins(ctx, validatorAddr, coins)
+	return coins, nil
+}
+
+// add withdrawn coins to an account
+func (k Keeper) addCoins(ctx sdk.Context, addr sdk.Address, coins sdk.Coins) {
+	if coins.IsZero() {
+		return
+	}
+	_, _, err := k.coinKeeper.AddCoins(ctx, addr, coins)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,48 @@
+package gov
+
+import (
+	"fmt"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// The cost of pushing to and popping from the proposal queues should not
+// depend on the number of queued proposals.
+func BenchmarkProposalQueuePushPop(b *testing.B) {
+	for _, size := range []int64{10, 1000, 10000} {
+		b.Run(fmt.Sprintf("queued=%d", size), func(b *testing.B) {
+			benchmarkProposalQueuePushPop(b, size)
+		})
+	}
+}
+
+func benchmarkProposalQueuePushPop(b *testing.B, size int64) {
+	keyGov := sdk.NewKVStoreKey("gov")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyGov, sdk.StoreTypeIAVL, db)
+	ms.LoadLatestVersion()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	keeper := Keeper{storeKey: keyGov, cdc: wire.NewCodec()}
+
+	// the proposal i ends at time i
+	for i := int64(0); i < size; i++ {
+		keeper.InsertActiveProposalQueue(ctx, i, i)
+	}
+
+	b.ResetTimer()
+	for i := int64(0); i < int64(b.N); i++ {
+		keeper.InsertActiveProposalQueue(ctx, size+i, size+i)
+
+		for _, proposalID := range queuedProposalIDs(keeper.ActiveProposalQueueIterator(ctx, i)) {
+			keeper.RemoveFromActiveProposalQueue(ctx, i, proposalID)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -11,19 +13,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	"github.com/pkg/errors"
 )
 
 const (
-	flagProposalID   = "proposalID"
-	flagTitle        = "title"
-	flagDescription  = "description"
-	flagProposalType = "type"
-	flagDeposit      = "deposit"
-	flagProposer     = "proposer"
-	flagDepositer    = "depositer"
-	flagVoter        = "voter"
-	flagOption       = "option"
+	flagProposalID    = "proposalID"
+	flagTitle         = "title"
+	flagDescription   = "description"
+	flagProposalType  = "type"
+	flagDeposit       = "deposit"
+	flagProposer      = "proposer"
+	flagDepositer     = "depositer"
+	flagVoter         = "voter"
+	flagOption        = "option"
+	flagStatus        = "status"
+	flagLimit         = "limit"
+	flagParamChange   = "param-change"
+	flagUpgradeName   = "upgrade-name"
+	flagUpgradeHeight = "upgrade-height"
+	flagUpgradeTime   = "upgrade-time"
+	flagUpgradeInfo   = "upgrade-info"
 )
 
 // submit a proposal tx
@@ -55,6 +65,24 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 
 			// create the message
 			msg := gov.NewMsgSubmitProposal(title, description, proposalType, from, amount)
+			switch proposalType {
+			case gov.ProposalTypeParameterChange:
+				strChanges, err := cmd.Flags().GetStringArray(flagParamChange)
+				if err != nil {
+					return err
+				}
+				changes, err := parseParamChanges(strChanges)
+				if err != nil {
+					return err
+				}
+				msg = gov.NewMsgSubmitParameterChangeProposal(title, description, changes, from, amount)
+			case gov.ProposalTypeSoftwareUpgrade:
+				plan, err := parseUpgradePlan()
+				if err != nil {
+					return err
+				}
+				msg = gov.NewMsgSubmitSoftwareUpgradeProposal(title, description, plan, from, amount)
+			}
 
 			err = msg.ValidateBasic()
 			if err != nil {
@@ -79,10 +107,46 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagProposalType, "", "proposalType of proposal")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposer, "", "proposer of proposal")
+	cmd.Flags().StringArray(flagParamChange, nil, "parameter change of a ParameterChange proposal, as subspace:key:value with the JSON of the new value, can be repeated")
+	cmd.Flags().String(flagUpgradeName, "", "name of the upgrade of a SoftwareUpgrade proposal")
+	cmd.Flags().Int64(flagUpgradeHeight, 0, "height of the upgrade of a SoftwareUpgrade proposal")
+	cmd.Flags().String(flagUpgradeTime, "", "time of the upgrade of a SoftwareUpgrade proposal, in RFC3339 format, if no height is set")
+	cmd.Flags().String(flagUpgradeInfo, "", "information about the upgrade of a SoftwareUpgrade proposal, e.g. where to get the new binary")
 
 	return cmd
 }
 
+// parse parameter changes of the form subspace:key:value
+func parseParamChanges(strChanges []string) ([]gov.ParamChange, error) {
+	changes := make([]gov.ParamChange, len(strChanges))
+	for i, strChange := range strChanges {
+		parts := strings.SplitN(strChange, ":", 3)
+		if len(parts) != 3 {
+			return nil, errors.Errorf("invalid parameter change %s, expected subspace:key:value", strChange)
+		}
+		changes[i] = gov.NewParamChange(parts[0], parts[1], parts[2])
+	}
+	return changes, nil
+}
+
+// parse the upgrade plan of a SoftwareUpgrade proposal from the flags
+func parseUpgradePlan() (upgrade.Plan, error) {
+	var upgradeTime int64
+	if strTime := viper.GetString(flagUpgradeTime); strTime != "" {
+		t, err := time.Parse(time.RFC3339, strTime)
+		if err != nil {
+			return upgrade.Plan{}, errors.Errorf("invalid upgrade time %s, expected RFC3339 format", strTime)
+		}
+		upgradeTime = t.Unix()
+	}
+	return upgrade.NewPlan(
+		viper.GetString(flagUpgradeName),
+		viper.GetInt64(flagUpgradeHeight),
+		upgradeTime,
+		viper.GetString(flagUpgradeInfo),
+	), nil
+}
+
 // set a new Deposit transaction
 func GetCmdDeposit(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -247,3 +311,175 @@ func GetCmdQueryVote(storeName string, cdc *wire.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// Command to list the proposals, filtered by voter, depositer and status
+func GetCmdQueryProposals(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-proposals",
+		Short: "query proposals with optional filters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := gov.QueryProposalsParams{
+				NumLatestProposals: viper.GetInt64(flagLimit),
+			}
+
+			if bechVoter := viper.GetString(flagVoter); len(bechVoter) != 0 {
+				voterAddr, err := sdk.GetAccAddressBech32(bechVoter)
+				if err != nil {
+					return err
+				}
+				params.Voter = voterAddr
+			}
+
+			if bechDepositer := viper.GetString(flagDepositer); len(bechDepositer) != 0 {
+				depositerAddr, err := sdk.GetAccAddressBech32(bechDepositer)
+				if err != nil {
+					return err
+				}
+				params.Depositer = depositerAddr
+			}
+
+			if strStatus := viper.GetString(flagStatus); len(strStatus) != 0 {
+				params.ProposalStatus = gov.StringToStatus(strStatus)
+				if params.ProposalStatus == gov.VoteStatus(0xff) {
+					return errors.Errorf("invalid status %s", strStatus)
+				}
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryProposals), bz)
+			if err != nil {
+				return err
+			}
+
+			var proposals []gov.Proposal
+			err = cdc.UnmarshalJSON(res, &proposals)
+			if err != nil {
+				return err
+			}
+			proposalsRest := make([]gov.ProposalRest, len(proposals))
+			for i, proposal := range proposals {
+				proposalsRest[i] = gov.ProposalToRest(proposal)
+			}
+			output, err := wire.MarshalJSONIndent(cdc, proposalsRest)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagVoter, "", "(optional) filter by proposals voted on by the bech32 voter address")
+	cmd.Flags().String(flagDepositer, "", "(optional) filter by proposals deposited on by the bech32 depositer address")
+	cmd.Flags().String(flagStatus, "", "(optional) filter by the proposal status {DepositPeriod, VotingPeriod, Passed, Rejected}")
+	cmd.Flags().Int64(flagLimit, 0, "(optional) limit to the latest [number] proposals, defaults to all the proposals")
+
+	return cmd
+}
+
+// Command to list the votes on a proposal
+func GetCmdQueryVotes(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-votes",
+		Short: "query the votes on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := queryByProposalID(queryRoute, gov.QueryVotes, cdc)
+			if err != nil {
+				return err
+			}
+
+			var votes []gov.Vote
+			err = cdc.UnmarshalJSON(res, &votes)
+			if err != nil {
+				return err
+			}
+			votesRest := make([]gov.VoteRest, len(votes))
+			for i, vote := range votes {
+				votesRest[i] = gov.VoteToRest(vote)
+			}
+			output, err := wire.MarshalJSONIndent(cdc, votesRest)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of the proposal being queried")
+
+	return cmd
+}
+
+// Command to list the deposits on a proposal
+func GetCmdQueryDeposits(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-deposits",
+		Short: "query the deposits on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := queryByProposalID(queryRoute, gov.QueryDeposits, cdc)
+			if err != nil {
+				return err
+			}
+
+			var deposits []gov.Deposit
+			err = cdc.UnmarshalJSON(res, &deposits)
+			if err != nil {
+				return err
+			}
+			depositsRest := make([]gov.DepositRest, len(deposits))
+			for i, deposit := range deposits {
+				depositsRest[i] = gov.DepositToRest(deposit)
+			}
+			output, err := wire.MarshalJSONIndent(cdc, depositsRest)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of the proposal being queried")
+
+	return cmd
+}
+
+// Command to get the current tally of a proposal in its voting period
+func GetCmdQueryTally(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-tally",
+		Short: "query the current tally of a proposal in its voting period",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := queryByProposalID(queryRoute, gov.QueryTally, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of the proposal being tallied")
+
+	return cmd
+}
+
+// queries a gov endpoint with the proposal ID of the flags
+func queryByProposalID(queryRoute string, endpoint string, cdc *wire.Codec) ([]byte, error) {
+	params := gov.QueryProposalParams{
+		ProposalID: viper.GetInt64(flagProposalID),
+	}
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.NewCoreContextFromViper()
+	return ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), bz)
+}
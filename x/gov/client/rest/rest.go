@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
@@ -16,10 +18,13 @@ import (
 // REST Variable names
 // nolint
 const (
-	RestProposalID = "proposalID"
-	RestDepositer  = "depositer"
-	RestVoter      = "voter"
-	storeName      = "gov"
+	RestProposalID     = "proposalID"
+	RestDepositer      = "depositer"
+	RestVoter          = "voter"
+	RestProposalStatus = "status"
+	RestNumLatest      = "limit"
+	storeName          = "gov"
+	queryRoute         = "gov"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
@@ -31,6 +36,9 @@ func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}", RestProposalID), queryProposalHandlerFn(cdc)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositer), queryDepositHandlerFn(cdc)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes/{%s}", RestProposalID, RestVoter), queryVoteHandlerFn(cdc)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), queryDepositsHandlerFn(cdc)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), queryVotesHandlerFn(cdc)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/tally", RestProposalID), queryTallyHandlerFn(cdc)).Methods("GET")
 
 	r.HandleFunc("/gov/proposals", queryProposalsWithParameterFn(cdc)).Methods("GET")
 }
@@ -42,6 +50,9 @@ type postProposalReq struct {
 	ProposalType   string    `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 	Proposer       string    `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins `json:"initial_deposit"` // Coins to add to the proposal's deposit
+
+	ParamChanges []gov.ParamChange `json:"param_changes"` // Changes of a ParameterChange proposal
+	Plan         upgrade.Plan      `json:"plan"`          // Upgrade plan of a SoftwareUpgrade proposal
 }
 
 type depositReq struct {
@@ -82,6 +93,12 @@ func postProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.Handle
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalTypeByte, proposer, req.InitialDeposit)
+		switch proposalTypeByte {
+		case gov.ProposalTypeParameterChange:
+			msg = gov.NewMsgSubmitParameterChangeProposal(req.Title, req.Description, req.ParamChanges, proposer, req.InitialDeposit)
+		case gov.ProposalTypeSoftwareUpgrade:
+			msg = gov.NewMsgSubmitSoftwareUpgradeProposal(req.Title, req.Description, req.Plan, proposer, req.InitialDeposit)
+		}
 		err = msg.ValidateBasic()
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
@@ -373,75 +390,171 @@ func queryProposalsWithParameterFn(cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bechVoterAddr := r.URL.Query().Get(RestVoter)
 		bechDepositerAddr := r.URL.Query().Get(RestDepositer)
+		strProposalStatus := r.URL.Query().Get(RestProposalStatus)
+		strNumLatest := r.URL.Query().Get(RestNumLatest)
 
-		var err error
-		var voterAddr sdk.Address
-		var depositerAddr sdk.Address
+		params := gov.QueryProposalsParams{}
 
 		if len(bechVoterAddr) != 0 {
-			voterAddr, err = sdk.GetAccAddressBech32(bechVoterAddr)
+			voterAddr, err := sdk.GetAccAddressBech32(bechVoterAddr)
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
 				err := errors.Errorf("'%s' needs to be bech32 encoded", RestVoter)
 				w.Write([]byte(err.Error()))
 				return
 			}
+			params.Voter = voterAddr
 		}
 
 		if len(bechDepositerAddr) != 0 {
-			depositerAddr, err = sdk.GetAccAddressBech32(bechDepositerAddr)
+			depositerAddr, err := sdk.GetAccAddressBech32(bechDepositerAddr)
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
 				err := errors.Errorf("'%s' needs to be bech32 encoded", RestDepositer)
 				w.Write([]byte(err.Error()))
 				return
 			}
+			params.Depositer = depositerAddr
 		}
 
-		ctx := context.NewCoreContextFromViper()
+		if len(strProposalStatus) != 0 {
+			params.ProposalStatus = gov.StringToStatus(strProposalStatus)
+			if params.ProposalStatus == gov.VoteStatus(0xff) {
+				writeErr(&w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid proposal status", strProposalStatus))
+				return
+			}
+		}
+
+		if len(strNumLatest) != 0 {
+			numLatest, err := strconv.ParseInt(strNumLatest, 10, 64)
+			if err != nil {
+				writeErr(&w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid limit", strNumLatest))
+				return
+			}
+			params.NumLatestProposals = numLatest
+		}
+
+		res, ok := queryWithParams(w, cdc, gov.QueryProposals, params)
+		if !ok {
+			return
+		}
+
+		var proposals []gov.Proposal
+		err := cdc.UnmarshalJSON(res, &proposals)
+		if err != nil {
+			writeErr(&w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		matchingProposals := make([]gov.ProposalRest, len(proposals))
+		for i, proposal := range proposals {
+			matchingProposals[i] = gov.ProposalToRest(proposal)
+		}
 
-		res, err := ctx.QueryStore(gov.KeyNextProposalID, storeName)
+		output, err := wire.MarshalJSONIndent(cdc, matchingProposals)
 		if err != nil {
-			err = errors.New("no proposals exist yet and proposalID has not been set")
+			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
 			return
 		}
-		var maxProposalID int64
-		cdc.MustUnmarshalBinary(res, &maxProposalID)
+		w.Write(output)
+	}
+}
 
-		matchingProposals := []gov.ProposalRest{}
+func queryVotesHandlerFn(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		proposalID, ok := parseProposalID(w, r)
+		if !ok {
+			return
+		}
 
-		for proposalID := int64(0); proposalID < maxProposalID; proposalID++ {
-			if voterAddr != nil {
-				res, err = ctx.QueryStore(gov.KeyVote(proposalID, voterAddr), storeName)
-				if err != nil || len(res) == 0 {
-					continue
-				}
-			}
+		startKey, limit, err := client.ParsePageParams(r)
+		if err != nil {
+			writeErr(&w, http.StatusBadRequest, err.Error())
+			return
+		}
 
-			if depositerAddr != nil {
-				res, err = ctx.QueryStore(gov.KeyDeposit(proposalID, depositerAddr), storeName)
-				if err != nil || len(res) == 0 {
-					continue
-				}
-			}
+		ctx := context.NewCoreContextFromViper()
+		kvs, nextKey, err := ctx.QuerySubspacePage(cdc, gov.KeyVotesSubspace(proposalID), startKey, limit, storeName)
+		if err != nil {
+			writeErr(&w, http.StatusInternalServerError, err.Error())
+			return
+		}
 
-			res, err = ctx.QueryStore(gov.KeyProposal(proposalID), storeName)
-			if err != nil || len(res) == 0 {
-				continue
+		votesRest := make([]gov.VoteRest, len(kvs))
+		for i, kv := range kvs {
+			var vote gov.Vote
+			err = cdc.UnmarshalBinary(kv.Value, &vote)
+			if err != nil {
+				writeErr(&w, http.StatusInternalServerError, err.Error())
+				return
 			}
-			var proposal gov.Proposal
-			cdc.MustUnmarshalBinary(res, &proposal)
+			votesRest[i] = gov.VoteToRest(vote)
+		}
 
-			matchingProposals = append(matchingProposals, gov.ProposalToRest(proposal))
+		output, err := wire.MarshalJSONIndent(cdc, votesRest)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
 		}
+		client.SetNextKeyHeader(w, nextKey)
+		w.Write(output)
+	}
+}
 
-		output, err := wire.MarshalJSONIndent(cdc, matchingProposals)
+func queryDepositsHandlerFn(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		proposalID, ok := parseProposalID(w, r)
+		if !ok {
+			return
+		}
+
+		startKey, limit, err := client.ParsePageParams(r)
+		if err != nil {
+			writeErr(&w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := context.NewCoreContextFromViper()
+		kvs, nextKey, err := ctx.QuerySubspacePage(cdc, gov.KeyDepositsSubspace(proposalID), startKey, limit, storeName)
+		if err != nil {
+			writeErr(&w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		depositsRest := make([]gov.DepositRest, len(kvs))
+		for i, kv := range kvs {
+			var deposit gov.Deposit
+			err = cdc.UnmarshalBinary(kv.Value, &deposit)
+			if err != nil {
+				writeErr(&w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			depositsRest[i] = gov.DepositToRest(deposit)
+		}
+
+		output, err := wire.MarshalJSONIndent(cdc, depositsRest)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
 			return
 		}
+		client.SetNextKeyHeader(w, nextKey)
 		w.Write(output)
 	}
 }
+
+func queryTallyHandlerFn(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		proposalID, ok := parseProposalID(w, r)
+		if !ok {
+			return
+		}
+
+		res, ok := queryWithParams(w, cdc, gov.QueryTally, gov.QueryProposalParams{ProposalID: proposalID})
+		if !ok {
+			return
+		}
+		w.Write(res)
+	}
+}
@@ -1,12 +1,15 @@
 package rest
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
 
@@ -67,6 +70,40 @@ func writeErr(w *http.ResponseWriter, status int, msg string) {
 	(*w).Write([]byte(err.Error()))
 }
 
+// parses the proposal ID of the request path, writes the error and returns
+// false if it is missing or invalid
+func parseProposalID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	strProposalID := mux.Vars(r)[RestProposalID]
+	if len(strProposalID) == 0 {
+		writeErr(&w, http.StatusBadRequest, "proposalId required but not specified")
+		return 0, false
+	}
+
+	proposalID, err := strconv.ParseInt(strProposalID, 10, 64)
+	if err != nil {
+		writeErr(&w, http.StatusBadRequest, fmt.Sprintf("proposalID [%s] is not a valid ID", strProposalID))
+		return 0, false
+	}
+	return proposalID, true
+}
+
+// queries a gov endpoint, writes the error and returns false if it fails
+func queryWithParams(w http.ResponseWriter, cdc *wire.Codec, endpoint string, params interface{}) ([]byte, bool) {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		writeErr(&w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	ctx := context.NewCoreContextFromViper()
+	res, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), bz)
+	if err != nil {
+		writeErr(&w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return res, true
+}
+
 // TODO: Build this function out into a more generic base-request (probably should live in client/lcd)
 func signAndBuild(w http.ResponseWriter, ctx context.CoreContext, baseReq baseReq, msg sdk.Msg, cdc *wire.Codec) {
 	ctx = ctx.WithAccountNumber(baseReq.AccountNumber)
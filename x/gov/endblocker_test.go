@@ -7,6 +7,9 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
 func TestTickExpiredDepositPeriod(t *testing.T) {
@@ -15,29 +18,33 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)})
 
 	res := govHandler(ctx, newProposalMsg)
 	require.True(t, res.IsOK())
 
-	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10})
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-
-	ctx = ctx.WithBlockHeight(250)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetDepositProcedure(ctx).MaxDepositPeriod})
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 	EndBlocker(ctx, keeper)
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 }
 
 func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
@@ -46,40 +53,46 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)})
 
 	res := govHandler(ctx, newProposalMsg)
 	require.True(t, res.IsOK())
 
-	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 2})
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newProposalMsg2 := NewMsgSubmitProposal("Test2", "test2", ProposalTypeText, addrs[1], sdk.Coins{sdk.NewCoin("steak", 5)})
 	res = govHandler(ctx, newProposalMsg2)
 	require.True(t, res.IsOK())
 
-	ctx = ctx.WithBlockHeight(205)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetDepositProcedure(ctx).MaxDepositPeriod})
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-
-	ctx = ctx.WithBlockHeight(215)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 2 + keeper.GetDepositProcedure(ctx).MaxDepositPeriod})
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 	EndBlocker(ctx, keeper)
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 }
 
 func TestTickPassedDepositPeriod(t *testing.T) {
@@ -88,11 +101,6 @@ func TestTickPassedDepositPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
-
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)})
 
 	res := govHandler(ctx, newProposalMsg)
@@ -100,29 +108,31 @@ func TestTickPassedDepositPeriod(t *testing.T) {
 	var proposalID int64
 	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
 
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 2})
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-
-	ctx = ctx.WithBlockHeight(10)
-	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	depositEndTime := keeper.GetProposal(ctx, proposalID).GetDepositEndTime()
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, depositEndTime)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newDepositMsg := NewMsgDeposit(addrs[1], proposalID, sdk.Coins{sdk.NewCoin("steak", 5)})
 	res = govHandler(ctx, newDepositMsg)
 	require.True(t, res.IsOK())
 
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
-
+	// the proposal left the inactive queue for the active queue
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, depositEndTime)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+	votingEndTime := keeper.GetProposal(ctx, proposalID).GetVotingEndTime()
+	require.Equal(t, 2+keeper.GetVotingProcedure(ctx).VotingPeriod, votingEndTime)
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, votingEndTime)
+	require.True(t, activeQueue.Valid())
+	activeQueue.Close()
+
+	// the proposal isn't dropped at the end of its deposit period
+	ctx = ctx.WithBlockHeader(abci.Header{Time: depositEndTime})
 	EndBlocker(ctx, keeper)
-
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
 }
 
 func TestTickPassedVotingPeriod(t *testing.T) {
@@ -132,11 +142,6 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
-
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)})
 
 	res := govHandler(ctx, newProposalMsg)
@@ -144,25 +149,79 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	var proposalID int64
 	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10})
 	newDepositMsg := NewMsgDeposit(addrs[1], proposalID, sdk.Coins{sdk.NewCoin("steak", 5)})
 	res = govHandler(ctx, newDepositMsg)
 	require.True(t, res.IsOK())
 
 	EndBlocker(ctx, keeper)
 
-	ctx = ctx.WithBlockHeight(215)
-	require.True(t, shouldPopActiveProposalQueue(ctx, keeper))
+	// the voting period isn't over yet
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 9 + keeper.GetVotingProcedure(ctx).VotingPeriod})
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10 + keeper.GetVotingProcedure(ctx).VotingPeriod})
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, activeQueue.Valid())
+	activeQueue.Close()
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 	require.True(t, depositsIterator.Valid())
 	depositsIterator.Close()
-	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
 
 	EndBlocker(ctx, keeper)
 
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
 	depositsIterator = keeper.GetDeposits(ctx, proposalID)
 	require.False(t, depositsIterator.Valid())
 	depositsIterator.Close()
 	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
 }
+
+func TestTickPenalizesNonVoters(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+	tallyingProcedure.GovernancePenalty = sdk.NewRat(1, 2)
+	keeper.SetTallyingProcedure(ctx, tallyingProcedure)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 40), dummyDescription, dummyCommission)
+	res := stakeHandler(ctx, val1CreateMsg)
+	require.True(t, res.IsOK())
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 20), dummyDescription, dummyCommission)
+	res = stakeHandler(ctx, val2CreateMsg)
+	require.True(t, res.IsOK())
+
+	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[2], sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	// no one is penalized before the end of the voting period
+	tags := EndBlocker(ctx, keeper)
+	require.NotContains(t, tags, sdk.MakeTag("penalizedValidator", []byte(addrs[1].String())))
+	require.Equal(t, sdk.NewRat(20), sk.Validator(ctx, addrs[1]).GetPower())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags = EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Nil(t, keeper.GetAllVotes(ctx, proposalID))
+
+	// only the validator which didn't vote is slashed by the penalty
+	require.Contains(t, tags, sdk.MakeTag("penalizedValidator", []byte(addrs[1].String())))
+	require.NotContains(t, tags, sdk.MakeTag("penalizedValidator", []byte(addrs[0].String())))
+	require.Equal(t, sdk.NewRat(10), sk.Validator(ctx, addrs[1]).GetPower())
+	require.Equal(t, sdk.NewRat(40), sk.Validator(ctx, addrs[0]).GetPower())
+}
@@ -20,6 +20,7 @@ const (
 	CodeInvalidProposalType     sdk.CodeType = 8
 	CodeInvalidVote             sdk.CodeType = 9
 	CodeInvalidGenesis          sdk.CodeType = 10
+	CodeInvalidParamChange      sdk.CodeType = 11
 )
 
 //----------------------------------------
@@ -65,3 +66,7 @@ func ErrInvalidVote(codespace sdk.CodespaceType, strOption string) sdk.Error {
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
+
+func ErrInvalidParamChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamChange, msg)
+}
@@ -1,17 +1,39 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// GenesisState - all staking state that must be provided at genesis
+// default deposit and voting periods, two days in seconds
+const (
+	defaultMaxDepositPeriod int64 = 60 * 60 * 24 * 2
+	defaultVotingPeriod     int64 = 60 * 60 * 24 * 2
+)
+
+// GenesisState - all gov state that must be provided at genesis
 type GenesisState struct {
-	StartingProposalID int64 `json:"starting_proposalID"`
+	StartingProposalID int64             `json:"starting_proposalID"`
+	DepositProcedure   DepositProcedure  `json:"deposit_procedure"`
+	VotingProcedure    VotingProcedure   `json:"voting_procedure"`
+	TallyingProcedure  TallyingProcedure `json:"tallying_procedure"`
+	Proposals          []Proposal        `json:"proposals"`
+	Deposits           []Deposit         `json:"deposits"`
+	Votes              []Vote            `json:"votes"`
 }
 
-func NewGenesisState(startingProposalID int64) GenesisState {
+func NewGenesisState(startingProposalID int64, dp DepositProcedure, vp VotingProcedure, tp TallyingProcedure,
+	proposals []Proposal, deposits []Deposit, votes []Vote) GenesisState {
+
 	return GenesisState{
 		StartingProposalID: startingProposalID,
+		DepositProcedure:   dp,
+		VotingProcedure:    vp,
+		TallyingProcedure:  tp,
+		Proposals:          proposals,
+		Deposits:           deposits,
+		Votes:              votes,
 	}
 }
 
@@ -19,23 +41,178 @@ func NewGenesisState(startingProposalID int64) GenesisState {
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		StartingProposalID: 1,
+		DepositProcedure: DepositProcedure{
+			MinDeposit:       sdk.Coins{sdk.NewCoin("steak", 10)},
+			MaxDepositPeriod: defaultMaxDepositPeriod,
+		},
+		VotingProcedure: VotingProcedure{
+			VotingPeriod: defaultVotingPeriod,
+		},
+		TallyingProcedure: TallyingProcedure{
+			Quorum:            sdk.NewRat(1, 3),
+			Threshold:         sdk.NewRat(1, 2),
+			Veto:              sdk.NewRat(1, 3),
+			GovernancePenalty: sdk.NewRat(1, 100),
+		},
+	}
+}
+
+// ValidateGenesis - check the starting proposal ID and the procedures are
+// valid, and that the deposits and votes are on open proposals
+func ValidateGenesis(data GenesisState) error {
+	if data.StartingProposalID < 1 {
+		return fmt.Errorf("invalid starting proposal ID %d, must be positive", data.StartingProposalID)
+	}
+	if err := validateProcedures(data.DepositProcedure, data.VotingProcedure, data.TallyingProcedure); err != nil {
+		return err
+	}
+
+	proposals := make(map[int64]Proposal, len(data.Proposals))
+	for _, proposal := range data.Proposals {
+		proposalID := proposal.GetProposalID()
+		if proposalID < 1 || proposalID >= data.StartingProposalID {
+			return fmt.Errorf("invalid proposal ID %d in genesis state, must be positive and below the starting proposal ID", proposalID)
+		}
+		if proposals[proposalID] != nil {
+			return fmt.Errorf("duplicate proposal in genesis state: ID %d", proposalID)
+		}
+		switch proposal.GetStatus() {
+		case StatusDepositPeriod, StatusVotingPeriod, StatusPassed, StatusRejected:
+		default:
+			return fmt.Errorf("invalid status %d of proposal %d in genesis state", proposal.GetStatus(), proposalID)
+		}
+		proposals[proposalID] = proposal
+	}
+
+	// the deposits of the open proposals add up to their total deposit, the
+	// deposits of the dropped proposals are kept with the tokens they hold
+	deposited := make(map[int64]sdk.Coins)
+	depositers := make(map[string]bool, len(data.Deposits))
+	for _, deposit := range data.Deposits {
+		if deposit.ProposalID < 1 || deposit.ProposalID >= data.StartingProposalID {
+			return fmt.Errorf("deposit on unknown proposal in genesis state: ID %d", deposit.ProposalID)
+		}
+		proposal := proposals[deposit.ProposalID]
+		if proposal != nil && proposal.GetStatus() != StatusDepositPeriod && proposal.GetStatus() != StatusVotingPeriod {
+			return fmt.Errorf("deposit on closed proposal in genesis state: ID %d", deposit.ProposalID)
+		}
+		if !deposit.Amount.IsValid() || !deposit.Amount.IsNotNegative() {
+			return fmt.Errorf("invalid deposit %v of %v on proposal %d in genesis state", deposit.Amount, deposit.Depositer, deposit.ProposalID)
+		}
+		key := string(KeyDeposit(deposit.ProposalID, deposit.Depositer))
+		if depositers[key] {
+			return fmt.Errorf("duplicate deposit in genesis state: %v on proposal %d", deposit.Depositer, deposit.ProposalID)
+		}
+		depositers[key] = true
+		deposited[deposit.ProposalID] = deposited[deposit.ProposalID].Plus(deposit.Amount)
 	}
+	for proposalID, proposal := range proposals {
+		if proposal.GetStatus() != StatusDepositPeriod && proposal.GetStatus() != StatusVotingPeriod {
+			continue
+		}
+		if !deposited[proposalID].IsEqual(proposal.GetTotalDeposit()) {
+			return fmt.Errorf("total deposit %v of proposal %d doesn't match its deposits %v in genesis state",
+				proposal.GetTotalDeposit(), proposalID, deposited[proposalID])
+		}
+	}
+
+	voters := make(map[string]bool, len(data.Votes))
+	for _, vote := range data.Votes {
+		proposal := proposals[vote.ProposalID]
+		if proposal == nil || proposal.GetStatus() != StatusVotingPeriod {
+			return fmt.Errorf("vote on unknown or closed proposal in genesis state: ID %d", vote.ProposalID)
+		}
+		if !validVoteOption(vote.Option) {
+			return fmt.Errorf("invalid vote option %d of %v on proposal %d in genesis state", vote.Option, vote.Voter, vote.ProposalID)
+		}
+		key := string(KeyVote(vote.ProposalID, vote.Voter))
+		if voters[key] {
+			return fmt.Errorf("duplicate vote in genesis state: %v on proposal %d", vote.Voter, vote.ProposalID)
+		}
+		voters[key] = true
+	}
+	return nil
 }
 
-// InitGenesis - store genesis parameters
+// the procedures of the genesis state and of the params store must be valid
+func validateProcedures(dp DepositProcedure, vp VotingProcedure, tp TallyingProcedure) error {
+	if !dp.MinDeposit.IsValid() {
+		return fmt.Errorf("invalid minimum deposit %v", dp.MinDeposit)
+	}
+	if dp.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("invalid maximum deposit period %d, must be positive", dp.MaxDepositPeriod)
+	}
+	if vp.VotingPeriod <= 0 {
+		return fmt.Errorf("invalid voting period %d, must be positive", vp.VotingPeriod)
+	}
+
+	if err := validateRatio("quorum", tp.Quorum); err != nil {
+		return err
+	}
+	if err := validateRatio("threshold", tp.Threshold); err != nil {
+		return err
+	}
+	if err := validateRatio("veto", tp.Veto); err != nil {
+		return err
+	}
+	return validateRatio("governance penalty", tp.GovernancePenalty)
+}
+
+// a ratio of the tallying procedure must be within [0, 1]
+func validateRatio(name string, ratio sdk.Rat) error {
+	if ratio.Rat == nil || ratio.LT(sdk.ZeroRat()) || ratio.GT(sdk.OneRat()) {
+		return fmt.Errorf("invalid %s %v, must be between 0 and 1", name, ratio)
+	}
+	return nil
+}
+
+// InitGenesis - store genesis parameters, the proposals with their deposits
+// and votes, and queue the open proposals until the end of their period
 func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	err := k.setInitialProposalID(ctx, data.StartingProposalID)
 	if err != nil {
 		// TODO: Handle this with #870
 		panic(err)
 	}
+	k.SetDepositProcedure(ctx, data.DepositProcedure)
+	k.SetVotingProcedure(ctx, data.VotingProcedure)
+	k.SetTallyingProcedure(ctx, data.TallyingProcedure)
+
+	for _, proposal := range data.Proposals {
+		k.SetProposal(ctx, proposal)
+		switch proposal.GetStatus() {
+		case StatusDepositPeriod:
+			k.InsertInactiveProposalQueue(ctx, proposal.GetDepositEndTime(), proposal.GetProposalID())
+		case StatusVotingPeriod:
+			k.InsertActiveProposalQueue(ctx, proposal.GetVotingEndTime(), proposal.GetProposalID())
+		}
+	}
+	for _, deposit := range data.Deposits {
+		k.setDeposit(ctx, deposit.ProposalID, deposit.Depositer, deposit)
+	}
+	for _, vote := range data.Votes {
+		k.setVote(ctx, vote.ProposalID, vote.Voter, vote)
+	}
 }
 
-// WriteGenesis - output genesis parameters
+// WriteGenesis - output genesis parameters, the proposals, the votes on them
+// and all the deposits
 func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	initalProposalID, _ := k.getNewProposalID(ctx)
+	initalProposalID, _ := k.peekCurrentProposalID(ctx)
 
-	return GenesisState{
-		initalProposalID,
+	var votes []Vote
+	proposals := k.GetAllProposals(ctx)
+	for _, proposal := range proposals {
+		votes = append(votes, k.GetAllVotes(ctx, proposal.GetProposalID())...)
 	}
+
+	return NewGenesisState(
+		initalProposalID,
+		k.GetDepositProcedure(ctx),
+		k.GetVotingProcedure(ctx),
+		k.GetTallyingProcedure(ctx),
+		proposals,
+		k.getAllDeposits(ctx),
+		votes,
+	)
 }
@@ -0,0 +1,117 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	addr1, addr2 := sdk.Address([]byte("addr1")), sdk.Address([]byte("addr2"))
+	coins := sdk.Coins{sdk.NewCoin("steak", 10)}
+	newProposal := func(proposalID int64, status VoteStatus, totalDeposit sdk.Coins) Proposal {
+		return &TextProposal{ProposalID: proposalID, Status: status, TotalDeposit: totalDeposit}
+	}
+	newGenesis := func(proposals []Proposal, deposits []Deposit, votes []Vote) GenesisState {
+		genesis := DefaultGenesisState()
+		genesis.StartingProposalID = 4
+		genesis.Proposals, genesis.Deposits, genesis.Votes = proposals, deposits, votes
+		return genesis
+	}
+	open := newProposal(1, StatusVotingPeriod, coins)
+	passed := newProposal(2, StatusPassed, coins)
+	deposit := Deposit{addr1, 1, coins}
+	vote := Vote{addr1, 1, OptionYes}
+
+	require.Nil(t, ValidateGenesis(newGenesis([]Proposal{open, passed}, []Deposit{deposit}, []Vote{vote})))
+
+	// the deposits of a dropped proposal are kept
+	require.Nil(t, ValidateGenesis(newGenesis([]Proposal{open}, []Deposit{deposit, {addr1, 3, coins}}, nil)))
+
+	// invalid proposals
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{newProposal(4, StatusPassed, nil)}, nil, nil)))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{passed, passed}, nil, nil)))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{newProposal(2, StatusNil, nil)}, nil, nil)))
+
+	// invalid deposits
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open}, nil, nil)))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open}, []Deposit{deposit, deposit}, nil)))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open}, []Deposit{deposit, {addr2, 1, coins}}, nil)))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open, passed}, []Deposit{deposit, {addr1, 2, coins}}, nil)))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open}, []Deposit{deposit, {addr1, 4, coins}}, nil)))
+
+	// invalid votes
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open, passed}, []Deposit{deposit}, []Vote{{addr1, 2, OptionYes}})))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open}, []Deposit{deposit}, []Vote{vote, vote})))
+	require.NotNil(t, ValidateGenesis(newGenesis([]Proposal{open}, []Deposit{deposit}, []Vote{{addr2, 1, OptionEmpty}})))
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+
+	// a proposal in its voting period with a vote, and one in its deposit period
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)}))
+	require.True(t, res.IsOK())
+	var votingProposalID int64
+	keeper.cdc.MustUnmarshalBinaryBare(res.Data, &votingProposalID)
+	res = govHandler(ctx, NewMsgDeposit(addrs[1], votingProposalID, sdk.Coins{sdk.NewCoin("steak", 5)}))
+	require.True(t, res.IsOK())
+	res = govHandler(ctx, NewMsgVote(addrs[0], votingProposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	res = govHandler(ctx, NewMsgSubmitProposal("Test2", "test2", ProposalTypeText, addrs[2], sdk.Coins{sdk.NewCoin("steak", 3)}))
+	require.True(t, res.IsOK())
+	var depositProposalID int64
+	keeper.cdc.MustUnmarshalBinaryBare(res.Data, &depositProposalID)
+
+	exported := WriteGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(exported))
+	require.Equal(t, 2, len(exported.Proposals))
+	require.Equal(t, 3, len(exported.Deposits))
+	require.Equal(t, []Vote{{addrs[0], votingProposalID, OptionYes}}, exported.Votes)
+
+	// the exported state goes through the JSON of the genesis file
+	module := NewAppModule(keeper)
+	bz := module.ExportGenesis(ctx)
+	require.Nil(t, module.ValidateGenesis(bz))
+	var imported GenesisState
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &imported))
+
+	// import the exported state in a new chain
+	newMapp, newKeeper, _, _, _, _ := getMockAppWithGenesis(t, 10, imported)
+	newMapp.BeginBlock(abci.RequestBeginBlock{})
+	newCtx := newMapp.BaseApp.NewContext(false, abci.Header{})
+	require.Equal(t, exported, WriteGenesis(newCtx, newKeeper))
+	require.True(t, sdk.NewRat(13).Equal(newKeeper.HeldTokens(newCtx, "steak")))
+
+	// the new proposals get the next IDs
+	require.Equal(t, depositProposalID, newKeeper.GetLastProposalID(newCtx))
+
+	// the open proposals are queued until the end of their period
+	votingEndTime := newKeeper.GetProposal(newCtx, votingProposalID).GetVotingEndTime()
+	activeQueue := newKeeper.ActiveProposalQueueIterator(newCtx, votingEndTime)
+	require.True(t, activeQueue.Valid())
+	require.Equal(t, votingProposalID, getProposalIDFromQueueKey(activeQueue.Key()))
+	activeQueue.Close()
+	depositEndTime := newKeeper.GetProposal(newCtx, depositProposalID).GetDepositEndTime()
+	inactiveQueue := newKeeper.InactiveProposalQueueIterator(newCtx, depositEndTime)
+	require.True(t, inactiveQueue.Valid())
+	require.Equal(t, depositProposalID, getProposalIDFromQueueKey(inactiveQueue.Key()))
+	inactiveQueue.Close()
+
+	// and processed at the end of their period
+	newCtx = newCtx.WithBlockHeader(abci.Header{Time: votingEndTime})
+	EndBlocker(newCtx, newKeeper)
+	require.Equal(t, StatusRejected, newKeeper.GetProposal(newCtx, votingProposalID).GetStatus())
+	require.Nil(t, newKeeper.GetAllVotes(newCtx, votingProposalID))
+	require.Nil(t, newKeeper.GetProposal(newCtx, depositProposalID))
+}
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -23,7 +25,23 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		err := keeper.ValidateParamChanges(ctx, msg.ParamChanges)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, msg.ParamChanges)
+	case ProposalTypeSoftwareUpgrade:
+		err := keeper.uk.ValidatePlan(ctx, msg.Plan)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewSoftwareUpgradeProposal(ctx, msg.Title, msg.Description, msg.Plan)
+	default:
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -39,7 +57,7 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 	)
 
 	if votingStarted {
-		tags.AppendTag("votingPeriodStart", proposalIDBytes)
+		tags = tags.AppendTag("votingPeriodStart", proposalIDBytes)
 	}
 
 	return sdk.Result{
@@ -65,7 +83,7 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 	)
 
 	if votingStarted {
-		tags.AppendTag("votingPeriodStart", proposalIDBytes)
+		tags = tags.AppendTag("votingPeriodStart", proposalIDBytes)
 	}
 
 	return sdk.Result{
@@ -92,71 +110,104 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 	}
 }
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags, nonVotingVals []sdk.Address) {
+// Called every block, drops the proposals which didn't reach the minimum
+// deposit, tallies the proposals which voting period ended and penalizes the
+// validators which didn't vote on them
+func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags) {
 
 	tags = sdk.NewTags()
+	blockTime := ctx.BlockHeader().Time
 
-	// Delete proposals that haven't met minDeposit
-	for shouldPopInactiveProposalQueue(ctx, keeper) {
-		inactiveProposal := keeper.InactiveProposalQueuePop(ctx)
-		if inactiveProposal.GetStatus() == StatusDepositPeriod {
-			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(inactiveProposal.GetProposalID())
-			keeper.DeleteProposal(ctx, inactiveProposal)
-			tags.AppendTag("action", []byte("proposalDropped"))
-			tags.AppendTag("proposalId", proposalIDBytes)
-		}
+	// Delete proposals that haven't met minDeposit by the end of their deposit period
+	for _, proposalID := range queuedProposalIDs(keeper.InactiveProposalQueueIterator(ctx, blockTime)) {
+		inactiveProposal := keeper.GetProposal(ctx, proposalID)
+		keeper.RemoveFromInactiveProposalQueue(ctx, inactiveProposal.GetDepositEndTime(), proposalID)
+		keeper.DeleteProposal(ctx, inactiveProposal)
+
+		proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(proposalID)
+		tags = tags.AppendTag("action", []byte("proposalDropped"))
+		tags = tags.AppendTag("proposalId", proposalIDBytes)
 	}
 
-	var passes bool
-
-	// Check if earliest Active Proposal ended voting period yet
-	for shouldPopActiveProposalQueue(ctx, keeper) {
-		activeProposal := keeper.ActiveProposalQueuePop(ctx)
-
-		if ctx.BlockHeight() >= activeProposal.GetVotingStartBlock()+keeper.GetVotingProcedure().VotingPeriod {
-			passes, nonVotingVals = tally(ctx, keeper, activeProposal)
-			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(activeProposal.GetProposalID())
-			if passes {
-				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
-				activeProposal.SetStatus(StatusPassed)
-				tags.AppendTag("action", []byte("proposalPassed"))
-				tags.AppendTag("proposalId", proposalIDBytes)
-			} else {
-				keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
-				activeProposal.SetStatus(StatusRejected)
-				tags.AppendTag("action", []byte("proposalRejected"))
-				tags.AppendTag("proposalId", proposalIDBytes)
-			}
-
-			keeper.SetProposal(ctx, activeProposal)
+	// Tally the proposals which voting period ended
+	for _, proposalID := range queuedProposalIDs(keeper.ActiveProposalQueueIterator(ctx, blockTime)) {
+		activeProposal := keeper.GetProposal(ctx, proposalID)
+		keeper.RemoveFromActiveProposalQueue(ctx, activeProposal.GetVotingEndTime(), proposalID)
+
+		passes, _, nonVotingVals := tally(ctx, keeper, activeProposal)
+		keeper.deleteVotes(ctx, proposalID)
+		proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(proposalID)
+		if passes {
+			keeper.RefundDeposits(ctx, proposalID)
+			activeProposal.SetStatus(StatusPassed)
+			tags = tags.AppendTag("action", []byte("proposalPassed"))
+			tags = tags.AppendTag("proposalId", proposalIDBytes)
+			tags = tags.AppendTags(keeper.penalizeNonVoters(ctx, nonVotingVals))
+			tags = tags.AppendTags(keeper.executeProposal(ctx, activeProposal))
+		} else {
+			keeper.DeleteDeposits(ctx, proposalID)
+			activeProposal.SetStatus(StatusRejected)
+			tags = tags.AppendTag("action", []byte("proposalRejected"))
+			tags = tags.AppendTag("proposalId", proposalIDBytes)
+			tags = tags.AppendTags(keeper.penalizeNonVoters(ctx, nonVotingVals))
 		}
+
+		keeper.SetProposal(ctx, activeProposal)
 	}
 
-	return tags, nonVotingVals
+	return tags
 }
-func shouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	depositProcedure := keeper.GetDepositProcedure()
-	peekProposal := keeper.InactiveProposalQueuePeek(ctx)
-
-	if peekProposal == nil {
-		return false
-	} else if peekProposal.GetStatus() != StatusDepositPeriod {
-		return true
-	} else if ctx.BlockHeight() >= peekProposal.GetSubmitBlock()+depositProcedure.MaxDepositPeriod {
-		return true
-	}
-	return false
+
+// returns the IDs of the proposals of a queue iterator and closes it, the
+// proposals are removed from the queue only once iterated over
+func queuedProposalIDs(iterator sdk.Iterator) (proposalIDs []int64) {
+	for ; iterator.Valid(); iterator.Next() {
+		proposalIDs = append(proposalIDs, getProposalIDFromQueueKey(iterator.Key()))
+	}
+	iterator.Close()
+	return proposalIDs
 }
 
-func shouldPopActiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	votingProcedure := keeper.GetVotingProcedure()
-	peekProposal := keeper.ActiveProposalQueuePeek(ctx)
+// slashes the bonded validators which didn't vote on a proposal by the
+// governance penalty, before the proposal is executed. Returns a tag for each
+// penalized validator.
+func (keeper Keeper) penalizeNonVoters(ctx sdk.Context, nonVotingVals []sdk.Address) sdk.Tags {
+	tags := sdk.EmptyTags()
+	penalty := keeper.GetTallyingProcedure(ctx).GovernancePenalty
+	for _, owner := range nonVotingVals {
+		validator := keeper.vs.Validator(ctx, owner)
+		if validator == nil || validator.GetStatus() != sdk.Bonded {
+			continue
+		}
+		keeper.vs.Slash(ctx, validator.GetPubKey(), ctx.BlockHeight(), validator.GetPower().RoundInt64(), penalty)
+		tags = tags.AppendTag("penalizedValidator", []byte(owner.String()))
+	}
+	return tags
+}
 
-	if peekProposal == nil {
-		return false
-	} else if ctx.BlockHeight() >= peekProposal.GetVotingStartBlock()+votingProcedure.VotingPeriod {
-		return true
+// executes a passed proposal, text proposals have no effect. The effects
+// of a proposal are applied all together or not at all.
+func (keeper Keeper) executeProposal(ctx sdk.Context, proposal Proposal) sdk.Tags {
+	var tagKey, success string
+	var err sdk.Error
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	switch proposal := proposal.(type) {
+	case *ParameterChangeProposal:
+		tagKey, success = "paramChange", "applied"
+		err = keeper.applyParamChanges(cacheCtx, proposal.Changes)
+	case *SoftwareUpgradeProposal:
+		tagKey, success = "softwareUpgrade", "scheduled"
+		err = keeper.uk.ScheduleUpgrade(cacheCtx, proposal.Plan)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		ctx.Logger().With("module", "x/gov").Info(
+			fmt.Sprintf("could not execute proposal %d: %s", proposal.GetProposalID(), err.Error()))
+		return sdk.NewTags(tagKey, []byte("failed"))
 	}
-	return false
+	writeCache()
+	return sdk.NewTags(tagKey, []byte(success))
 }
@@ -0,0 +1,50 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the gov invariants
+func RegisterInvariants(ir sdk.InvariantRouter, keeper Keeper) {
+	ir.RegisterRoute(ModuleName, "deposits", DepositsInvariant(keeper))
+}
+
+// DepositsInvariant checks that the total deposit of every proposal which
+// is still open equals the sum of its deposits
+func DepositsInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		nextProposalID, err := keeper.peekCurrentProposalID(ctx)
+		if err != nil {
+			// no genesis, no proposals
+			return nil
+		}
+
+		for proposalID := int64(1); proposalID < nextProposalID; proposalID++ {
+			proposal := keeper.GetProposal(ctx, proposalID)
+			if proposal == nil {
+				continue
+			}
+			status := proposal.GetStatus()
+			if status != StatusDepositPeriod && status != StatusVotingPeriod {
+				continue
+			}
+
+			var deposits sdk.Coins
+			depositsIterator := keeper.GetDeposits(ctx, proposalID)
+			for ; depositsIterator.Valid(); depositsIterator.Next() {
+				deposit := &Deposit{}
+				keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
+				deposits = deposits.Plus(deposit.Amount)
+			}
+			depositsIterator.Close()
+
+			if !deposits.IsEqual(proposal.GetTotalDeposit()) {
+				return fmt.Errorf("total deposit of proposal %d (%v) differs from the sum of its deposits (%v)",
+					proposalID, proposal.GetTotalDeposit(), deposits)
+			}
+		}
+		return nil
+	}
+}
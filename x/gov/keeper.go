@@ -4,19 +4,70 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
+// keys of the governance procedures in the params store
+// nolint
+var (
+	ParamStoreKeyDepositProcedure  = []byte("depositprocedure")
+	ParamStoreKeyVotingProcedure   = []byte("votingprocedure")
+	ParamStoreKeyTallyingProcedure = []byte("tallyingprocedure")
+)
+
+// ParamKeyTable registers the types of the governance procedures and their
+// validation
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().
+		RegisterType(ParamStoreKeyDepositProcedure, DepositProcedure{}).
+		RegisterType(ParamStoreKeyVotingProcedure, VotingProcedure{}).
+		RegisterType(ParamStoreKeyTallyingProcedure, TallyingProcedure{}).
+		RegisterValidation(validateProcedureParams)
+}
+
+// validates the procedures of the params store
+func validateProcedureParams(ctx sdk.Context, space params.Subspace) error {
+	var dp DepositProcedure
+	var vp VotingProcedure
+	var tp TallyingProcedure
+	space.Get(ctx, ParamStoreKeyDepositProcedure, &dp)
+	space.Get(ctx, ParamStoreKeyVotingProcedure, &vp)
+	space.Get(ctx, ParamStoreKeyTallyingProcedure, &tp)
+	return validateProcedures(dp, vp, tp)
+}
+
+// StakeKeeper is the staking keeper expected by the governance: the
+// delegations for the tallies, and the pool from which the burned deposits
+// are removed
+type StakeKeeper interface {
+	sdk.DelegationSet
+	sdk.TokenBurner
+}
+
 // Governance Keeper
 type Keeper struct {
 	// The reference to the CoinKeeper to modify balances
 	ck bank.Keeper
 
+	// The reference to the params Keeper, to execute parameter changes
+	pk params.Keeper
+
+	// The subspace of the params store holding the procedures
+	paramSpace params.Subspace
+
 	// The ValidatorSet to get information about validators
 	vs sdk.ValidatorSet
 
 	// The reference to the DelegationSet to get information about delegators
 	ds sdk.DelegationSet
 
+	// The reference to the TokenBurner to burn the deposits
+	tb sdk.TokenBurner
+
+	// The reference to the upgrade Keeper, to schedule software upgrades
+	uk upgrade.Keeper
+
 	// The (unexposed) keys used to access the stores from the Context.
 	storeKey sdk.StoreKey
 
@@ -28,14 +79,18 @@ type Keeper struct {
 }
 
 // NewGovernanceMapper returns a mapper that uses go-wire to (binary) encode and decode gov types.
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, pk params.Keeper, paramSpace params.Subspace, ck bank.Keeper, sk StakeKeeper, uk upgrade.Keeper, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
-		storeKey:  key,
-		ck:        ck,
-		ds:        ds,
-		vs:        ds.GetValidatorSet(),
-		cdc:       cdc,
-		codespace: codespace,
+		storeKey:   key,
+		pk:         pk,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		ck:         ck,
+		ds:         sk,
+		tb:         sk,
+		vs:         sk.GetValidatorSet(),
+		uk:         uk,
+		cdc:        cdc,
+		codespace:  codespace,
 	}
 }
 
@@ -53,19 +108,34 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	if err != nil {
 		return nil
 	}
-	var proposal Proposal = &TextProposal{
-		ProposalID:       proposalID,
-		Title:            title,
-		Description:      description,
-		ProposalType:     proposalType,
-		Status:           StatusDepositPeriod,
-		TotalDeposit:     sdk.Coins{},
-		SubmitBlock:      ctx.BlockHeight(),
-		VotingStartBlock: -1, // TODO: Make Time
+	textProposal := keeper.newTextProposal(ctx, proposalID, title, description, proposalType)
+	var proposal Proposal = &textProposal
+	keeper.submitProposal(ctx, proposal)
+	return proposal
+}
+
+// the fields of a proposal in its deposit period, which ends after the
+// maximum deposit period
+func (keeper Keeper) newTextProposal(ctx sdk.Context, proposalID int64, title string, description string, proposalType byte) TextProposal {
+	submitTime := ctx.BlockHeader().Time
+	return TextProposal{
+		ProposalID:      proposalID,
+		Title:           title,
+		Description:     description,
+		ProposalType:    proposalType,
+		Status:          StatusDepositPeriod,
+		TotalDeposit:    sdk.Coins{},
+		SubmitTime:      submitTime,
+		DepositEndTime:  submitTime + keeper.GetDepositProcedure(ctx).MaxDepositPeriod,
+		VotingStartTime: -1,
+		VotingEndTime:   -1,
 	}
+}
+
+// stores a new proposal and queues it until the end of its deposit period
+func (keeper Keeper) submitProposal(ctx sdk.Context, proposal Proposal) {
 	keeper.SetProposal(ctx, proposal)
-	keeper.InactiveProposalQueuePush(ctx, proposal)
-	return proposal
+	keeper.InsertInactiveProposalQueue(ctx, proposal.GetDepositEndTime(), proposal.GetProposalID())
 }
 
 // Get Proposal from store by ProposalID
@@ -95,6 +165,57 @@ func (keeper Keeper) DeleteProposal(ctx sdk.Context, proposal Proposal) {
 	store.Delete(KeyProposal(proposal.GetProposalID()))
 }
 
+// Returns all the stored proposals, ordered by their key
+func (keeper Keeper) GetAllProposals(ctx sdk.Context) (proposals []Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	proposalsIterator := sdk.KVStorePrefixIterator(store, KeyProposalsSubspace())
+	for ; proposalsIterator.Valid(); proposalsIterator.Next() {
+		var proposal Proposal
+		keeper.cdc.MustUnmarshalBinary(proposalsIterator.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	proposalsIterator.Close()
+	return proposals
+}
+
+// Returns the proposals voted on by voterAddr, deposited on by depositerAddr
+// and with the given status, ordered by ID. The filters which are nil or
+// StatusNil match all the proposals, only the numLatest latest matching
+// proposals are returned if numLatest is positive.
+func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.Address, depositerAddr sdk.Address, status VoteStatus, numLatest int64) []Proposal {
+	var proposals []Proposal
+	for proposalID := keeper.GetLastProposalID(ctx); proposalID >= 0; proposalID-- {
+		if numLatest > 0 && int64(len(proposals)) == numLatest {
+			break
+		}
+		if voterAddr != nil {
+			if _, found := keeper.GetVote(ctx, proposalID, voterAddr); !found {
+				continue
+			}
+		}
+		if depositerAddr != nil {
+			if _, found := keeper.GetDeposit(ctx, proposalID, depositerAddr); !found {
+				continue
+			}
+		}
+
+		proposal := keeper.GetProposal(ctx, proposalID)
+		if proposal == nil {
+			continue
+		}
+		if status != StatusNil && proposal.GetStatus() != status {
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+
+	// the proposals were collected from the latest
+	for i, j := 0, len(proposals)-1; i < j; i, j = i+1, j-1 {
+		proposals[i], proposals[j] = proposals[j], proposals[i]
+	}
+	return proposals
+}
+
 func (keeper Keeper) setInitialProposalID(ctx sdk.Context, proposalID int64) sdk.Error {
 	store := ctx.KVStore(keeper.storeKey)
 	bz := store.Get(KeyNextProposalID)
@@ -118,38 +239,73 @@ func (keeper Keeper) getNewProposalID(ctx sdk.Context) (proposalID int64, err sd
 	return proposalID, nil
 }
 
+// Peeks the next available ProposalID without incrementing it
+func (keeper Keeper) peekCurrentProposalID(ctx sdk.Context) (proposalID int64, err sdk.Error) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyNextProposalID)
+	if bz == nil {
+		return -1, ErrInvalidGenesis(keeper.codespace, "InitialProposalID never set")
+	}
+	keeper.cdc.MustUnmarshalBinary(bz, &proposalID)
+	return proposalID, nil
+}
+
+// GetLastProposalID returns the ID preceding the next proposal ID, which is
+// the ID of the last submitted proposal, if any
+func (keeper Keeper) GetLastProposalID(ctx sdk.Context) int64 {
+	proposalID, err := keeper.peekCurrentProposalID(ctx)
+	if err != nil {
+		return 0
+	}
+	return proposalID - 1
+}
+
+// moves a proposal from the inactive to the active proposal queue, its
+// voting period ends after the voting period of the current procedure
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
-	proposal.SetVotingStartBlock(ctx.BlockHeight())
+	votingStartTime := ctx.BlockHeader().Time
+	proposal.SetVotingStartTime(votingStartTime)
+	proposal.SetVotingEndTime(votingStartTime + keeper.GetVotingProcedure(ctx).VotingPeriod)
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
-	keeper.ActiveProposalQueuePush(ctx, proposal)
+
+	keeper.RemoveFromInactiveProposalQueue(ctx, proposal.GetDepositEndTime(), proposal.GetProposalID())
+	keeper.InsertActiveProposalQueue(ctx, proposal.GetVotingEndTime(), proposal.GetProposalID())
 }
 
 // =====================================================
 // Procedures
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetDepositProcedure() DepositProcedure {
-	return DepositProcedure{
-		MinDeposit:       sdk.Coins{sdk.NewCoin("steak", 10)},
-		MaxDepositPeriod: 200,
-	}
+// Returns the current DepositProcedure from the params store
+func (keeper Keeper) GetDepositProcedure(ctx sdk.Context) DepositProcedure {
+	var depositProcedure DepositProcedure
+	keeper.paramSpace.Get(ctx, ParamStoreKeyDepositProcedure, &depositProcedure)
+	return depositProcedure
 }
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetVotingProcedure() VotingProcedure {
-	return VotingProcedure{
-		VotingPeriod: 200,
-	}
+// Returns the current VotingProcedure from the params store
+func (keeper Keeper) GetVotingProcedure(ctx sdk.Context) VotingProcedure {
+	var votingProcedure VotingProcedure
+	keeper.paramSpace.Get(ctx, ParamStoreKeyVotingProcedure, &votingProcedure)
+	return votingProcedure
 }
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetTallyingProcedure() TallyingProcedure {
-	return TallyingProcedure{
-		Threshold:         sdk.NewRat(1, 2),
-		Veto:              sdk.NewRat(1, 3),
-		GovernancePenalty: sdk.NewRat(1, 100),
-	}
+// Returns the current TallyingProcedure from the params store
+func (keeper Keeper) GetTallyingProcedure(ctx sdk.Context) TallyingProcedure {
+	var tallyingProcedure TallyingProcedure
+	keeper.paramSpace.Get(ctx, ParamStoreKeyTallyingProcedure, &tallyingProcedure)
+	return tallyingProcedure
+}
+
+// nolint
+func (keeper Keeper) SetDepositProcedure(ctx sdk.Context, depositProcedure DepositProcedure) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyDepositProcedure, &depositProcedure)
+}
+func (keeper Keeper) SetVotingProcedure(ctx sdk.Context, votingProcedure VotingProcedure) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyVotingProcedure, &votingProcedure)
+}
+func (keeper Keeper) SetTallyingProcedure(ctx sdk.Context, tallyingProcedure TallyingProcedure) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyTallyingProcedure, &tallyingProcedure)
 }
 
 // =====================================================
@@ -203,9 +359,28 @@ func (keeper Keeper) GetVotes(ctx sdk.Context, proposalID int64) sdk.Iterator {
 	return sdk.KVStorePrefixIterator(store, KeyVotesSubspace(proposalID))
 }
 
-func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address) {
+// Returns all the votes on a specific proposal
+func (keeper Keeper) GetAllVotes(ctx sdk.Context, proposalID int64) (votes []Vote) {
+	votesIterator := keeper.GetVotes(ctx, proposalID)
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		vote := Vote{}
+		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	votesIterator.Close()
+	return votes
+}
+
+// Deletes all the votes on a specific proposal
+func (keeper Keeper) deleteVotes(ctx sdk.Context, proposalID int64) {
 	store := ctx.KVStore(keeper.storeKey)
-	store.Delete(KeyVote(proposalID, voterAddr))
+	votesIterator := keeper.GetVotes(ctx, proposalID)
+
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		store.Delete(votesIterator.Key())
+	}
+
+	votesIterator.Close()
 }
 
 // =====================================================
@@ -256,7 +431,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	// Check if deposit tipped proposal into voting period
 	// Active voting period if so
 	activatedVotingPeriod := false
-	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositProcedure().MinDeposit) {
+	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositProcedure(ctx).MinDeposit) {
 		keeper.activateVotingPeriod(ctx, proposal)
 		activatedVotingPeriod = true
 	}
@@ -280,6 +455,31 @@ func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator
 	return sdk.KVStorePrefixIterator(store, KeyDepositsSubspace(proposalID))
 }
 
+// Returns all the deposits on a specific proposal
+func (keeper Keeper) GetAllDeposits(ctx sdk.Context, proposalID int64) (deposits []Deposit) {
+	depositsIterator := keeper.GetDeposits(ctx, proposalID)
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	depositsIterator.Close()
+	return deposits
+}
+
+// Returns the deposits on all the proposals, including the dropped ones
+func (keeper Keeper) getAllDeposits(ctx sdk.Context) (deposits []Deposit) {
+	store := ctx.KVStore(keeper.storeKey)
+	depositsIterator := sdk.KVStorePrefixIterator(store, KeyDepositsSubspaceAll())
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	depositsIterator.Close()
+	return deposits
+}
+
 // Returns and deletes all the deposits on a specific proposal
 func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 	store := ctx.KVStore(keeper.storeKey)
@@ -300,108 +500,80 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 	depositsIterator.Close()
 }
 
-// Deletes all the deposits on a specific proposal without refunding them
+// Deletes and burns all the deposits on a specific proposal without refunding them
 func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
 	store := ctx.KVStore(keeper.storeKey)
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 
+	var burned sdk.Coins
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := &Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
+		burned = burned.Plus(deposit.Amount)
+
 		store.Delete(depositsIterator.Key())
 	}
 
 	depositsIterator.Close()
+	keeper.tb.BurnLooseTokens(ctx, burned)
 }
 
-// =====================================================
-// ProposalQueues
-
-func (keeper Keeper) getActiveProposalQueue(ctx sdk.Context) ProposalQueue {
+// Returns the amount of a denom held as deposits, implements the token
+// holder of the stake supply invariant
+func (keeper Keeper) HeldTokens(ctx sdk.Context, denom string) sdk.Rat {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyActiveProposalQueue)
-	if bz == nil {
-		return nil
-	}
-
-	var proposalQueue ProposalQueue
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
+	depositsIterator := sdk.KVStorePrefixIterator(store, KeyDepositsSubspaceAll())
 
-	return proposalQueue
-}
-
-func (keeper Keeper) setActiveProposalQueue(ctx sdk.Context, proposalQueue ProposalQueue) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposalQueue)
-	store.Set(KeyActiveProposalQueue, bz)
-}
-
-// Return the Proposal at the front of the ProposalQueue
-func (keeper Keeper) ActiveProposalQueuePeek(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getActiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
+	held := sdk.ZeroInt()
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := &Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
+		held = held.Add(deposit.Amount.AmountOf(denom))
 	}
-	return keeper.GetProposal(ctx, proposalQueue[0])
-}
 
-// Remove and return a Proposal from the front of the ProposalQueue
-func (keeper Keeper) ActiveProposalQueuePop(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getActiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
-	}
-	frontElement, proposalQueue := proposalQueue[0], proposalQueue[1:]
-	keeper.setActiveProposalQueue(ctx, proposalQueue)
-	return keeper.GetProposal(ctx, frontElement)
+	depositsIterator.Close()
+	return sdk.NewRatFromInt(held)
 }
 
-// Add a proposalID to the back of the ProposalQueue
-func (keeper Keeper) ActiveProposalQueuePush(ctx sdk.Context, proposal Proposal) {
-	proposalQueue := append(keeper.getActiveProposalQueue(ctx), proposal.GetProposalID())
-	keeper.setActiveProposalQueue(ctx, proposalQueue)
-}
+// =====================================================
+// ProposalQueues
 
-func (keeper Keeper) getInactiveProposalQueue(ctx sdk.Context) ProposalQueue {
+// Returns an iterator over the proposals of the active proposal queue which
+// voting period ends at most at endTime
+func (keeper Keeper) ActiveProposalQueueIterator(ctx sdk.Context, endTime int64) sdk.Iterator {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyInactiveProposalQueue)
-	if bz == nil {
-		return nil
-	}
-
-	var proposalQueue ProposalQueue
-
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
+	return store.Iterator(PrefixActiveProposalQueue, KeyProposalQueueTime(PrefixActiveProposalQueue, endTime+1))
+}
 
-	return proposalQueue
+// Inserts a proposal into the active proposal queue at the end time of its
+// voting period
+func (keeper Keeper) InsertActiveProposalQueue(ctx sdk.Context, endTime int64, proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyActiveProposalQueueProposal(endTime, proposalID), []byte{})
 }
 
-func (keeper Keeper) setInactiveProposalQueue(ctx sdk.Context, proposalQueue ProposalQueue) {
+// Removes a proposal from the active proposal queue
+func (keeper Keeper) RemoveFromActiveProposalQueue(ctx sdk.Context, endTime int64, proposalID int64) {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposalQueue)
-	store.Set(KeyInactiveProposalQueue, bz)
+	store.Delete(KeyActiveProposalQueueProposal(endTime, proposalID))
 }
 
-// Return the Proposal at the front of the ProposalQueue
-func (keeper Keeper) InactiveProposalQueuePeek(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getInactiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
-	}
-	return keeper.GetProposal(ctx, proposalQueue[0])
+// Returns an iterator over the proposals of the inactive proposal queue
+// which deposit period ends at most at endTime
+func (keeper Keeper) InactiveProposalQueueIterator(ctx sdk.Context, endTime int64) sdk.Iterator {
+	store := ctx.KVStore(keeper.storeKey)
+	return store.Iterator(PrefixInactiveProposalQueue, KeyProposalQueueTime(PrefixInactiveProposalQueue, endTime+1))
 }
 
-// Remove and return a Proposal from the front of the ProposalQueue
-func (keeper Keeper) InactiveProposalQueuePop(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getInactiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
-	}
-	frontElement, proposalQueue := proposalQueue[0], proposalQueue[1:]
-	keeper.setInactiveProposalQueue(ctx, proposalQueue)
-	return keeper.GetProposal(ctx, frontElement)
+// Inserts a proposal into the inactive proposal queue at the end time of
+// its deposit period
+func (keeper Keeper) InsertInactiveProposalQueue(ctx sdk.Context, endTime int64, proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyInactiveProposalQueueProposal(endTime, proposalID), []byte{})
 }
 
-// Add a proposalID to the back of the ProposalQueue
-func (keeper Keeper) InactiveProposalQueuePush(ctx sdk.Context, proposal Proposal) {
-	proposalQueue := append(keeper.getInactiveProposalQueue(ctx), proposal.GetProposalID())
-	keeper.setInactiveProposalQueue(ctx, proposalQueue)
+// Removes a proposal from the inactive proposal queue
+func (keeper Keeper) RemoveFromInactiveProposalQueue(ctx sdk.Context, endTime int64, proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(KeyInactiveProposalQueueProposal(endTime, proposalID))
 }
@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -10,9 +11,15 @@ import (
 
 // Key for getting a the next available proposalID from the store
 var (
-	KeyNextProposalID        = []byte("newProposalID")
-	KeyActiveProposalQueue   = []byte("activeProposalQueue")
-	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+	KeyNextProposalID = []byte("newProposalID")
+)
+
+// Prefixes of the proposal queues, the queued proposals are keyed by the end
+// time of their period
+// nolint
+var (
+	PrefixActiveProposalQueue   = []byte("activeProposalQueue:")
+	PrefixInactiveProposalQueue = []byte("inactiveProposalQueue:")
 )
 
 // Key for getting a specific proposal from the store
@@ -20,6 +27,11 @@ func KeyProposal(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("proposals:%d", proposalID))
 }
 
+// Key for getting all proposals from the store
+func KeyProposalsSubspace() []byte {
+	return []byte("proposals:")
+}
+
 // Key for getting a specific deposit from the store
 func KeyDeposit(proposalID int64, depositerAddr sdk.Address) []byte {
 	return []byte(fmt.Sprintf("deposits:%d:%d", proposalID, depositerAddr))
@@ -35,7 +47,44 @@ func KeyDepositsSubspace(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
 }
 
+// Key for getting the deposits on all proposals from the store
+func KeyDepositsSubspaceAll() []byte {
+	return []byte("deposits:")
+}
+
 // Key for getting all votes on a proposal from the store
 func KeyVotesSubspace(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("votes:%d:", proposalID))
 }
+
+// Key for getting the prefix of the proposals of a queue which period ends at
+// a time, the big endian time orders the queue by end time
+func KeyProposalQueueTime(queuePrefix []byte, endTime int64) []byte {
+	timeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timeBytes, uint64(endTime))
+	return append(append([]byte{}, queuePrefix...), timeBytes...)
+}
+
+// Key for getting a specific proposal from the active proposal queue
+// VALUE: none (key rearrangement used)
+func KeyActiveProposalQueueProposal(endTime int64, proposalID int64) []byte {
+	return keyProposalQueueProposal(PrefixActiveProposalQueue, endTime, proposalID)
+}
+
+// Key for getting a specific proposal from the inactive proposal queue
+// VALUE: none (key rearrangement used)
+func KeyInactiveProposalQueueProposal(endTime int64, proposalID int64) []byte {
+	return keyProposalQueueProposal(PrefixInactiveProposalQueue, endTime, proposalID)
+}
+
+// proposals ending at the same time are ordered by ID
+func keyProposalQueueProposal(queuePrefix []byte, endTime int64, proposalID int64) []byte {
+	idBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(idBytes, uint64(proposalID))
+	return append(KeyProposalQueueTime(queuePrefix, endTime), idBytes...)
+}
+
+// get the proposal ID from a key of a proposal queue
+func getProposalIDFromQueueKey(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+}
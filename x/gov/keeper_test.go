@@ -41,21 +41,35 @@ func TestIncrementProposalNumber(t *testing.T) {
 func TestActivateVotingPeriod(t *testing.T) {
 	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
-	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: 10})
 
 	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
 
-	require.Equal(t, int64(-1), proposal.GetVotingStartBlock())
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
+	require.Equal(t, int64(10)+keeper.GetDepositProcedure(ctx).MaxDepositPeriod, proposal.GetDepositEndTime())
+	require.Equal(t, int64(-1), proposal.GetVotingStartTime())
+	require.Equal(t, int64(-1), proposal.GetVotingEndTime())
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, proposal.GetDepositEndTime())
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	keeper.activateVotingPeriod(ctx, proposal)
 
-	require.Equal(t, proposal.GetVotingStartBlock(), ctx.BlockHeight())
-	require.Equal(t, proposal.GetProposalID(), keeper.ActiveProposalQueuePeek(ctx).GetProposalID())
+	votingEndTime := int64(10) + keeper.GetVotingProcedure(ctx).VotingPeriod
+	require.Equal(t, int64(10), proposal.GetVotingStartTime())
+	require.Equal(t, votingEndTime, proposal.GetVotingEndTime())
+
+	// the proposal moved from the inactive to the active proposal queue
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, proposal.GetDepositEndTime())
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, votingEndTime)
+	require.True(t, activeQueue.Valid())
+	require.Equal(t, proposal.GetProposalID(), getProposalIDFromQueueKey(activeQueue.Key()))
+	activeQueue.Close()
 }
 
 func TestDeposits(t *testing.T) {
-	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 2)
 	SortAddresses(addrs)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
@@ -77,8 +91,10 @@ func TestDeposits(t *testing.T) {
 	// Check no deposits at beginning
 	deposit, found := keeper.GetDeposit(ctx, proposalID, addrs[1])
 	require.False(t, found)
-	require.Equal(t, keeper.GetProposal(ctx, proposalID).GetVotingStartBlock(), int64(-1))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
+	require.Equal(t, keeper.GetProposal(ctx, proposalID).GetVotingStartTime(), int64(-1))
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
 
 	// Check first deposit
 	err, votingStarted := keeper.AddDeposit(ctx, proposalID, addrs[0], fourSteak)
@@ -114,9 +130,11 @@ func TestDeposits(t *testing.T) {
 	require.Equal(t, addr1Initial.Minus(fourSteak), keeper.ck.GetCoins(ctx, addrs[1]))
 
 	// Check that proposal moved to voting period
-	require.Equal(t, ctx.BlockHeight(), keeper.GetProposal(ctx, proposalID).GetVotingStartBlock())
-	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.Equal(t, proposalID, keeper.ActiveProposalQueuePeek(ctx).GetProposalID())
+	require.Equal(t, ctx.BlockHeader().Time, keeper.GetProposal(ctx, proposalID).GetVotingStartTime())
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.True(t, activeQueue.Valid())
+	require.Equal(t, proposalID, getProposalIDFromQueueKey(activeQueue.Key()))
+	activeQueue.Close()
 
 	// Test deposit iterator
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
@@ -130,6 +148,7 @@ func TestDeposits(t *testing.T) {
 	require.Equal(t, fourSteak, deposit.Amount)
 	depositsIterator.Next()
 	require.False(t, depositsIterator.Valid())
+	require.True(t, keeper.HeldTokens(ctx, "steak").Equal(sdk.NewRat(13)))
 
 	// Test Refund Deposits
 	deposit, found = keeper.GetDeposit(ctx, proposalID, addrs[1])
@@ -140,7 +159,21 @@ func TestDeposits(t *testing.T) {
 	require.False(t, found)
 	require.Equal(t, addr0Initial, keeper.ck.GetCoins(ctx, addrs[0]))
 	require.Equal(t, addr1Initial, keeper.ck.GetCoins(ctx, addrs[1]))
+	require.True(t, keeper.HeldTokens(ctx, "steak").IsZero())
 
+	// Test Delete Deposits, which burns them
+	proposal = keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID = proposal.GetProposalID()
+	err, _ = keeper.AddDeposit(ctx, proposalID, addrs[0], fiveSteak)
+	require.Nil(t, err)
+	require.True(t, keeper.HeldTokens(ctx, "steak").Equal(sdk.NewRat(5)))
+	looseTokens := sk.GetPool(ctx).LooseTokens
+	keeper.DeleteDeposits(ctx, proposalID)
+	_, found = keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.False(t, found)
+	require.True(t, keeper.HeldTokens(ctx, "steak").IsZero())
+	require.Equal(t, addr0Initial.Minus(fiveSteak), keeper.ck.GetCoins(ctx, addrs[0]))
+	require.Equal(t, looseTokens-5, sk.GetPool(ctx).LooseTokens)
 }
 
 func TestVotes(t *testing.T) {
@@ -196,6 +229,52 @@ func TestVotes(t *testing.T) {
 	require.Equal(t, OptionNoWithVeto, vote.Option)
 	votesIterator.Next()
 	require.False(t, votesIterator.Valid())
+	votesIterator.Close()
+
+	// Test vote list
+	votes := keeper.GetAllVotes(ctx, proposalID)
+	require.Equal(t, 2, len(votes))
+	require.Equal(t, addrs[0], votes[0].Voter)
+	require.Equal(t, addrs[1], votes[1].Voter)
+	require.Nil(t, keeper.GetAllVotes(ctx, proposalID+1))
+}
+
+func TestGetProposalsFiltered(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// #1 in its deposit period, #2 voted on by addrs[0], #3 voted on and
+	// deposited on by addrs[1], #4 passed
+	proposals := make([]Proposal, 4)
+	for i := range proposals {
+		proposals[i] = keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	}
+	for _, proposal := range proposals[1:3] {
+		proposal.SetStatus(StatusVotingPeriod)
+		keeper.SetProposal(ctx, proposal)
+	}
+	proposals[3].SetStatus(StatusPassed)
+	keeper.SetProposal(ctx, proposals[3])
+	require.Nil(t, keeper.AddVote(ctx, proposals[1].GetProposalID(), addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposals[2].GetProposalID(), addrs[1], OptionNo))
+	err, _ := keeper.AddDeposit(ctx, proposals[2].GetProposalID(), addrs[1], sdk.Coins{sdk.NewCoin("steak", 1)})
+	require.Nil(t, err)
+
+	proposalIDs := func(proposals []Proposal) (proposalIDs []int64) {
+		for _, proposal := range proposals {
+			proposalIDs = append(proposalIDs, proposal.GetProposalID())
+		}
+		return proposalIDs
+	}
+	first := proposals[0].GetProposalID()
+
+	require.Equal(t, []int64{first, first + 1, first + 2, first + 3}, proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusNil, 0)))
+	require.Equal(t, []int64{first + 2, first + 3}, proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusNil, 2)))
+	require.Equal(t, []int64{first + 1, first + 2}, proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusVotingPeriod, 0)))
+	require.Equal(t, []int64{first + 1}, proposalIDs(keeper.GetProposalsFiltered(ctx, addrs[0], nil, StatusNil, 0)))
+	require.Equal(t, []int64{first + 2}, proposalIDs(keeper.GetProposalsFiltered(ctx, addrs[1], addrs[1], StatusVotingPeriod, 0)))
+	require.Nil(t, keeper.GetProposalsFiltered(ctx, addrs[0], addrs[1], StatusNil, 0))
 }
 
 func TestProposalQueues(t *testing.T) {
@@ -204,44 +283,30 @@ func TestProposalQueues(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	mapp.InitChainer(ctx, abci.RequestInitChain{})
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
-
-	// create test proposals
-	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
-	proposal2 := keeper.NewTextProposal(ctx, "Test2", "description", ProposalTypeText)
-	proposal3 := keeper.NewTextProposal(ctx, "Test3", "description", ProposalTypeText)
-	proposal4 := keeper.NewTextProposal(ctx, "Test4", "description", ProposalTypeText)
-
-	// test pushing to inactive proposal queue
-	keeper.InactiveProposalQueuePush(ctx, proposal)
-	keeper.InactiveProposalQueuePush(ctx, proposal2)
-	keeper.InactiveProposalQueuePush(ctx, proposal3)
-	keeper.InactiveProposalQueuePush(ctx, proposal4)
-
-	// test peeking and popping from inactive proposal queue
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
-
-	// test pushing to active proposal queue
-	keeper.ActiveProposalQueuePush(ctx, proposal)
-	keeper.ActiveProposalQueuePush(ctx, proposal2)
-	keeper.ActiveProposalQueuePush(ctx, proposal3)
-	keeper.ActiveProposalQueuePush(ctx, proposal4)
-
-	// test peeking and popping from active proposal queue
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, 1000)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, 1000)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
+
+	// the queues are ordered by end time, then by proposal ID
+	keeper.InsertInactiveProposalQueue(ctx, 20, 4)
+	keeper.InsertInactiveProposalQueue(ctx, 10, 3)
+	keeper.InsertInactiveProposalQueue(ctx, 10, 1)
+	keeper.InsertInactiveProposalQueue(ctx, 30, 2)
+	keeper.InsertActiveProposalQueue(ctx, 20, 4)
+	keeper.InsertActiveProposalQueue(ctx, 10, 3)
+	keeper.InsertActiveProposalQueue(ctx, 10, 1)
+	keeper.InsertActiveProposalQueue(ctx, 30, 2)
+
+	require.Equal(t, []int64{1, 3}, queuedProposalIDs(keeper.InactiveProposalQueueIterator(ctx, 19)))
+	require.Equal(t, []int64{1, 3, 4}, queuedProposalIDs(keeper.InactiveProposalQueueIterator(ctx, 20)))
+	require.Equal(t, []int64{1, 3, 4, 2}, queuedProposalIDs(keeper.ActiveProposalQueueIterator(ctx, 30)))
+
+	// removing from a queue leaves the other queue untouched
+	keeper.RemoveFromInactiveProposalQueue(ctx, 10, 1)
+	keeper.RemoveFromInactiveProposalQueue(ctx, 30, 2)
+	require.Equal(t, []int64{3, 4}, queuedProposalIDs(keeper.InactiveProposalQueueIterator(ctx, 30)))
+	require.Equal(t, []int64{1, 3, 4, 2}, queuedProposalIDs(keeper.ActiveProposalQueueIterator(ctx, 30)))
 }
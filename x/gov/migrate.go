@@ -0,0 +1,157 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// keys of the serialized proposal queues replaced by the queues indexed by
+// end time
+var (
+	legacyKeyActiveProposalQueue   = []byte("activeProposalQueue")
+	legacyKeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+)
+
+// layout of the proposals when their periods were block heights
+type legacyTextProposal struct {
+	ProposalID       int64        `json:"proposal_id"`
+	Title            string       `json:"title"`
+	Description      string       `json:"description"`
+	ProposalType     ProposalKind `json:"proposal_type"`
+	Status           VoteStatus   `json:"string"`
+	SubmitBlock      int64        `json:"submit_block"`
+	TotalDeposit     sdk.Coins    `json:"total_deposit"`
+	VotingStartBlock int64        `json:"voting_start_block"`
+}
+
+type legacyParameterChangeProposal struct {
+	TextProposal legacyTextProposal
+	Changes      []ParamChange `json:"changes"`
+}
+
+type legacySoftwareUpgradeProposal struct {
+	TextProposal legacyTextProposal
+	Plan         upgrade.Plan `json:"plan"`
+}
+
+type legacyProposal interface {
+	getTextProposal() legacyTextProposal
+}
+
+// nolint
+func (tp *legacyTextProposal) getTextProposal() legacyTextProposal { return *tp }
+func (pcp *legacyParameterChangeProposal) getTextProposal() legacyTextProposal {
+	return pcp.TextProposal
+}
+func (sup *legacySoftwareUpgradeProposal) getTextProposal() legacyTextProposal {
+	return sup.TextProposal
+}
+
+// the legacy proposals are registered under the names of the current ones
+func newLegacyProposalCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*legacyProposal)(nil), nil)
+	cdc.RegisterConcrete(&legacyTextProposal{}, "gov/TextProposal", nil)
+	cdc.RegisterConcrete(&legacyParameterChangeProposal{}, "gov/ParameterChangeProposal", nil)
+	cdc.RegisterConcrete(&legacySoftwareUpgradeProposal{}, "gov/SoftwareUpgradeProposal", nil)
+	return cdc
+}
+
+// MigrateToTimeBasedPeriods converts the governance state of a chain started
+// when the deposit and voting periods were block counts, it is meant to be
+// called by the handler of the upgrade to a binary with time based periods.
+// The periods of the procedures and the heights of the proposals are
+// converted to seconds with blockInterval, the expected seconds per block,
+// the quorum is added to the tallying procedure, and the serialized proposal
+// queues are replaced by the queues indexed by end time.
+func (keeper Keeper) MigrateToTimeBasedPeriods(ctx sdk.Context, blockInterval int64, quorum sdk.Rat) {
+	depositProcedure := keeper.GetDepositProcedure(ctx)
+	depositProcedure.MaxDepositPeriod *= blockInterval
+	keeper.SetDepositProcedure(ctx, depositProcedure)
+
+	votingProcedure := keeper.GetVotingProcedure(ctx)
+	votingProcedure.VotingPeriod *= blockInterval
+	keeper.SetVotingProcedure(ctx, votingProcedure)
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+	tallyingProcedure.Quorum = quorum
+	keeper.SetTallyingProcedure(ctx, tallyingProcedure)
+
+	// past heights are converted to the times they had at the block interval
+	blockTime := ctx.BlockHeader().Time
+	heightToTime := func(height int64) int64 {
+		return blockTime - (ctx.BlockHeight()-height)*blockInterval
+	}
+
+	store := ctx.KVStore(keeper.storeKey)
+	legacyCdc := newLegacyProposalCodec()
+
+	// the proposals are rewritten once the iteration is over
+	var legacyProposals []legacyProposal
+	proposalsIterator := sdk.KVStorePrefixIterator(store, KeyProposalsSubspace())
+	for ; proposalsIterator.Valid(); proposalsIterator.Next() {
+		var legacy legacyProposal
+		legacyCdc.MustUnmarshalBinary(proposalsIterator.Value(), &legacy)
+		legacyProposals = append(legacyProposals, legacy)
+	}
+	proposalsIterator.Close()
+
+	for _, legacy := range legacyProposals {
+		legacyText := legacy.getTextProposal()
+		textProposal := TextProposal{
+			ProposalID:      legacyText.ProposalID,
+			Title:           legacyText.Title,
+			Description:     legacyText.Description,
+			ProposalType:    legacyText.ProposalType,
+			Status:          legacyText.Status,
+			SubmitTime:      heightToTime(legacyText.SubmitBlock),
+			TotalDeposit:    legacyText.TotalDeposit,
+			VotingStartTime: -1,
+			VotingEndTime:   -1,
+		}
+		textProposal.DepositEndTime = textProposal.SubmitTime + depositProcedure.MaxDepositPeriod
+		if legacyText.VotingStartBlock >= 0 {
+			textProposal.VotingStartTime = heightToTime(legacyText.VotingStartBlock)
+			textProposal.VotingEndTime = textProposal.VotingStartTime + votingProcedure.VotingPeriod
+		}
+
+		var proposal Proposal
+		switch legacy := legacy.(type) {
+		case *legacyParameterChangeProposal:
+			proposal = &ParameterChangeProposal{TextProposal: textProposal, Changes: legacy.Changes}
+		case *legacySoftwareUpgradeProposal:
+			proposal = &SoftwareUpgradeProposal{TextProposal: textProposal, Plan: legacy.Plan}
+		default:
+			proposal = &textProposal
+		}
+		keeper.SetProposal(ctx, proposal)
+	}
+
+	// the serialized queues may hold proposals which already left their
+	// period, they are only popped lazily
+	for _, proposalID := range keeper.popLegacyProposalQueue(ctx, legacyKeyInactiveProposalQueue) {
+		proposal := keeper.GetProposal(ctx, proposalID)
+		if proposal != nil && proposal.GetStatus() == StatusDepositPeriod {
+			keeper.InsertInactiveProposalQueue(ctx, proposal.GetDepositEndTime(), proposalID)
+		}
+	}
+	for _, proposalID := range keeper.popLegacyProposalQueue(ctx, legacyKeyActiveProposalQueue) {
+		proposal := keeper.GetProposal(ctx, proposalID)
+		if proposal != nil && proposal.GetStatus() == StatusVotingPeriod {
+			keeper.InsertActiveProposalQueue(ctx, proposal.GetVotingEndTime(), proposalID)
+		}
+	}
+}
+
+// returns the proposal IDs of a serialized proposal queue and deletes it
+func (keeper Keeper) popLegacyProposalQueue(ctx sdk.Context, key []byte) (proposalIDs []int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(key)
+	if bz == nil {
+		return nil
+	}
+	keeper.cdc.MustUnmarshalBinary(bz, &proposalIDs)
+	store.Delete(key)
+	return proposalIDs
+}
@@ -0,0 +1,78 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMigrateToTimeBasedPeriods(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: 1000}).WithBlockHeight(100)
+	store := ctx.KVStore(keeper.storeKey)
+	legacyCdc := newLegacyProposalCodec()
+
+	// procedures in blocks
+	keeper.SetDepositProcedure(ctx, DepositProcedure{MinDeposit: sdk.Coins{sdk.NewCoin("steak", 10)}, MaxDepositPeriod: 200})
+	keeper.SetVotingProcedure(ctx, VotingProcedure{VotingPeriod: 100})
+
+	// a proposal in its deposit period, one in its voting period which is
+	// still in the inactive queue, and a passed one
+	changes := []ParamChange{NewParamChange("stake", "MaxValidators", `105`)}
+	legacyProposals := []legacyProposal{
+		&legacyTextProposal{ProposalID: 1, Title: "Test", Status: StatusDepositPeriod, SubmitBlock: 90, VotingStartBlock: -1},
+		&legacyParameterChangeProposal{
+			TextProposal: legacyTextProposal{ProposalID: 2, Title: "Test2", Status: StatusVotingPeriod, SubmitBlock: 50, VotingStartBlock: 80},
+			Changes:      changes,
+		},
+		&legacyTextProposal{ProposalID: 3, Title: "Test3", Status: StatusPassed, SubmitBlock: 10, VotingStartBlock: 20},
+	}
+	for _, legacy := range legacyProposals {
+		store.Set(KeyProposal(legacy.getTextProposal().ProposalID), legacyCdc.MustMarshalBinary(legacy))
+	}
+	store.Set(legacyKeyInactiveProposalQueue, keeper.cdc.MustMarshalBinary([]int64{1, 2}))
+	store.Set(legacyKeyActiveProposalQueue, keeper.cdc.MustMarshalBinary([]int64{2}))
+
+	keeper.MigrateToTimeBasedPeriods(ctx, 5, sdk.NewRat(1, 3))
+
+	require.Equal(t, int64(1000), keeper.GetDepositProcedure(ctx).MaxDepositPeriod)
+	require.Equal(t, int64(500), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.Equal(t, sdk.NewRat(1, 3), keeper.GetTallyingProcedure(ctx).Quorum)
+
+	// the heights are converted at 5 seconds per block before the block time
+	proposal := keeper.GetProposal(ctx, 1)
+	require.Equal(t, "Test", proposal.GetTitle())
+	require.Equal(t, int64(950), proposal.GetSubmitTime())
+	require.Equal(t, int64(1950), proposal.GetDepositEndTime())
+	require.Equal(t, int64(-1), proposal.GetVotingStartTime())
+
+	proposal = keeper.GetProposal(ctx, 2)
+	require.Equal(t, changes, proposal.(*ParameterChangeProposal).Changes)
+	require.Equal(t, int64(750), proposal.GetSubmitTime())
+	require.Equal(t, int64(900), proposal.GetVotingStartTime())
+	require.Equal(t, int64(1400), proposal.GetVotingEndTime())
+
+	proposal = keeper.GetProposal(ctx, 3)
+	require.Equal(t, StatusPassed, proposal.GetStatus())
+	require.Equal(t, int64(550), proposal.GetVotingStartTime())
+
+	// the queues only hold the proposals still in their period
+	require.Nil(t, store.Get(legacyKeyInactiveProposalQueue))
+	require.Nil(t, store.Get(legacyKeyActiveProposalQueue))
+	require.Equal(t, []int64{1}, queuedProposalIDs(keeper.InactiveProposalQueueIterator(ctx, 10000)))
+	require.Equal(t, []int64{2}, queuedProposalIDs(keeper.ActiveProposalQueueIterator(ctx, 10000)))
+
+	// the migrated proposals end with the block time
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 1400})
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, 2).GetStatus())
+	require.NotNil(t, keeper.GetProposal(ctx, 1))
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 1950})
+	EndBlocker(ctx, keeper)
+	require.Nil(t, keeper.GetProposal(ctx, 1))
+}
@@ -0,0 +1,82 @@
+package gov
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name of the gov module, used for its routes and genesis state
+const ModuleName = "gov"
+
+// default name of the gov subspace of the params store
+const DefaultParamspace = ModuleName
+
+// AppModule implements module.AppModule for the gov module
+type AppModule struct {
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// nolint
+func (AppModule) Name() string                                 { return ModuleName }
+func (AppModule) Route() string                                { return ModuleName }
+func (am AppModule) NewHandler() sdk.Handler                   { return NewHandler(am.keeper) }
+func (AppModule) QuerierRoute() string                         { return ModuleName }
+func (am AppModule) NewQuerierHandler() sdk.Querier            { return NewQuerier(am.keeper) }
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter) { RegisterInvariants(ir, am.keeper) }
+
+// DefaultGenesis returns the default gov genesis state
+func (am AppModule) DefaultGenesis() json.RawMessage {
+	return am.mustMarshalGenesis(DefaultGenesisState())
+}
+
+// ValidateGenesis validates the gov genesis state
+func (am AppModule) ValidateGenesis(data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	return ValidateGenesis(genesisState)
+}
+
+// InitGenesis initializes the gov state from its genesis state
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	InitGenesis(ctx, am.keeper, genesisState)
+	return nil
+}
+
+// ExportGenesis exports the gov state as its genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return am.mustMarshalGenesis(WriteGenesis(ctx, am.keeper))
+}
+
+// BeginBlock is a no-op for the gov module
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return nil
+}
+
+// EndBlock drops the proposals which didn't reach the minimum deposit,
+// tallies the proposals at the end of their voting period and penalizes the
+// validators which didn't vote
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return nil, EndBlocker(ctx, am.keeper)
+}
+
+func (am AppModule) mustMarshalGenesis(genesisState GenesisState) json.RawMessage {
+	bz, err := am.keeper.cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // name to idetify transaction types
@@ -12,11 +13,13 @@ const MsgType = "gov"
 //-----------------------------------------------------------
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string       //  Title of the proposal
-	Description    string       //  Description of the proposal
-	ProposalType   ProposalKind //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.Address  //  Address of the proposer
-	InitialDeposit sdk.Coins    //  Initial deposit paid by sender. Must be strictly positive.
+	Title          string        //  Title of the proposal
+	Description    string        //  Description of the proposal
+	ProposalType   ProposalKind  //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.Address   //  Address of the proposer
+	InitialDeposit sdk.Coins     //  Initial deposit paid by sender. Must be strictly positive.
+	ParamChanges   []ParamChange //  Changes applied if a ParameterChange proposal passes
+	Plan           upgrade.Plan  //  Upgrade scheduled if a SoftwareUpgrade proposal passes
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -29,6 +32,28 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+func NewMsgSubmitParameterChangeProposal(title string, description string, changes []ParamChange, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		ParamChanges:   changes,
+	}
+}
+
+func NewMsgSubmitSoftwareUpgradeProposal(title string, description string, plan upgrade.Plan, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeSoftwareUpgrade,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		Plan:           plan,
+	}
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 
@@ -52,11 +77,30 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
+	if msg.ProposalType != ProposalTypeParameterChange && len(msg.ParamChanges) != 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "only ParameterChange proposals can change parameters")
+	}
+	if msg.ProposalType != ProposalTypeSoftwareUpgrade && msg.Plan != (upgrade.Plan{}) {
+		return upgrade.ErrInvalidPlan(upgrade.DefaultCodespace, "only SoftwareUpgrade proposals can schedule an upgrade")
+	}
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		if len(msg.ParamChanges) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "no parameter changes")
+		}
+		for _, change := range msg.ParamChanges {
+			if err := change.ValidateBasic(); err != nil {
+				return err
+			}
+		}
+	case ProposalTypeSoftwareUpgrade:
+		return msg.Plan.ValidateBasic()
+	}
 	return nil
 }
 
 func (msg MsgSubmitProposal) String() string {
-	return fmt.Sprintf("MsgSubmitProposal{%v, %v, %v, %v}", msg.Title, msg.Description, ProposalTypeToString(msg.ProposalType), msg.InitialDeposit)
+	return fmt.Sprintf("MsgSubmitProposal{%v, %v, %v, %v, %v, %v}", msg.Title, msg.Description, ProposalTypeToString(msg.ProposalType), msg.InitialDeposit, msg.ParamChanges, msg.Plan)
 }
 
 // Implements Msg.
@@ -66,18 +110,26 @@ func (msg MsgSubmitProposal) Get(key interface{}) (value interface{}) {
 
 // Implements Msg.
 func (msg MsgSubmitProposal) GetSignBytes() []byte {
+	var plan *upgrade.Plan
+	if msg.Plan != (upgrade.Plan{}) {
+		plan = &msg.Plan
+	}
 	b, err := msgCdc.MarshalJSON(struct {
-		Title          string    `json:"title"`
-		Description    string    `json:"description"`
-		ProposalType   string    `json:"proposal_type"`
-		Proposer       string    `json:"proposer"`
-		InitialDeposit sdk.Coins `json:"deposit"`
+		Title          string        `json:"title"`
+		Description    string        `json:"description"`
+		ProposalType   string        `json:"proposal_type"`
+		Proposer       string        `json:"proposer"`
+		InitialDeposit sdk.Coins     `json:"deposit"`
+		ParamChanges   []ParamChange `json:"param_changes,omitempty"`
+		Plan           *upgrade.Plan `json:"plan,omitempty"`
 	}{
 		Title:          msg.Title,
 		Description:    msg.Description,
 		ProposalType:   ProposalTypeToString(msg.ProposalType),
 		Proposer:       sdk.MustBech32ifyVal(msg.Proposer),
 		InitialDeposit: msg.InitialDeposit,
+		ParamChanges:   msg.ParamChanges,
+		Plan:           plan,
 	})
 	if err != nil {
 		panic(err)
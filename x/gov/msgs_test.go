@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 var (
@@ -30,8 +31,8 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.Address{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -49,6 +50,65 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgSubmitProposal with parameter changes
+func TestMsgSubmitParameterChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{NewParamChange("gov", "votingprocedure", `{"voting_period":"300"}`)}, true},
+		{[]ParamChange{NewParamChange("gov", "votingprocedure", `{"voting_period":"300"}`), NewParamChange("stake", "MaxValidators", `105`)}, true},
+		{nil, false},
+		{[]ParamChange{NewParamChange("", "votingprocedure", `{"voting_period":"300"}`)}, false},
+		{[]ParamChange{NewParamChange("gov", "", `{"voting_period":"300"}`)}, false},
+		{[]ParamChange{NewParamChange("gov", "votingprocedure", `{"voting_period":`)}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", tc.changes, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// only parameter change proposals carry changes
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.ParamChanges = []ParamChange{NewParamChange("stake", "MaxValidators", `105`)}
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// test ValidateBasic for MsgSubmitProposal with an upgrade plan
+func TestMsgSubmitSoftwareUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		plan       upgrade.Plan
+		expectPass bool
+	}{
+		{upgrade.NewPlan("v2", 100, 0, "info"), true},
+		{upgrade.NewPlan("v2", 0, 1000, ""), true},
+		{upgrade.Plan{}, false},
+		{upgrade.NewPlan("", 100, 0, "info"), false},
+		{upgrade.NewPlan("v2", 100, 1000, "info"), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", tc.plan, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// only software upgrade proposals carry a plan
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.Plan = upgrade.NewPlan("v2", 100, 0, "info")
+	require.NotNil(t, msg.ValidateBasic())
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
@@ -0,0 +1,119 @@
+package gov
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+//-----------------------------------------------------------
+// Parameter changes
+
+// ParamChange sets the parameter stored under Key in the Subspace of the
+// params store to Value, the JSON of the new value of the parameter
+type ParamChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+func NewParamChange(subspace, key, value string) ParamChange {
+	return ParamChange{
+		Subspace: subspace,
+		Key:      key,
+		Value:    value,
+	}
+}
+
+// ValidateBasic checks the change is well formed, the parameter and the
+// type of its value are checked against the params store on submission
+func (pc ParamChange) ValidateBasic() sdk.Error {
+	if len(pc.Subspace) == 0 || len(pc.Key) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("empty subspace or key in parameter change %v", pc))
+	}
+	if !json.Valid([]byte(pc.Value)) {
+		return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("value of parameter change %v is not valid JSON", pc))
+	}
+	return nil
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s=%s", pc.Subspace, pc.Key, pc.Value)
+}
+
+//-----------------------------------------------------------
+// ParameterChange Proposals
+
+// ParameterChangeProposal is a proposal which applies its parameter
+// changes when it passes
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Changes applied if the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
+// Creates a new ParameterChangeProposal, its changes must have been validated
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeParameterChange),
+		Changes:      changes,
+	}
+	keeper.submitProposal(ctx, proposal)
+	return proposal
+}
+
+// ValidateParamChanges checks that the changed parameters exist, that the
+// new values are of their type and that the subspaces pass their validation
+// once changed. The changes are applied to a cache of the context which is
+// discarded.
+func (keeper Keeper) ValidateParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	if len(changes) == 0 {
+		return ErrInvalidParamChange(keeper.codespace, "no parameter changes")
+	}
+	for _, change := range changes {
+		space, ok := keeper.pk.GetSubspace(change.Subspace)
+		if !ok {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("unknown subspace %s", change.Subspace))
+		}
+		if err := space.Validate([]byte(change.Key), []byte(change.Value)); err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	return keeper.applyParamChanges(cacheCtx, changes)
+}
+
+// apply the parameter changes, then run the validation of every changed
+// subspace. It stops at the first failure, the caller must revert the
+// changes already applied.
+func (keeper Keeper) applyParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	var spaces []params.Subspace
+	changed := make(map[string]bool)
+	for _, change := range changes {
+		space, ok := keeper.pk.GetSubspace(change.Subspace)
+		if !ok {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("unknown subspace %s", change.Subspace))
+		}
+		if err := space.Update(ctx, []byte(change.Key), []byte(change.Value)); err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+		if !changed[change.Subspace] {
+			changed[change.Subspace] = true
+			spaces = append(spaces, space)
+		}
+	}
+	for _, space := range spaces {
+		if err := space.ValidateParams(ctx); err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	return nil
+}
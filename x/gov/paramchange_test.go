@@ -0,0 +1,136 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestValidateParamChanges(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{NewParamChange("gov", "votingprocedure", `{"voting_period":"300"}`)}, true},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `105`)}, true},
+		{nil, false},
+		{[]ParamChange{NewParamChange("unknown", "votingprocedure", `{"voting_period":"300"}`)}, false},
+		{[]ParamChange{NewParamChange("gov", "unknown", `{"voting_period":"300"}`)}, false},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `"many"`)}, false},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `105`), NewParamChange("stake", "unknown", `105`)}, false},
+		// values rejected by the validation of the subspaces
+		{[]ParamChange{NewParamChange("gov", "votingprocedure", `{"voting_period":"0"}`)}, false},
+		{[]ParamChange{NewParamChange("gov", "tallyingprocedure", `{"quorum":"1/3","threshold":"2/1","veto":"1/3","governance_penalty":"1/100"}`)}, false},
+		{[]ParamChange{NewParamChange("gov", "tallyingprocedure", `{"quorum":"1/3","threshold":"1/2","veto":"1/3","governance_penalty":"3/2"}`)}, false},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `0`)}, false},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `105`), NewParamChange("gov", "votingprocedure", `{"voting_period":"-1"}`)}, false},
+	}
+
+	for i, tc := range tests {
+		err := keeper.ValidateParamChanges(ctx, tc.changes)
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", i)
+		} else {
+			require.NotNil(t, err, "test: %v", i)
+		}
+	}
+}
+
+func TestExecuteParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// text proposals have no effect
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	require.Nil(t, keeper.executeProposal(ctx, proposal))
+
+	changes := []ParamChange{
+		NewParamChange("gov", "votingprocedure", `{"voting_period":"300"}`),
+		NewParamChange("stake", "MaxValidators", `105`),
+	}
+	proposal = keeper.NewParameterChangeProposal(ctx, "Test", "description", changes)
+	require.Equal(t, ProposalTypeParameterChange, proposal.GetProposalType())
+	require.Equal(t, changes, keeper.GetProposal(ctx, proposal.GetProposalID()).(*ParameterChangeProposal).Changes)
+
+	tags := keeper.executeProposal(ctx, proposal)
+	require.Equal(t, sdk.NewTags("paramChange", []byte("applied")), tags)
+	require.Equal(t, int64(300), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.Equal(t, uint16(105), sk.GetParams(ctx).MaxValidators)
+
+	// a failing change reverts the whole proposal
+	changes = []ParamChange{
+		NewParamChange("gov", "votingprocedure", `{"voting_period":"400"}`),
+		NewParamChange("stake", "MaxValidators", `"many"`),
+	}
+	proposal = keeper.NewParameterChangeProposal(ctx, "Test", "description", changes)
+	tags = keeper.executeProposal(ctx, proposal)
+	require.Equal(t, sdk.NewTags("paramChange", []byte("failed")), tags)
+	require.Equal(t, int64(300), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.Equal(t, uint16(105), sk.GetParams(ctx).MaxValidators)
+
+	// so does a change rejected by the validation of its subspace
+	changes = []ParamChange{
+		NewParamChange("gov", "votingprocedure", `{"voting_period":"400"}`),
+		NewParamChange("stake", "MaxValidators", `0`),
+	}
+	proposal = keeper.NewParameterChangeProposal(ctx, "Test", "description", changes)
+	tags = keeper.executeProposal(ctx, proposal)
+	require.Equal(t, sdk.NewTags("paramChange", []byte("failed")), tags)
+	require.Equal(t, int64(300), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.Equal(t, uint16(105), sk.GetParams(ctx).MaxValidators)
+}
+
+func TestParameterChangeProposalPasses(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription, dummyCommission)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+
+	// unknown parameters are rejected on submission
+	changes := []ParamChange{NewParamChange("stake", "unknown", `105`)}
+	newProposalMsg := NewMsgSubmitParameterChangeProposal("Test", "test", changes, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.False(t, res.IsOK())
+
+	// so are invalid values
+	changes = []ParamChange{NewParamChange("stake", "MaxValidators", `0`)}
+	newProposalMsg = NewMsgSubmitParameterChangeProposal("Test", "test", changes, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.False(t, res.IsOK())
+	require.Equal(t, uint16(100), sk.GetParams(ctx).MaxValidators)
+
+	changes = []ParamChange{NewParamChange("stake", "MaxValidators", `105`)}
+	newProposalMsg = NewMsgSubmitParameterChangeProposal("Test", "test", changes, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, uint16(105), sk.GetParams(ctx).MaxValidators)
+	require.Contains(t, tags, sdk.MakeTag("paramChange", []byte("applied")))
+}
@@ -7,11 +7,12 @@ import (
 // Procedure around Deposits for governance
 type DepositProcedure struct {
 	MinDeposit       sdk.Coins `json:"min_deposit"`        //  Minimum deposit for a proposal to enter voting period.
-	MaxDepositPeriod int64     `json:"max_deposit_period"` //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
+	MaxDepositPeriod int64     `json:"max_deposit_period"` //  Maximum period in seconds for Atom holders to deposit on a proposal. Initial value: 2 days
 }
 
 // Procedure around Tallying votes in governance
 type TallyingProcedure struct {
+	Quorum            sdk.Rat `json:"quorum"`             //  Minimum proportion of the bonded power which must vote for the result to be valid. Initial value: 1/3
 	Threshold         sdk.Rat `json:"threshold"`          //  Minimum propotion of Yes votes for proposal to pass. Initial value: 0.5
 	Veto              sdk.Rat `json:"veto"`               //  Minimum value of Veto votes to Total votes ratio for proposal to be vetoed. Initial value: 1/3
 	GovernancePenalty sdk.Rat `json:"governance_penalty"` //  Penalty if validator does not vote
@@ -19,5 +20,5 @@ type TallyingProcedure struct {
 
 // Procedure around Voting in governance
 type VotingProcedure struct {
-	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period.
+	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period in seconds.
 }
@@ -2,6 +2,7 @@ package gov
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // Type that represents Status as a byte
@@ -12,6 +13,7 @@ type ProposalKind = byte
 
 //nolint
 const (
+	StatusNil           VoteStatus = 0x00
 	StatusDepositPeriod VoteStatus = 0x01
 	StatusVotingPeriod  VoteStatus = 0x02
 	StatusPassed        VoteStatus = 0x03
@@ -40,14 +42,20 @@ type Proposal interface {
 	GetStatus() VoteStatus
 	SetStatus(VoteStatus)
 
-	GetSubmitBlock() int64
-	SetSubmitBlock(int64)
+	GetSubmitTime() int64
+	SetSubmitTime(int64)
+
+	GetDepositEndTime() int64
+	SetDepositEndTime(int64)
 
 	GetTotalDeposit() sdk.Coins
 	SetTotalDeposit(sdk.Coins)
 
-	GetVotingStartBlock() int64
-	SetVotingStartBlock(int64)
+	GetVotingStartTime() int64
+	SetVotingStartTime(int64)
+
+	GetVotingEndTime() int64
+	SetVotingEndTime(int64)
 }
 
 // checks if two proposals are equal
@@ -57,9 +65,11 @@ func ProposalEqual(proposalA Proposal, proposalB Proposal) bool {
 		proposalA.GetDescription() != proposalB.GetDescription() ||
 		proposalA.GetProposalType() != proposalB.GetProposalType() ||
 		proposalA.GetStatus() != proposalB.GetStatus() ||
-		proposalA.GetSubmitBlock() != proposalB.GetSubmitBlock() ||
+		proposalA.GetSubmitTime() != proposalB.GetSubmitTime() ||
+		proposalA.GetDepositEndTime() != proposalB.GetDepositEndTime() ||
 		!(proposalA.GetTotalDeposit().IsEqual(proposalB.GetTotalDeposit())) ||
-		proposalA.GetVotingStartBlock() != proposalB.GetVotingStartBlock() {
+		proposalA.GetVotingStartTime() != proposalB.GetVotingStartTime() ||
+		proposalA.GetVotingEndTime() != proposalB.GetVotingEndTime() {
 		return false
 	}
 	return true
@@ -75,16 +85,18 @@ type TextProposal struct {
 
 	Status VoteStatus `json:"string"` //  Status of the Proposal {Pending, Active, Passed, Rejected}
 
-	SubmitBlock  int64     `json:"submit_block"`  //  Height of the block where TxGovSubmitProposal was included
-	TotalDeposit sdk.Coins `json:"total_deposit"` //  Current deposit on this proposal. Initial value is set at InitialDeposit
+	SubmitTime     int64     `json:"submit_time"`      //  Time of the block where TxGovSubmitProposal was included
+	DepositEndTime int64     `json:"deposit_end_time"` //  Time at which the deposit period ends, the proposal is dropped if MinDeposit is not reached by then
+	TotalDeposit   sdk.Coins `json:"total_deposit"`    //  Current deposit on this proposal. Initial value is set at InitialDeposit
 
-	VotingStartBlock int64 `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	VotingStartTime int64 `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	VotingEndTime   int64 `json:"voting_end_time"`   //  Time at which the voting period ends and the votes are tallied. -1 if MinDeposit is not reached
 }
 
 // Implements Proposal Interface
 var _ Proposal = (*TextProposal)(nil)
 
-// nolint
+//nolint
 func (tp TextProposal) GetProposalID() int64                       { return tp.ProposalID }
 func (tp *TextProposal) SetProposalID(proposalID int64)            { tp.ProposalID = proposalID }
 func (tp TextProposal) GetTitle() string                           { return tp.Title }
@@ -95,26 +107,25 @@ func (tp TextProposal) GetProposalType() ProposalKind              { return tp.P
 func (tp *TextProposal) SetProposalType(proposalType ProposalKind) { tp.ProposalType = proposalType }
 func (tp TextProposal) GetStatus() VoteStatus                      { return tp.Status }
 func (tp *TextProposal) SetStatus(status VoteStatus)               { tp.Status = status }
-func (tp TextProposal) GetSubmitBlock() int64                      { return tp.SubmitBlock }
-func (tp *TextProposal) SetSubmitBlock(submitBlock int64)          { tp.SubmitBlock = submitBlock }
+func (tp TextProposal) GetSubmitTime() int64                       { return tp.SubmitTime }
+func (tp *TextProposal) SetSubmitTime(submitTime int64)            { tp.SubmitTime = submitTime }
+func (tp TextProposal) GetDepositEndTime() int64                   { return tp.DepositEndTime }
+func (tp *TextProposal) SetDepositEndTime(depositEndTime int64)    { tp.DepositEndTime = depositEndTime }
 func (tp TextProposal) GetTotalDeposit() sdk.Coins                 { return tp.TotalDeposit }
 func (tp *TextProposal) SetTotalDeposit(totalDeposit sdk.Coins)    { tp.TotalDeposit = totalDeposit }
-func (tp TextProposal) GetVotingStartBlock() int64                 { return tp.VotingStartBlock }
-func (tp *TextProposal) SetVotingStartBlock(votingStartBlock int64) {
-	tp.VotingStartBlock = votingStartBlock
-}
-
-// Current Active Proposals
-type ProposalQueue []int64
+func (tp TextProposal) GetVotingStartTime() int64                  { return tp.VotingStartTime }
+func (tp *TextProposal) SetVotingStartTime(votingStartTime int64)  { tp.VotingStartTime = votingStartTime }
+func (tp TextProposal) GetVotingEndTime() int64                    { return tp.VotingEndTime }
+func (tp *TextProposal) SetVotingEndTime(votingEndTime int64)      { tp.VotingEndTime = votingEndTime }
 
 // ProposalTypeToString for pretty prints of ProposalType
 func ProposalTypeToString(proposalType ProposalKind) string {
 	switch proposalType {
-	case 0x00:
+	case ProposalTypeText:
 		return "Text"
-	case 0x01:
+	case ProposalTypeParameterChange:
 		return "ParameterChange"
-	case 0x02:
+	case ProposalTypeSoftwareUpgrade:
 		return "SoftwareUpgrade"
 	default:
 		return ""
@@ -179,26 +190,44 @@ func StringToStatus(status string) VoteStatus {
 //-----------------------------------------------------------
 // Rest Proposals
 type ProposalRest struct {
-	ProposalID       int64     `json:"proposal_id"`        //  ID of the proposal
-	Title            string    `json:"title"`              //  Title of the proposal
-	Description      string    `json:"description"`        //  Description of the proposal
-	ProposalType     string    `json:"proposal_type"`      //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Status           string    `json:"string"`             //  Status of the Proposal {Pending, Active, Passed, Rejected}
-	SubmitBlock      int64     `json:"submit_block"`       //  Height of the block where TxGovSubmitProposal was included
-	TotalDeposit     sdk.Coins `json:"total_deposit"`      //  Current deposit on this proposal. Initial value is set at InitialDeposit
-	VotingStartBlock int64     `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	ProposalID      int64     `json:"proposal_id"`       //  ID of the proposal
+	Title           string    `json:"title"`             //  Title of the proposal
+	Description     string    `json:"description"`       //  Description of the proposal
+	ProposalType    string    `json:"proposal_type"`     //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Status          string    `json:"string"`            //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	SubmitTime      int64     `json:"submit_time"`       //  Time of the block where TxGovSubmitProposal was included
+	DepositEndTime  int64     `json:"deposit_end_time"`  //  Time at which the deposit period ends
+	TotalDeposit    sdk.Coins `json:"total_deposit"`     //  Current deposit on this proposal. Initial value is set at InitialDeposit
+	VotingStartTime int64     `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	VotingEndTime   int64     `json:"voting_end_time"`   //  Time at which the voting period ends. -1 if MinDeposit is not reached
+
+	ParamChanges []ParamChange `json:"param_changes,omitempty"` //  Changes of a ParameterChange proposal
+	Plan         *upgrade.Plan `json:"plan,omitempty"`          //  Upgrade plan of a SoftwareUpgrade proposal
 }
 
 // Turn any Proposal to a ProposalRest
 func ProposalToRest(proposal Proposal) ProposalRest {
+	var paramChanges []ParamChange
+	var plan *upgrade.Plan
+	switch proposal := proposal.(type) {
+	case *ParameterChangeProposal:
+		paramChanges = proposal.Changes
+	case *SoftwareUpgradeProposal:
+		plan = &proposal.Plan
+	}
+
 	return ProposalRest{
-		ProposalID:       proposal.GetProposalID(),
-		Title:            proposal.GetTitle(),
-		Description:      proposal.GetDescription(),
-		ProposalType:     ProposalTypeToString(proposal.GetProposalType()),
-		Status:           StatusToString(proposal.GetStatus()),
-		SubmitBlock:      proposal.GetSubmitBlock(),
-		TotalDeposit:     proposal.GetTotalDeposit(),
-		VotingStartBlock: proposal.GetVotingStartBlock(),
+		ProposalID:      proposal.GetProposalID(),
+		Title:           proposal.GetTitle(),
+		Description:     proposal.GetDescription(),
+		ProposalType:    ProposalTypeToString(proposal.GetProposalType()),
+		Status:          StatusToString(proposal.GetStatus()),
+		SubmitTime:      proposal.GetSubmitTime(),
+		DepositEndTime:  proposal.GetDepositEndTime(),
+		TotalDeposit:    proposal.GetTotalDeposit(),
+		VotingStartTime: proposal.GetVotingStartTime(),
+		VotingEndTime:   proposal.GetVotingEndTime(),
+		ParamChanges:    paramChanges,
+		Plan:            plan,
 	}
 }
@@ -0,0 +1,168 @@
+package gov
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the governance Querier
+const (
+	QueryProposals = "proposals"
+	QueryProposal  = "proposal"
+	QueryDeposits  = "deposits"
+	QueryDeposit   = "deposit"
+	QueryVotes     = "votes"
+	QueryVote      = "vote"
+	QueryTally     = "tally"
+)
+
+// Params for query 'custom/gov/proposals', the filters which are nil or
+// StatusNil are ignored, all the matching proposals are returned if
+// NumLatestProposals isn't positive
+type QueryProposalsParams struct {
+	Voter              sdk.Address
+	Depositer          sdk.Address
+	ProposalStatus     VoteStatus
+	NumLatestProposals int64
+}
+
+// Params for query 'custom/gov/proposal', 'custom/gov/deposits', 'custom/gov/votes' and 'custom/gov/tally'
+type QueryProposalParams struct {
+	ProposalID int64
+}
+
+// Params for query 'custom/gov/deposit'
+type QueryDepositParams struct {
+	ProposalID int64
+	Depositer  sdk.Address
+}
+
+// Params for query 'custom/gov/vote'
+type QueryVoteParams struct {
+	ProposalID int64
+	Voter      sdk.Address
+}
+
+// NewQuerier returns the querier for the governance module
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no gov query endpoint specified")
+		}
+		switch path[0] {
+		case QueryProposals:
+			return queryProposals(ctx, req, keeper)
+		case QueryProposal:
+			return queryProposal(ctx, req, keeper)
+		case QueryDeposits:
+			return queryDeposits(ctx, req, keeper)
+		case QueryDeposit:
+			return queryDeposit(ctx, req, keeper)
+		case QueryVotes:
+			return queryVotes(ctx, req, keeper)
+		case QueryVote:
+			return queryVote(ctx, req, keeper)
+		case QueryTally:
+			return queryTally(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown gov query endpoint %s", path[0]))
+		}
+	}
+}
+
+func queryProposals(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalsParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	proposals := keeper.GetProposalsFiltered(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals)
+	if proposals == nil {
+		proposals = []Proposal{}
+	}
+	return sdk.MarshalQueryResult(keeper.cdc, proposals)
+}
+
+func queryProposal(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(keeper.codespace, params.ProposalID)
+	}
+	return sdk.MarshalQueryResult(keeper.cdc, proposal)
+}
+
+func queryDeposits(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	deposits := keeper.GetAllDeposits(ctx, params.ProposalID)
+	return sdk.MarshalQueryResult(keeper.cdc, deposits)
+}
+
+func queryDeposit(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryDepositParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	deposit, found := keeper.GetDeposit(ctx, params.ProposalID, params.Depositer)
+	if !found {
+		msg := fmt.Sprintf("address %s has not deposited on proposal %d", params.Depositer, params.ProposalID)
+		return nil, sdk.ErrUnknownRequest(msg)
+	}
+	return sdk.MarshalQueryResult(keeper.cdc, deposit)
+}
+
+func queryVotes(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	votes := keeper.GetAllVotes(ctx, params.ProposalID)
+	return sdk.MarshalQueryResult(keeper.cdc, votes)
+}
+
+func queryVote(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryVoteParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	vote, found := keeper.GetVote(ctx, params.ProposalID, params.Voter)
+	if !found {
+		msg := fmt.Sprintf("address %s has not voted on proposal %d", params.Voter, params.ProposalID)
+		return nil, sdk.ErrUnknownRequest(msg)
+	}
+	return sdk.MarshalQueryResult(keeper.cdc, vote)
+}
+
+// returns the current tally of a proposal in its voting period, the tally
+// of a finished proposal isn't kept as its votes are deleted
+func queryTally(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(keeper.codespace, params.ProposalID)
+	}
+	if proposal.GetStatus() != StatusVotingPeriod {
+		return nil, ErrInactiveProposal(keeper.codespace, params.ProposalID)
+	}
+
+	_, tallyResults, _ := tally(ctx, keeper, proposal)
+	return sdk.MarshalQueryResult(keeper.cdc, tallyResults)
+}
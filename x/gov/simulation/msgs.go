@@ -0,0 +1,98 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+)
+
+const denom = "steak"
+
+// SimulateMsgSubmitProposal submits a text proposal of a random account,
+// with a random initial deposit
+func SimulateMsgSubmitProposal(m auth.AccountMapper, k gov.Keeper) simulation.Operation {
+	handler := gov.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		acc := simulation.RandomAcc(r, accs)
+		msg := gov.NewMsgSubmitProposal(
+			simulation.RandStringOfLength(r, 5),
+			simulation.RandStringOfLength(r, 20),
+			gov.ProposalTypeText,
+			acc.Address,
+			randomDeposit(r, ctx, m, acc.Address),
+		)
+		return simulation.RunMsg(ctx, handler, msg, "gov/MsgSubmitProposal", event)
+	}
+}
+
+// SimulateMsgDeposit deposits a random amount of a random account on a
+// random proposal
+func SimulateMsgDeposit(m auth.AccountMapper, k gov.Keeper) simulation.Operation {
+	handler := gov.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		proposalID, ok := randomProposalID(r, ctx, k)
+		if !ok {
+			return simulation.Noop("gov/MsgDeposit", "no proposals", event)
+		}
+		acc := simulation.RandomAcc(r, accs)
+		deposit := randomDeposit(r, ctx, m, acc.Address)
+		if deposit.IsZero() {
+			return simulation.Noop("gov/MsgDeposit", fmt.Sprintf("account %v has no %s to deposit", acc.Address, denom), event)
+		}
+
+		msg := gov.NewMsgDeposit(acc.Address, proposalID, deposit)
+		return simulation.RunMsg(ctx, handler, msg, "gov/MsgDeposit", event)
+	}
+}
+
+// SimulateMsgVote casts a random vote of a random account on a random
+// proposal
+func SimulateMsgVote(k gov.Keeper) simulation.Operation {
+	handler := gov.NewHandler(k)
+	options := []gov.VoteOption{gov.OptionYes, gov.OptionAbstain, gov.OptionNo, gov.OptionNoWithVeto}
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		proposalID, ok := randomProposalID(r, ctx, k)
+		if !ok {
+			return simulation.Noop("gov/MsgVote", "no proposals", event)
+		}
+		acc := simulation.RandomAcc(r, accs)
+
+		msg := gov.NewMsgVote(acc.Address, proposalID, options[r.Intn(len(options))])
+		return simulation.RunMsg(ctx, handler, msg, "gov/MsgVote", event)
+	}
+}
+
+//______________________________________________________________________
+
+// a random amount of the deposit denom of an account, no coins if it's zero
+func randomDeposit(r *rand.Rand, ctx sdk.Context, m auth.AccountMapper, addr sdk.Address) sdk.Coins {
+	acc := m.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.Coins{}
+	}
+	amount := simulation.RandomAmount(r, acc.GetCoins().AmountOf(denom))
+	if amount.IsZero() {
+		return sdk.Coins{}
+	}
+	return sdk.Coins{sdk.Coin{Denom: denom, Amount: amount}}
+}
+
+// the ID of a random submitted proposal, which may have been removed since
+func randomProposalID(r *rand.Rand, ctx sdk.Context, k gov.Keeper) (int64, bool) {
+	lastProposalID := k.GetLastProposalID(ctx)
+	if lastProposalID < 1 {
+		return 0, false
+	}
+	return 1 + r.Int63n(lastProposalID), true
+}
@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakesim "github.com/cosmos/cosmos-sdk/x/stake/simulation"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+func TestGovWithRandomMessages(t *testing.T) {
+	mapp := mock.NewApp()
+
+	bank.RegisterWire(mapp.Cdc)
+	stake.RegisterWire(mapp.Cdc)
+	gov.RegisterWire(mapp.Cdc)
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyGov := sdk.NewKVStoreKey("gov")
+	keyParams := sdk.NewKVStoreKey("params")
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
+	paramsKeeper := params.NewKeeper(mapp.Cdc, keyParams)
+	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
+	stakeKeeper := stake.NewKeeper(mapp.Cdc, keyStake, coinKeeper, paramsKeeper.Subspace(stake.DefaultParamspace), mapp.RegisterCodespace(stake.DefaultCodespace))
+	upgradeKeeper := upgrade.NewKeeper(mapp.Cdc, keyUpgrade, mapp.RegisterCodespace(upgrade.DefaultCodespace))
+	govKeeper := gov.NewKeeper(mapp.Cdc, keyGov, paramsKeeper, paramsKeeper.Subspace(gov.DefaultParamspace), coinKeeper, stakeKeeper, upgradeKeeper, mapp.RegisterCodespace(gov.DefaultCodespace))
+	mapp.Router().AddRoute("stake", stake.NewHandler(stakeKeeper))
+	mapp.Router().AddRoute("gov", gov.NewHandler(govKeeper))
+	mapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+		tags := gov.EndBlocker(ctx, govKeeper)
+		validatorUpdates, stakeTags := stake.EndBlocker(ctx, stakeKeeper)
+		tags = tags.AppendTags(stakeTags)
+		return abci.ResponseEndBlock{
+			ValidatorUpdates: validatorUpdates,
+			Tags:             tags,
+		}
+	})
+
+	// the loose tokens of the pool are the bond denom of the accounts
+	var looseTokens int64
+	mapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		mapp.InitChainer(ctx, req)
+		stakeGenesis := stake.DefaultGenesisState()
+		stakeGenesis.Pool.LooseTokens = looseTokens
+		stake.InitGenesis(ctx, stakeKeeper, stakeGenesis)
+		govGenesis := gov.DefaultGenesisState()
+		// short enough for proposals to end during the simulation
+		govGenesis.DepositProcedure.MaxDepositPeriod = 60 * 60
+		govGenesis.VotingProcedure.VotingPeriod = 60 * 60
+		gov.InitGenesis(ctx, govKeeper, govGenesis)
+		return abci.ResponseInitChain{}
+	})
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov, keyParams, keyUpgrade}))
+
+	appStateFn := func(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+		mapp.GenesisAccounts = simulation.RandomGenesisAccounts(r, accs, []string{denom}, 100)
+		for _, acc := range mapp.GenesisAccounts {
+			looseTokens += acc.GetCoins().AmountOf(denom).Int64()
+		}
+		return json.RawMessage("{}")
+	}
+
+	invariants := simulation.NewInvariants()
+	gov.RegisterInvariants(invariants, govKeeper)
+	stake.NewAppModule(stakeKeeper, mapp.AccountMapper, mapp.FeeCollectionKeeper.HeldTokens, govKeeper.HeldTokens).RegisterInvariants(invariants)
+
+	simulation.Simulate(
+		t, mapp.BaseApp, appStateFn,
+		[]simulation.WeightedOperation{
+			{Weight: 2, Op: stakesim.SimulateMsgCreateValidator(mapp.AccountMapper, stakeKeeper)},
+			{Weight: 2, Op: stakesim.SimulateMsgDelegate(mapp.AccountMapper, stakeKeeper)},
+			{Weight: 5, Op: SimulateMsgSubmitProposal(mapp.AccountMapper, govKeeper)},
+			{Weight: 10, Op: SimulateMsgDeposit(mapp.AccountMapper, govKeeper)},
+			{Weight: 10, Op: SimulateMsgVote(govKeeper)},
+		},
+		invariants, 100, 20,
+	)
+}
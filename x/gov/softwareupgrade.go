@@ -0,0 +1,33 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+//-----------------------------------------------------------
+// SoftwareUpgrade Proposals
+
+// SoftwareUpgradeProposal is a proposal which schedules its upgrade plan
+// when it passes
+type SoftwareUpgradeProposal struct {
+	TextProposal
+	Plan upgrade.Plan `json:"plan"` //  Upgrade scheduled if the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*SoftwareUpgradeProposal)(nil)
+
+// Creates a new SoftwareUpgradeProposal, its plan must have been validated
+func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, title string, description string, plan upgrade.Plan) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &SoftwareUpgradeProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeSoftwareUpgrade),
+		Plan:         plan,
+	}
+	keeper.submitProposal(ctx, proposal)
+	return proposal
+}
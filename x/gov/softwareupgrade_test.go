@@ -0,0 +1,77 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+func TestExecuteSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	plan := upgrade.NewPlan("v2", 100, 0, "info")
+	proposal := keeper.NewSoftwareUpgradeProposal(ctx, "Test", "description", plan)
+	require.Equal(t, ProposalTypeSoftwareUpgrade, proposal.GetProposalType())
+	require.Equal(t, plan, keeper.GetProposal(ctx, proposal.GetProposalID()).(*SoftwareUpgradeProposal).Plan)
+
+	tags := keeper.executeProposal(ctx, proposal)
+	require.Equal(t, sdk.NewTags("softwareUpgrade", []byte("scheduled")), tags)
+	stored, found := keeper.uk.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	// a plan which is due by the time the proposal passes is not scheduled
+	keeper.uk.ClearUpgradePlan(ctx)
+	tags = keeper.executeProposal(ctx.WithBlockHeight(100), proposal)
+	require.Equal(t, sdk.NewTags("softwareUpgrade", []byte("failed")), tags)
+	_, found = keeper.uk.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestSoftwareUpgradeProposalPasses(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription, dummyCommission)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+
+	// plans in the past are rejected on submission
+	ctx = ctx.WithBlockHeight(10)
+	plan := upgrade.NewPlan("v2", 10, 0, "info")
+	newProposalMsg := NewMsgSubmitSoftwareUpgradeProposal("Test", "test", plan, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.False(t, res.IsOK())
+
+	plan = upgrade.NewPlan("v2", 1000, 0, "info")
+	newProposalMsg = NewMsgSubmitSoftwareUpgradeProposal("Test", "test", plan, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Contains(t, tags, sdk.MakeTag("softwareUpgrade", []byte("scheduled")))
+	stored, found := keeper.uk.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+}
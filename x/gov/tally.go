@@ -13,7 +13,17 @@ type validatorGovInfo struct {
 	Vote            VoteOption  // Vote of the validator
 }
 
-func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonVoting []sdk.Address) {
+// TallyResult is the voting power of each vote option on a proposal
+type TallyResult struct {
+	Yes        sdk.Rat `json:"yes"`
+	Abstain    sdk.Rat `json:"abstain"`
+	No         sdk.Rat `json:"no"`
+	NoWithVeto sdk.Rat `json:"no_with_veto"`
+}
+
+// tallies the votes on a proposal, without modifying the store so that the
+// tally of a proposal in its voting period can be queried
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tallyResults TallyResult, nonVoting []sdk.Address) {
 	results := make(map[VoteOption]sdk.Rat)
 	results[OptionYes] = sdk.ZeroRat()
 	results[OptionAbstain] = sdk.ZeroRat()
@@ -22,8 +32,10 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 
 	totalVotingPower := sdk.ZeroRat()
 	currValidators := make(map[string]validatorGovInfo)
+	var bondedOwners []string // owners in the bonded order, to iterate deterministically
 
 	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+		bondedOwners = append(bondedOwners, validator.GetOwner().String())
 		currValidators[validator.GetOwner().String()] = validatorGovInfo{
 			Address:         validator.GetOwner(),
 			Power:           validator.GetPower(),
@@ -48,7 +60,11 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 		} else {
 
 			keeper.ds.IterateDelegations(ctx, vote.Voter, func(index int64, delegation sdk.Delegation) (stop bool) {
-				val := currValidators[delegation.GetValidator().String()]
+				// the delegations to validators which aren't bonded have no voting power
+				val, ok := currValidators[delegation.GetValidator().String()]
+				if !ok {
+					return false
+				}
 				val.Minus = val.Minus.Add(delegation.GetBondShares())
 				currValidators[delegation.GetValidator().String()] = val
 
@@ -61,14 +77,13 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 				return false
 			})
 		}
-
-		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
 	}
 	votesIterator.Close()
 
 	// Iterate over the validators again to tally their voting power and see who didn't vote
 	nonVoting = []sdk.Address{}
-	for _, val := range currValidators {
+	for _, owner := range bondedOwners {
+		val := currValidators[owner]
 		if val.Vote == OptionEmpty {
 			nonVoting = append(nonVoting, val.Address)
 			continue
@@ -81,20 +96,31 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 		totalVotingPower = totalVotingPower.Add(votingPower)
 	}
 
-	tallyingProcedure := keeper.GetTallyingProcedure()
+	tallyResults = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
+	}
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
 
+	// If less than the quorum of the bonded power votes, proposal fails
+	totalBondedPower := keeper.vs.TotalPower(ctx)
+	if totalBondedPower.Equal(sdk.ZeroRat()) || totalVotingPower.Quo(totalBondedPower).LT(tallyingProcedure.Quorum) {
+		return false, tallyResults, nonVoting
+	}
 	// If no one votes, proposal fails
 	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroRat()) {
-		return false, nonVoting
+		return false, tallyResults, nonVoting
 	}
 	// If more than 1/3 of voters veto, proposal fails
 	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
-		return false, nonVoting
+		return false, tallyResults, nonVoting
 	}
 	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
 	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingProcedure.Threshold) {
-		return true, nonVoting
+		return true, tallyResults, nonVoting
 	}
 	// If more than 1/2 of non-abstaining voters vote No, proposal fails
-	return false, nonVoting
+	return false, tallyResults, nonVoting
 }
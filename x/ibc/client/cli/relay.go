@@ -33,6 +33,9 @@ type relayCommander struct {
 	ibcStore  string
 	accStore  string
 
+	// trusted headers of the chains by node
+	headers map[string]context.HeaderSource
+
 	logger log.Logger
 }
 
@@ -82,6 +85,10 @@ func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
 		panic(err)
 	}
 	c.address = address
+	c.headers = map[string]context.HeaderSource{
+		fromChainNode: context.NewCertifiedHeaderSource(fromChainID, context.LiteDir(fromChainID), fromChainNode),
+		toChainNode:   context.NewCertifiedHeaderSource(toChainID, context.LiteDir(toChainID), toChainNode),
+	}
 
 	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
 }
@@ -102,7 +109,7 @@ OUTER:
 	for {
 		time.Sleep(5 * time.Second)
 
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
+		processedbz, err := c.query(toChainNode, ingressKey, c.ibcStore)
 		if err != nil {
 			panic(err)
 		}
@@ -115,7 +122,7 @@ OUTER:
 		}
 
 		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
+		egressLengthbz, err := c.query(fromChainNode, lengthKey, c.ibcStore)
 		if err != nil {
 			c.logger.Error("error querying outgoing packet list length", "err", err)
 			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
@@ -133,7 +140,7 @@ OUTER:
 		seq := c.getSequence(toChainNode)
 
 		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
+			egressbz, err := c.query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
 			if err != nil {
 				c.logger.Error("error querying egress packet", "err", err)
 				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
@@ -151,8 +158,8 @@ OUTER:
 	}
 }
 
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCoreContextFromViper().WithNodeURI(node).QueryStore(key, storeName)
+func (c relayCommander) query(node string, key []byte, storeName string) (res []byte, err error) {
+	return context.NewCoreContextFromViper().WithNodeURI(node).WithTrustedHeader(c.headers[node]).QueryStore(key, storeName)
 }
 
 func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
@@ -161,7 +168,7 @@ func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
 }
 
 func (c relayCommander) getSequence(node string) int64 {
-	res, err := query(node, c.address, c.accStore)
+	res, err := c.query(node, c.address, c.accStore)
 	if err != nil {
 		panic(err)
 	}
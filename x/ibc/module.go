@@ -0,0 +1,43 @@
+package ibc
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// name of the ibc module, used for its routes
+const ModuleName = "ibc"
+
+// AppModule implements module.AppModule for the ibc module,
+// which has no queries, no genesis state and no block hooks
+type AppModule struct {
+	mapper     Mapper
+	coinKeeper bank.Keeper
+}
+
+// NewAppModule creates a new AppModule
+func NewAppModule(mapper Mapper, coinKeeper bank.Keeper) AppModule {
+	return AppModule{mapper: mapper, coinKeeper: coinKeeper}
+}
+
+// nolint
+func (AppModule) Name() string                                       { return ModuleName }
+func (AppModule) Route() string                                      { return ModuleName }
+func (am AppModule) NewHandler() sdk.Handler                         { return NewHandler(am.mapper, am.coinKeeper) }
+func (AppModule) QuerierRoute() string                               { return "" }
+func (AppModule) NewQuerierHandler() sdk.Querier                     { return nil }
+func (AppModule) RegisterInvariants(_ sdk.InvariantRouter)           {}
+func (AppModule) DefaultGenesis() json.RawMessage                    { return nil }
+func (AppModule) ValidateGenesis(_ json.RawMessage) error            { return nil }
+func (AppModule) InitGenesis(_ sdk.Context, _ json.RawMessage) error { return nil }
+func (AppModule) ExportGenesis(_ sdk.Context) json.RawMessage        { return nil }
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return nil
+}
+func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return nil, nil
+}
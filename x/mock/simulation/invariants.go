@@ -0,0 +1,47 @@
+package simulation
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Invariants collects the invariants checked after every block of a
+// simulation. It implements sdk.InvariantRouter, so that modules register
+// their invariants with their RegisterInvariants function.
+type Invariants struct {
+	routes []string
+	invars []sdk.Invariant
+}
+
+// NewInvariants creates an empty set of invariants
+func NewInvariants() *Invariants {
+	return &Invariants{}
+}
+
+// RegisterRoute implements sdk.InvariantRouter
+func (invs *Invariants) RegisterRoute(moduleName, route string, invar sdk.Invariant) {
+	invs.routes = append(invs.routes, moduleName+"/"+route)
+	invs.invars = append(invs.invars, invar)
+}
+
+// Routes returns the routes of the registered invariants, in registration
+// order
+func (invs *Invariants) Routes() []string {
+	return invs.routes
+}
+
+// check all the invariants, on a cached context so that they can't modify
+// the state, and return the first broken one
+func (invs *Invariants) assertAll(ctx sdk.Context) error {
+	if invs == nil {
+		return nil
+	}
+	for i, invar := range invs.invars {
+		cacheCtx, _ := ctx.CacheContext()
+		if err := invar(cacheCtx); err != nil {
+			return fmt.Errorf("invariant %s broken: %v", invs.routes[i], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package simulation
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RunMsg runs a message generated by an operation through its handler, on
+// a cache of ctx: as for a transaction, the state is only written if the
+// message succeeds. The message must pass ValidateBasic, operations only
+// generate valid messages even if the state may not allow them. It returns
+// the action of the operation, and records the event name/ok or
+// name/failure.
+func RunMsg(ctx sdk.Context, handler sdk.Handler, msg sdk.Msg, name string, event func(string)) (action string, err error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return name, fmt.Errorf("%s generated an invalid message: %v", name, err.Error())
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	res := handler(cacheCtx, msg)
+	if !res.IsOK() {
+		event(name + "/failure")
+		return fmt.Sprintf("%s failure (%s): %s", name, res.Log, msg.GetSignBytes()), nil
+	}
+	write()
+	event(name + "/ok")
+	return fmt.Sprintf("%s ok: %s", name, msg.GetSignBytes()), nil
+}
+
+// Noop is the action of an operation which found nothing to do in the
+// current state, it records the event name/noop
+func Noop(name, reason string, event func(string)) (action string, err error) {
+	event(name + "/noop")
+	return fmt.Sprintf("%s noop: %s", name, reason), nil
+}
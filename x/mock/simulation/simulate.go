@@ -0,0 +1,202 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+const (
+	// number of random accounts of a simulation
+	numAccounts = 20
+
+	// maximum time between two blocks, in seconds
+	maxTimePerBlock = 60
+
+	// probability that a validator misses the signature of a block
+	missedSignatureProbability = 0.05
+
+	// probability that a block contains evidence of a double sign
+	evidenceProbability = 0.01
+)
+
+// Simulate runs a simulation from a random seed, see SimulateFromSeed. The
+// seed is logged, a failing simulation can be reproduced from it.
+func Simulate(t *testing.T, app *baseapp.BaseApp, appStateFn AppStateFn,
+	ops []WeightedOperation, invariants *Invariants, numBlocks, blockSize int) {
+
+	SimulateFromSeed(t, app, appStateFn, time.Now().UnixNano(), ops, invariants, numBlocks, blockSize)
+}
+
+// SimulateFromSeed runs numBlocks blocks of blockSize random operations
+// each, chosen by weight. The begin block requests simulate validators
+// missing signatures and double signing. All the invariants are checked
+// after every block. The simulation is deterministic, the same seed gives
+// the same blocks, and a failure reports the seed and the operations of the
+// failing block.
+func SimulateFromSeed(t *testing.T, app *baseapp.BaseApp, appStateFn AppStateFn, seed int64,
+	ops []WeightedOperation, invariants *Invariants, numBlocks, blockSize int) {
+
+	t.Logf("Starting the simulation from seed %d", seed)
+	r := rand.New(rand.NewSource(seed))
+	selectOp := weightedOperationSelector(ops)
+
+	events := make(map[string]uint)
+	event := func(what string) {
+		events[what]++
+	}
+
+	// the header and operations of the current block, reported on failures
+	header := abci.Header{}
+	var log []string
+	failure := func(err interface{}) string {
+		return fmt.Sprintf("simulation from seed %d failed at height %d: %v\noperations of the block:\n%s",
+			seed, header.Height, err, strings.Join(log, "\n"))
+	}
+
+	// also report the seed if the app panics
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(failure(err))
+			panic(err)
+		}
+	}()
+
+	accs := RandomAccounts(r, numAccounts)
+	app.InitChain(abci.RequestInitChain{AppStateBytes: appStateFn(r, accs)})
+
+	// the validator set, as reported by the app, keyed by pubkey
+	validators := make(map[string]abci.Validator)
+	// time of every block, by height - 1
+	blockTimes := make([]int64, 0, numBlocks)
+
+	for height := int64(1); height <= int64(numBlocks); height++ {
+		header.Height = height
+		header.Time += 1 + r.Int63n(maxTimePerBlock)
+		blockTimes = append(blockTimes, header.Time)
+		log = nil
+
+		app.BeginBlock(RandomRequestBeginBlock(r, validators, blockTimes, header, event))
+
+		ctx := app.NewContext(false, header)
+		for i := 0; i < blockSize; i++ {
+			action, err := selectOp(r)(r, app, ctx, accs, event)
+			log = append(log, action)
+			if err != nil {
+				t.Fatal(failure(err))
+			}
+		}
+
+		res := app.EndBlock(abci.RequestEndBlock{Height: height})
+		updateValidators(validators, res.ValidatorUpdates, event)
+
+		if err := invariants.assertAll(app.NewContext(false, header)); err != nil {
+			t.Fatal(failure(err))
+		}
+		app.Commit()
+	}
+
+	t.Logf("Simulation from seed %d ran %d blocks of %d operations\n%s",
+		seed, numBlocks, blockSize, formatEvents(events))
+}
+
+// RandomRequestBeginBlock generates a begin block request for the given
+// validator set. Every validator misses its signature with
+// missedSignatureProbability and, with evidenceProbability, a random
+// validator double signed a random past block.
+func RandomRequestBeginBlock(r *rand.Rand, validators map[string]abci.Validator,
+	blockTimes []int64, header abci.Header, event func(string)) abci.RequestBeginBlock {
+
+	keys := sortedKeys(validators)
+	signingValidators := make([]abci.SigningValidator, len(keys))
+	for i, key := range keys {
+		signed := r.Float64() >= missedSignatureProbability
+		if signed {
+			event("beginblock/signature/signed")
+		} else {
+			event("beginblock/signature/missed")
+		}
+		signingValidators[i] = abci.SigningValidator{
+			Validator:       validators[key],
+			SignedLastBlock: signed,
+		}
+	}
+
+	var evidence []abci.Evidence
+	if len(keys) > 0 && r.Float64() < evidenceProbability {
+		height := 1 + r.Int63n(header.Height)
+		evidence = append(evidence, abci.Evidence{
+			Type:      tmtypes.ABCIEvidenceTypeDuplicateVote,
+			Validator: validators[keys[r.Intn(len(keys))]],
+			Height:    height,
+			Time:      blockTimes[height-1],
+		})
+		event("beginblock/evidence")
+	}
+
+	return abci.RequestBeginBlock{
+		Header:              header,
+		Validators:          signingValidators,
+		ByzantineValidators: evidence,
+	}
+}
+
+// apply the validator updates of an end block to the validator set
+func updateValidators(validators map[string]abci.Validator, updates []abci.Validator, event func(string)) {
+	for _, update := range updates {
+		key := string(update.PubKey.Data)
+		if update.Power == 0 {
+			delete(validators, key)
+			event("endblock/validatorupdates/removed")
+			continue
+		}
+		validators[key] = update
+		event("endblock/validatorupdates/updated")
+	}
+}
+
+// returns a function choosing an operation with a probability proportional
+// to its weight
+func weightedOperationSelector(ops []WeightedOperation) func(r *rand.Rand) Operation {
+	totalWeight := 0
+	for _, op := range ops {
+		totalWeight += op.Weight
+	}
+	if totalWeight <= 0 {
+		panic("the simulated operations must have a positive total weight")
+	}
+
+	return func(r *rand.Rand) Operation {
+		x := r.Intn(totalWeight)
+		for _, op := range ops {
+			if x < op.Weight {
+				return op.Op
+			}
+			x -= op.Weight
+		}
+		panic("unreachable")
+	}
+}
+
+// format the event counts, sorted by event
+func formatEvents(events map[string]uint) string {
+	names := make([]string, 0, len(events))
+	for name := range events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%s: %d", name, events[name])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,100 @@
+package simulation
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+)
+
+func TestSimulateFromSeed(t *testing.T) {
+	mapp := mock.NewApp()
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{}))
+
+	appStateFn := func(r *rand.Rand, accs []Account) json.RawMessage {
+		require.Equal(t, numAccounts, len(accs))
+		return json.RawMessage("{}")
+	}
+
+	var actions []string
+	op := func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []Account, event func(string)) (string, error) {
+		action := RandomAcc(r, accs).Address.String()
+		actions = append(actions, action)
+		event("test")
+		return action, nil
+	}
+
+	checks := 0
+	invariants := NewInvariants()
+	invariants.RegisterRoute("test", "count", func(_ sdk.Context) error {
+		checks++
+		return nil
+	})
+	require.Equal(t, []string{"test/count"}, invariants.Routes())
+
+	SimulateFromSeed(t, mapp.BaseApp, appStateFn, 42, []WeightedOperation{{1, op}}, invariants, 5, 3)
+	require.Equal(t, 15, len(actions))
+	require.Equal(t, 5, checks)
+
+	// the same seed gives the same operations
+	first := actions
+	actions = nil
+	mapp = mock.NewApp()
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{}))
+	SimulateFromSeed(t, mapp.BaseApp, appStateFn, 42, []WeightedOperation{{1, op}}, invariants, 5, 3)
+	require.Equal(t, first, actions)
+}
+
+func TestRandomRequestBeginBlock(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	validators := make(map[string]abci.Validator)
+	updateValidators(validators, []abci.Validator{
+		{PubKey: abci.PubKey{Data: []byte("a")}, Power: 10},
+		{PubKey: abci.PubKey{Data: []byte("b")}, Power: 10},
+		{PubKey: abci.PubKey{Data: []byte("c")}, Power: 10},
+	}, func(string) {})
+	updateValidators(validators, []abci.Validator{
+		{PubKey: abci.PubKey{Data: []byte("b")}, Power: 0},
+	}, func(string) {})
+	require.Equal(t, 2, len(validators))
+
+	header := abci.Header{Height: 3, Time: 30}
+	blockTimes := []int64{10, 20, 30}
+	for i := 0; i < 100; i++ {
+		req := RandomRequestBeginBlock(r, validators, blockTimes, header, func(string) {})
+		require.Equal(t, header, req.Header)
+
+		// validators are in a deterministic order
+		require.Equal(t, 2, len(req.Validators))
+		require.Equal(t, []byte("a"), req.Validators[0].Validator.PubKey.Data)
+		require.Equal(t, []byte("c"), req.Validators[1].Validator.PubKey.Data)
+
+		for _, evidence := range req.ByzantineValidators {
+			require.True(t, evidence.Height >= 1 && evidence.Height <= header.Height)
+			require.Equal(t, blockTimes[evidence.Height-1], evidence.Time)
+		}
+	}
+}
+
+func TestRandomAmount(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	require.True(t, RandomAmount(r, sdk.ZeroInt()).IsZero())
+
+	max := sdk.NewInt(10)
+	for i := 0; i < 100; i++ {
+		amt := RandomAmount(r, max)
+		require.False(t, amt.LT(sdk.ZeroInt()))
+		require.False(t, amt.GT(max))
+	}
+
+	// the same seed gives the same accounts
+	accs := RandomAccounts(rand.New(rand.NewSource(1)), 2)
+	require.Equal(t, accs, RandomAccounts(rand.New(rand.NewSource(1)), 2))
+	require.NotEqual(t, accs[0].Address, accs[1].Address)
+}
@@ -0,0 +1,43 @@
+// Package simulation runs randomized, seed-driven simulations of an app:
+// blocks of random operations of the modules, with missed signatures and
+// double sign evidence, checking the registered invariants after every
+// block.
+package simulation
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	// Operation runs a random state transition, typically a message of a
+	// module, on the given context. It returns a description of the action
+	// for the simulation log. Operations fail gracefully on random inputs
+	// the state doesn't allow, an error is only returned when the state
+	// transition went wrong, and fails the simulation.
+	Operation func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []Account, event func(string)) (action string, err error)
+
+	// AppStateFn returns the genesis app state of a simulation, for the
+	// given random accounts
+	AppStateFn func(r *rand.Rand, accs []Account) json.RawMessage
+)
+
+// WeightedOperation is an operation which is chosen with a probability
+// proportional to its weight
+type WeightedOperation struct {
+	Weight int
+	Op     Operation
+}
+
+// Account of a simulation, with its keys
+type Account struct {
+	PrivKey crypto.PrivKey
+	PubKey  crypto.PubKey
+	Address sdk.Address
+}
@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// RandStringOfLength generates a random string of a given length
+func RandStringOfLength(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// RandomAccounts generates n random accounts, their keys are derived from
+// the random source so that a seed always gives the same accounts
+func RandomAccounts(r *rand.Rand, n int) []Account {
+	accs := make([]Account, n)
+	for i := 0; i < n; i++ {
+		secret := make([]byte, 32)
+		r.Read(secret)
+		privKey := crypto.GenPrivKeyEd25519FromSecret(secret)
+		accs[i] = Account{
+			PrivKey: privKey,
+			PubKey:  privKey.PubKey(),
+			Address: privKey.PubKey().Address(),
+		}
+	}
+	return accs
+}
+
+// RandomAcc picks a random account
+func RandomAcc(r *rand.Rand, accs []Account) Account {
+	return accs[r.Intn(len(accs))]
+}
+
+// RandomAmount generates a random amount in [0, max], zero if max isn't
+// positive
+func RandomAmount(r *rand.Rand, max sdk.Int) sdk.Int {
+	if !max.GT(sdk.ZeroInt()) {
+		return sdk.ZeroInt()
+	}
+	bound := new(big.Int).Add(max.BigInt(), big.NewInt(1))
+	return sdk.NewIntFromBigInt(new(big.Int).Rand(r, bound))
+}
+
+// sort the keys of a map, so that iterating over it is deterministic
+func sortedKeys(m map[string]abci.Validator) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RandomGenesisAccounts creates the genesis accounts of the simulation
+// accounts, with a random amount in [1, maxAmount] of each denom
+func RandomGenesisAccounts(r *rand.Rand, accs []Account, denoms []string, maxAmount int64) []auth.Account {
+	genAccs := make([]auth.Account, len(accs))
+	for i, acc := range accs {
+		var coins sdk.Coins
+		for _, denom := range denoms {
+			coins = append(coins, sdk.NewCoin(denom, 1+r.Int63n(maxAmount)))
+		}
+		genAccs[i] = &auth.BaseAccount{
+			Address: acc.Address,
+			Coins:   coins.Sort(),
+		}
+	}
+	return genAccs
+}
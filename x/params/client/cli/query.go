@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// get the command to query a parameter of a module
+func GetCmdQueryParam(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "param [subspace] [key]",
+		Short: "Query a parameter of a module, e.g. param stake MaxValidators",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			queryParams := params.QueryParamParams{Subspace: args[0], Key: args[1]}
+			bz, err := cdc.MarshalJSON(queryParams)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			path := fmt.Sprintf("custom/%s/%s", queryRoute, params.QueryParam)
+			res, err := ctx.QueryWithData(path, bz)
+			if err != nil {
+				return err
+			}
+
+			// parameters are stored as JSON
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// RegisterRoutes registers the params query routes
+func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, queryRoute string) {
+	r.HandleFunc(
+		"/params/{subspace}/{key}",
+		paramHandlerFn(ctx, cdc, queryRoute),
+	).Methods("GET")
+}
+
+// http request handler to query a parameter of a module
+func paramHandlerFn(ctx context.CoreContext, cdc *wire.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		queryParams := params.QueryParamParams{Subspace: vars["subspace"], Key: vars["key"]}
+		bz, err := cdc.MarshalJSON(queryParams)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		path := fmt.Sprintf("custom/%s/%s", queryRoute, params.QueryParam)
+		res, err := ctx.QueryWithData(path, bz)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf("couldn't query parameter. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(res)
+	}
+}
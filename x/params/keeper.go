@@ -0,0 +1,47 @@
+package params
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Keeper of the global parameter store. The parameters of each module live
+// in their own Subspace, allocated once with Subspace.
+type Keeper struct {
+	cdc *wire.Codec
+	key sdk.StoreKey
+
+	spaces map[string]Subspace // shared by the copies of the keeper
+}
+
+// NewKeeper creates a new params Keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		cdc:    cdc,
+		key:    key,
+		spaces: make(map[string]Subspace),
+	}
+}
+
+// Subspace allocates the subspace of a module, it panics if the name is
+// empty or already allocated
+func (k Keeper) Subspace(name string) Subspace {
+	if name == "" {
+		panic("empty subspace name")
+	}
+	if _, ok := k.spaces[name]; ok {
+		panic(fmt.Sprintf("subspace %s already allocated", name))
+	}
+
+	space := NewSubspace(k.cdc, k.key, name)
+	k.spaces[name] = space
+	return space
+}
+
+// GetSubspace returns an allocated subspace
+func (k Keeper) GetSubspace(name string) (Subspace, bool) {
+	space, ok := k.spaces[name]
+	return space, ok
+}
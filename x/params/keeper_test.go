@@ -0,0 +1,113 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func defaultContext(key sdk.StoreKey) sdk.Context {
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	cms.LoadLatestVersion()
+	return sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+}
+
+// test parameter set
+type testParams struct {
+	Window   int64
+	Fraction sdk.Rat
+	Denom    string
+}
+
+var (
+	keyWindow   = []byte("Window")
+	keyFraction = []byte("Fraction")
+	keyDenom    = []byte("Denom")
+)
+
+func (p *testParams) KeyValuePairs() KeyValuePairs {
+	return KeyValuePairs{
+		{Key: keyWindow, Value: &p.Window},
+		{Key: keyFraction, Value: &p.Fraction},
+		{Key: keyDenom, Value: &p.Denom},
+	}
+}
+
+func testKeyTable() KeyTable {
+	return NewKeyTable().RegisterParamSet(&testParams{})
+}
+
+func TestKeeperSubspace(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := defaultContext(key)
+	keeper := NewKeeper(wire.NewCodec(), key)
+
+	space1 := keeper.Subspace("space1").WithKeyTable(testKeyTable())
+	space2 := keeper.Subspace("space2").WithKeyTable(testKeyTable())
+	require.Equal(t, "space1", space1.Name())
+
+	// subspaces are allocated once
+	require.Panics(t, func() { keeper.Subspace("space1") })
+	require.Panics(t, func() { keeper.Subspace("") })
+
+	// the keeper returns the allocated subspaces
+	_, ok := keeper.GetSubspace("space3")
+	require.False(t, ok)
+	space, ok := keeper.GetSubspace("space1")
+	require.True(t, ok)
+	require.Equal(t, "space1", space.Name())
+
+	// the subspaces don't share their parameters
+	space1.Set(ctx, keyWindow, int64(10))
+	space2.Set(ctx, keyWindow, int64(20))
+	var window int64
+	space1.Get(ctx, keyWindow, &window)
+	require.Equal(t, int64(10), window)
+	space2.Get(ctx, keyWindow, &window)
+	require.Equal(t, int64(20), window)
+
+	// the subspace of the keeper shares the key table of the module one
+	var window2 int64
+	space.Get(ctx, keyWindow, &window2)
+	require.Equal(t, int64(10), window2)
+	require.Nil(t, space.Update(ctx, keyWindow, []byte(`"30"`)))
+	space1.Get(ctx, keyWindow, &window)
+	require.Equal(t, int64(30), window)
+}
+
+func TestQuerier(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := defaultContext(key)
+	cdc := wire.NewCodec()
+	keeper := NewKeeper(cdc, key)
+	space := keeper.Subspace("space").WithKeyTable(testKeyTable())
+	space.Set(ctx, keyDenom, "steak")
+	querier := NewQuerier(keeper)
+
+	query := func(subspace, key string) ([]byte, sdk.Error) {
+		bz, err := cdc.MarshalJSON(QueryParamParams{Subspace: subspace, Key: key})
+		require.Nil(t, err)
+		return querier(ctx, []string{QueryParam}, abci.RequestQuery{Data: bz})
+	}
+
+	res, err := query("space", "Denom")
+	require.Nil(t, err)
+	require.Equal(t, `"steak"`, string(res))
+
+	_, err = query("space", "Window")
+	require.NotNil(t, err)
+	_, err = query("other", "Denom")
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
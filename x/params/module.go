@@ -0,0 +1,42 @@
+package params
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name of the params module, used for its query route
+const ModuleName = "params"
+
+// AppModule implements module.AppModule for the params module. The
+// parameters are set in the genesis of the modules owning them, so the
+// params module only serves queries.
+type AppModule struct {
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// nolint
+func (AppModule) Name() string                                       { return ModuleName }
+func (AppModule) Route() string                                      { return "" }
+func (AppModule) NewHandler() sdk.Handler                            { return nil }
+func (AppModule) QuerierRoute() string                               { return ModuleName }
+func (am AppModule) NewQuerierHandler() sdk.Querier                  { return NewQuerier(am.keeper) }
+func (AppModule) RegisterInvariants(_ sdk.InvariantRouter)           {}
+func (AppModule) DefaultGenesis() json.RawMessage                    { return nil }
+func (AppModule) ValidateGenesis(_ json.RawMessage) error            { return nil }
+func (AppModule) InitGenesis(_ sdk.Context, _ json.RawMessage) error { return nil }
+func (AppModule) ExportGenesis(_ sdk.Context) json.RawMessage        { return nil }
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return nil
+}
+func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return nil, nil
+}
@@ -0,0 +1,18 @@
+package params
+
+// KeyValuePair links a parameter key to a pointer to the field holding its
+// value
+type KeyValuePair struct {
+	Key   []byte
+	Value interface{}
+}
+
+// KeyValuePairs of a parameter set
+type KeyValuePairs []KeyValuePair
+
+// ParamSet is a struct of parameters, each field is stored under its own
+// key in the subspace of the module, see Subspace.GetParamSet and
+// Subspace.SetParamSet
+type ParamSet interface {
+	KeyValuePairs() KeyValuePairs
+}
@@ -0,0 +1,53 @@
+package params
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the params Querier
+const (
+	QueryParam = "param"
+)
+
+// Params for query 'custom/params/param'
+type QueryParamParams struct {
+	Subspace string
+	Key      string
+}
+
+// NewQuerier returns the querier of the parameter store, it returns the
+// JSON of a parameter
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no params query endpoint specified")
+		}
+		switch path[0] {
+		case QueryParam:
+			return queryParam(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown params query endpoint %s", path[0]))
+		}
+	}
+}
+
+func queryParam(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryParamParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	space, ok := k.GetSubspace(params.Subspace)
+	if !ok {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown subspace %s", params.Subspace))
+	}
+	res = space.GetRaw(ctx, []byte(params.Key))
+	if res == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("parameter %s not set in subspace %s", params.Key, params.Subspace))
+	}
+	return res, nil
+}
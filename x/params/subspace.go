@@ -0,0 +1,170 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Subspace of the parameter store, holding the parameters of a module.
+// Its keys are prefixed with its name, and the parameters are stored as
+// JSON. The types of the parameters must be registered in its KeyTable.
+type Subspace struct {
+	cdc    *wire.Codec
+	key    sdk.StoreKey
+	name   string
+	prefix []byte
+
+	table KeyTable // shared by the copies of the subspace
+}
+
+// NewSubspace creates a new Subspace, apps get theirs from Keeper.Subspace
+func NewSubspace(cdc *wire.Codec, key sdk.StoreKey, name string) Subspace {
+	return Subspace{
+		cdc:    cdc,
+		key:    key,
+		name:   name,
+		prefix: []byte(name + "/"),
+		table:  NewKeyTable(),
+	}
+}
+
+// Name of the subspace
+func (s Subspace) Name() string {
+	return s.name
+}
+
+// WithKeyTable registers the types of the parameters of the subspace and
+// the validations of their values. They are shared by all the copies of
+// the subspace, including the one of the Keeper.
+func (s Subspace) WithKeyTable(table KeyTable) Subspace {
+	for key, ty := range table.m {
+		if _, ok := s.table.m[key]; ok {
+			panic(fmt.Sprintf("parameter %s already registered in subspace %s", key, s.name))
+		}
+		s.table.m[key] = ty
+	}
+	*s.table.validations = append(*s.table.validations, *table.validations...)
+	return s
+}
+
+func (s Subspace) kvStore(ctx sdk.Context) sdk.KVStore {
+	return ctx.KVStore(s.key).Prefix(s.prefix)
+}
+
+// Get reads a parameter into ptr, it panics if the parameter isn't set
+func (s Subspace) Get(ctx sdk.Context, key []byte, ptr interface{}) {
+	bz := s.kvStore(ctx).Get(key)
+	if bz == nil {
+		panic(fmt.Sprintf("parameter %s not set in subspace %s", key, s.name))
+	}
+	if err := s.cdc.UnmarshalJSON(bz, ptr); err != nil {
+		panic(err)
+	}
+}
+
+// GetIfExists reads a parameter into ptr if it is set, and leaves ptr
+// untouched otherwise
+func (s Subspace) GetIfExists(ctx sdk.Context, key []byte, ptr interface{}) {
+	bz := s.kvStore(ctx).Get(key)
+	if bz == nil {
+		return
+	}
+	if err := s.cdc.UnmarshalJSON(bz, ptr); err != nil {
+		panic(err)
+	}
+}
+
+// GetRaw returns the JSON of a parameter, nil if it isn't set
+func (s Subspace) GetRaw(ctx sdk.Context, key []byte) []byte {
+	return s.kvStore(ctx).Get(key)
+}
+
+// Has returns whether a parameter is set
+func (s Subspace) Has(ctx sdk.Context, key []byte) bool {
+	return s.kvStore(ctx).Has(key)
+}
+
+// Set sets a parameter, it panics if the type of the parameter isn't the
+// registered one
+func (s Subspace) Set(ctx sdk.Context, key []byte, param interface{}) {
+	s.checkType(key, param)
+
+	bz, err := s.cdc.MarshalJSON(param)
+	if err != nil {
+		panic(err)
+	}
+	s.kvStore(ctx).Set(key, bz)
+}
+
+// Update sets a parameter from its JSON value, for changes coming from
+// outside the app like governance. It returns an error if the parameter
+// isn't registered or the value isn't of its type.
+func (s Subspace) Update(ctx sdk.Context, key []byte, value []byte) error {
+	ptr, err := s.decode(key, value)
+	if err != nil {
+		return err
+	}
+	s.Set(ctx, key, ptr)
+	return nil
+}
+
+// Validate checks that an Update of the parameter with the JSON value
+// would succeed, without setting it. The value itself is checked with
+// ValidateParams once updated.
+func (s Subspace) Validate(key []byte, value []byte) error {
+	_, err := s.decode(key, value)
+	return err
+}
+
+// ValidateParams runs the registered validations on the values of the
+// parameters, after Updates which must be reverted if it fails
+func (s Subspace) ValidateParams(ctx sdk.Context) error {
+	for _, validate := range *s.table.validations {
+		if err := validate(ctx, s); err != nil {
+			return fmt.Errorf("invalid parameters of subspace %s: %v", s.name, err)
+		}
+	}
+	return nil
+}
+
+// decode the JSON value of a parameter into a pointer to its type
+func (s Subspace) decode(key []byte, value []byte) (interface{}, error) {
+	ty, ok := s.table.m[string(key)]
+	if !ok {
+		return nil, fmt.Errorf("parameter %s not registered in subspace %s", key, s.name)
+	}
+
+	ptr := reflect.New(ty).Interface()
+	if err := s.cdc.UnmarshalJSON(value, ptr); err != nil {
+		return nil, fmt.Errorf("invalid value for parameter %s of subspace %s: %v", key, s.name, err)
+	}
+	return ptr, nil
+}
+
+// GetParamSet reads all the parameters of a set, it panics if one isn't set
+func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
+	for _, kvp := range ps.KeyValuePairs() {
+		s.Get(ctx, kvp.Key, kvp.Value)
+	}
+}
+
+// SetParamSet sets all the parameters of a set
+func (s Subspace) SetParamSet(ctx sdk.Context, ps ParamSet) {
+	for _, kvp := range ps.KeyValuePairs() {
+		s.Set(ctx, kvp.Key, kvp.Value)
+	}
+}
+
+// panics if the type of param isn't the one registered for key
+func (s Subspace) checkType(key []byte, param interface{}) {
+	ty, ok := s.table.m[string(key)]
+	if !ok {
+		panic(fmt.Sprintf("parameter %s not registered in subspace %s", key, s.name))
+	}
+	if pty := indirectType(param); pty != ty {
+		panic(fmt.Sprintf("type mismatch for parameter %s of subspace %s: expected %v, got %v", key, s.name, ty, pty))
+	}
+}
@@ -0,0 +1,122 @@
+package params
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func TestSubspaceGetSet(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := defaultContext(key)
+	space := NewSubspace(wire.NewCodec(), key, "space").WithKeyTable(testKeyTable())
+
+	// unset parameters
+	var window int64
+	require.False(t, space.Has(ctx, keyWindow))
+	require.Nil(t, space.GetRaw(ctx, keyWindow))
+	require.Panics(t, func() { space.Get(ctx, keyWindow, &window) })
+	window = 5
+	space.GetIfExists(ctx, keyWindow, &window)
+	require.Equal(t, int64(5), window)
+
+	// values and pointers to values can be set
+	space.Set(ctx, keyWindow, int64(10))
+	fraction := sdk.NewRat(1, 3)
+	space.Set(ctx, keyFraction, &fraction)
+	require.True(t, space.Has(ctx, keyWindow))
+	space.Get(ctx, keyWindow, &window)
+	require.Equal(t, int64(10), window)
+	var gotFraction sdk.Rat
+	space.GetIfExists(ctx, keyFraction, &gotFraction)
+	require.True(t, fraction.Equal(gotFraction))
+
+	// parameters must be registered with their type
+	require.Panics(t, func() { space.Set(ctx, keyWindow, "10") })
+	require.Panics(t, func() { space.Set(ctx, []byte("unknown"), int64(10)) })
+}
+
+func TestSubspaceUpdate(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := defaultContext(key)
+	space := NewSubspace(wire.NewCodec(), key, "space").WithKeyTable(testKeyTable())
+	space.Set(ctx, keyDenom, "steak")
+
+	require.Nil(t, space.Validate(keyDenom, []byte(`"atom"`)))
+	require.NotNil(t, space.Validate(keyDenom, []byte(`10`)))
+	require.NotNil(t, space.Validate([]byte("unknown"), []byte(`"atom"`)))
+
+	require.Nil(t, space.Update(ctx, keyDenom, []byte(`"atom"`)))
+	var denom string
+	space.Get(ctx, keyDenom, &denom)
+	require.Equal(t, "atom", denom)
+
+	// invalid values and unknown parameters are rejected
+	require.NotNil(t, space.Update(ctx, keyDenom, []byte(`10`)))
+	require.NotNil(t, space.Update(ctx, []byte("unknown"), []byte(`"atom"`)))
+	space.Get(ctx, keyDenom, &denom)
+	require.Equal(t, "atom", denom)
+}
+
+func TestSubspaceValidateParams(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := defaultContext(key)
+	table := testKeyTable().RegisterValidation(func(ctx sdk.Context, space Subspace) error {
+		var params testParams
+		space.GetParamSet(ctx, &params)
+		if params.Window <= 0 {
+			return fmt.Errorf("window must be positive")
+		}
+		return nil
+	})
+	space := NewSubspace(wire.NewCodec(), key, "space").WithKeyTable(table)
+	space.SetParamSet(ctx, &testParams{Window: 10, Fraction: sdk.OneRat(), Denom: "steak"})
+	require.Nil(t, space.ValidateParams(ctx))
+
+	// the values are checked once updated, the update is up to the caller
+	// to revert
+	cacheCtx, _ := ctx.CacheContext()
+	require.Nil(t, space.Validate(keyWindow, []byte(`"0"`)))
+	require.Nil(t, space.Update(cacheCtx, keyWindow, []byte(`"0"`)))
+	require.NotNil(t, space.ValidateParams(cacheCtx))
+	require.Nil(t, space.ValidateParams(ctx))
+
+	// the copies of the subspace share the validations
+	keeper := NewKeeper(wire.NewCodec(), key)
+	keeper.Subspace("other").WithKeyTable(table)
+	other, _ := keeper.GetSubspace("other")
+	other.SetParamSet(ctx, &testParams{Window: 0, Fraction: sdk.OneRat(), Denom: "steak"})
+	require.NotNil(t, other.ValidateParams(ctx))
+}
+
+func TestSubspaceParamSet(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := defaultContext(key)
+	space := NewSubspace(wire.NewCodec(), key, "space").WithKeyTable(testKeyTable())
+
+	params := testParams{Window: 100, Fraction: sdk.NewRat(1, 2), Denom: "steak"}
+	space.SetParamSet(ctx, &params)
+
+	var got testParams
+	space.GetParamSet(ctx, &got)
+	require.Equal(t, params.Window, got.Window)
+	require.True(t, params.Fraction.Equal(got.Fraction))
+	require.Equal(t, params.Denom, got.Denom)
+
+	// each parameter is stored under its own key
+	require.Equal(t, `"steak"`, string(space.GetRaw(ctx, keyDenom)))
+}
+
+func TestKeyTable(t *testing.T) {
+	table := NewKeyTable().RegisterType(keyWindow, int64(0))
+	require.Panics(t, func() { table.RegisterType(keyWindow, int64(0)) })
+	require.Panics(t, func() { table.RegisterType([]byte{}, int64(0)) })
+
+	// a subspace can't register the same parameter twice
+	space := NewSubspace(wire.NewCodec(), sdk.NewKVStoreKey("params"), "space").WithKeyTable(table)
+	require.Panics(t, func() { space.WithKeyTable(testKeyTable()) })
+}
@@ -0,0 +1,65 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeyTable registers the type of every parameter of a subspace, and the
+// validations of their values
+type KeyTable struct {
+	m           map[string]reflect.Type
+	validations *[]ValidateFn
+}
+
+// ValidateFn checks the values of the parameters of a subspace read from
+// the context, including the rules between several parameters
+type ValidateFn func(ctx sdk.Context, space Subspace) error
+
+// NewKeyTable creates an empty KeyTable
+func NewKeyTable() KeyTable {
+	return KeyTable{
+		m:           make(map[string]reflect.Type),
+		validations: &[]ValidateFn{},
+	}
+}
+
+// RegisterType registers the type of the parameter stored under key, it
+// panics if the key is empty or already registered
+func (t KeyTable) RegisterType(key []byte, ty interface{}) KeyTable {
+	if len(key) == 0 {
+		panic("empty parameter key")
+	}
+	if _, ok := t.m[string(key)]; ok {
+		panic(fmt.Sprintf("parameter %s already registered", key))
+	}
+
+	t.m[string(key)] = indirectType(ty)
+	return t
+}
+
+// RegisterValidation registers a validation of the values of the
+// parameters, see Subspace.ValidateParams
+func (t KeyTable) RegisterValidation(fn ValidateFn) KeyTable {
+	*t.validations = append(*t.validations, fn)
+	return t
+}
+
+// RegisterParamSet registers the types of all the parameters of a set
+func (t KeyTable) RegisterParamSet(ps ParamSet) KeyTable {
+	for _, kvp := range ps.KeyValuePairs() {
+		t = t.RegisterType(kvp.Key, kvp.Value)
+	}
+	return t
+}
+
+// the type of a value, or of the value it points to
+func indirectType(value interface{}) reflect.Type {
+	ty := reflect.TypeOf(value)
+	if ty.Kind() == reflect.Ptr {
+		return ty.Elem()
+	}
+	return ty
+}
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"io/ioutil"
+
 	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -36,3 +40,46 @@ func GetCmdUnrevoke(cdc *wire.Codec) *cobra.Command {
 	}
 	return cmd
 }
+
+// create submit evidence command, the evidence file holds the pubkey of the
+// validator and its two conflicting votes as JSON:
+// {"pub_key": ..., "vote_a": ..., "vote_b": ...}
+func GetCmdSubmitEvidence(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-evidence [evidence-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "submit evidence of a validator signing conflicting votes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			submitter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var evidence struct {
+				PubKey crypto.PubKey `json:"pub_key"`
+				VoteA  *tmtypes.Vote `json:"vote_a"`
+				VoteB  *tmtypes.Vote `json:"vote_b"`
+			}
+			err = cdc.UnmarshalJSON(bz, &evidence)
+			if err != nil {
+				return err
+			}
+
+			msg := slashing.NewMsgSubmitEvidence(submitter, evidence.PubKey, evidence.VoteA, evidence.VoteB)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -15,6 +15,10 @@ const (
 	CodeInvalidValidator    CodeType = 101
 	CodeValidatorJailed     CodeType = 102
 	CodeValidatorNotRevoked CodeType = 103
+	CodeInvalidEvidence     CodeType = 104
+	CodeEvidenceTooOld      CodeType = 105
+	CodeDuplicateEvidence   CodeType = 106
+	CodeValidatorTombstoned CodeType = 107
 )
 
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
@@ -29,3 +33,18 @@ func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 func ErrValidatorNotRevoked(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorNotRevoked, "validator not revoked, cannot be unrevoked")
 }
+func ErrNoSigningInfo(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "no signing info found for that validator address")
+}
+func ErrInvalidEvidence(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidEvidence, "invalid evidence: "+msg)
+}
+func ErrEvidenceTooOld(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeEvidenceTooOld, "evidence older than the max evidence age")
+}
+func ErrDuplicateEvidence(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicateEvidence, "evidence already submitted")
+}
+func ErrValidatorTombstoned(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorTombstoned, "validator tombstoned for double signing, cannot be unrevoked")
+}
@@ -0,0 +1,166 @@
+package slashing
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// gas charged for the verification of the signature of every vote of an
+// evidence, as for the signatures of a transaction
+const evidenceVerifyCost sdk.Gas = 100
+
+// hash of the evidence of a double sign, independent of the order of the
+// votes so that the same evidence can't be submitted twice by swapping them
+func evidenceHash(voteA, voteB *tmtypes.Vote) []byte {
+	bzA, bzB := cdc.MustMarshalBinaryBare(voteA), cdc.MustMarshalBinaryBare(voteB)
+	if bytes.Compare(bzA, bzB) > 0 {
+		bzA, bzB = bzB, bzA
+	}
+	return tmhash.Sum(append(bzA, bzB...))
+}
+
+// whether the evidence with the given hash has already been processed
+func (k Keeper) hasEvidence(ctx sdk.Context, hash []byte) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetEvidenceKey(hash))
+}
+
+// record the hash of processed evidence
+func (k Keeper) setEvidence(ctx sdk.Context, hash []byte) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetEvidenceKey(hash), []byte{})
+}
+
+// verify the signatures of the votes of the evidence against the pubkey of
+// the validator, charging the gas of every verification beforehand
+func verifyEvidenceSignatures(ctx sdk.Context, msg MsgSubmitEvidence) bool {
+	for _, vote := range []*tmtypes.Vote{msg.VoteA, msg.VoteB} {
+		ctx.GasMeter().ConsumeGas(evidenceVerifyCost, "evidence verify")
+		if !msg.PubKey.VerifyBytes(vote.SignBytes(ctx.ChainID()), vote.Signature) {
+			return false
+		}
+	}
+	return true
+}
+
+// validatorPower is the power of a validator from a height on, the powers
+// are recorded when they change so that the evidence of an infraction is
+// punished with the power the validator had when it committed it
+type validatorPower struct {
+	Power int64 `json:"power"`
+	Time  int64 `json:"time"` // time of the block which recorded the power
+}
+
+// record the power of a validator at a height if it changed, and prune its
+// powers which are too old to be used by evidence
+func (k Keeper) recordValidatorPower(ctx sdk.Context, address sdk.Address, height int64, power int64) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, GetValidatorPowersKey(address))
+	if iterator.Valid() {
+		var last validatorPower
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &last)
+		if last.Power == power {
+			iterator.Close()
+			return
+		}
+	}
+	iterator.Close()
+
+	time := ctx.BlockHeader().Time
+	bz := k.cdc.MustMarshalBinary(validatorPower{Power: power, Time: time})
+	store.Set(GetValidatorPowerKey(address, height), bz)
+
+	// the powers recorded before the max evidence age are pruned, except
+	// the last one which is the power of the validator at the cutoff
+	cutoff := time - k.MaxEvidenceAge(ctx)
+	var expired [][]byte
+	iterator = sdk.KVStorePrefixIterator(store, GetValidatorPowersKey(address))
+	for ; iterator.Valid(); iterator.Next() {
+		var vp validatorPower
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &vp)
+		if vp.Time >= cutoff {
+			break
+		}
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+	for i := 0; i < len(expired)-1; i++ {
+		store.Delete(expired[i])
+	}
+}
+
+// returns the power of a validator at a height, the last one recorded at or
+// before the height, false if there is none
+func (k Keeper) getValidatorPower(ctx sdk.Context, address sdk.Address, height int64) (power int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(GetValidatorPowersKey(address), GetValidatorPowerKey(address, height+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return 0, false
+	}
+	var vp validatorPower
+	k.cdc.MustUnmarshalBinary(iterator.Value(), &vp)
+	return vp.Power, true
+}
+
+// record a power of 0 at a height for the validators of the previous signing
+// set which left it, so that they can't be punished with a stale power for
+// the heights at which they weren't signing, and store the new signing set
+func (k Keeper) updateSigningSet(ctx sdk.Context, height int64, signingSet []sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	signing := make(map[string]bool, len(signingSet))
+	for _, address := range signingSet {
+		signing[string(address)] = true
+	}
+
+	var previous []sdk.Address
+	if bz := store.Get(SigningSetKey); bz != nil {
+		k.cdc.MustUnmarshalBinary(bz, &previous)
+	}
+	for _, address := range previous {
+		if !signing[string(address)] {
+			k.recordValidatorPower(ctx, address, height, 0)
+		}
+	}
+	store.Set(SigningSetKey, k.cdc.MustMarshalBinary(signingSet))
+}
+
+// record the time of the block at its height, which is the time of the
+// infractions committed at that height, and prune the times which are too
+// old to be used by evidence
+func (k Keeper) recordBlockTime(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	time := ctx.BlockHeader().Time
+	store.Set(GetBlockTimeKey(ctx.BlockHeight()), k.cdc.MustMarshalBinary(time))
+
+	cutoff := time - k.MaxEvidenceAge(ctx)
+	var expired [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, BlockTimeKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var blockTime int64
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &blockTime)
+		if blockTime >= cutoff {
+			break
+		}
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range expired {
+		store.Delete(key)
+	}
+}
+
+// returns the time of the block at a height, false if it wasn't recorded or
+// is too old to be used by evidence
+func (k Keeper) getBlockTime(ctx sdk.Context, height int64) (time int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetBlockTimeKey(height))
+	if bz == nil {
+		return 0, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &time)
+	return time, true
+}
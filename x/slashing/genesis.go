@@ -0,0 +1,137 @@
+package slashing
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all slashing state that must be provided at genesis
+type GenesisState struct {
+	Params       Params               `json:"params"`
+	SigningInfos []GenesisSigningInfo `json:"signing_infos"`
+}
+
+// GenesisSigningInfo - the signing info of a validator and the entries of
+// its signing bit array, by validator address
+type GenesisSigningInfo struct {
+	Address      sdk.Address          `json:"address"`
+	SigningInfo  ValidatorSigningInfo `json:"signing_info"`
+	SignedBlocks []SignedBlock        `json:"signed_blocks"`
+}
+
+// SignedBlock - an entry of the signing bit array of a validator
+type SignedBlock struct {
+	Index  int64 `json:"index"`
+	Signed bool  `json:"signed"`
+}
+
+func NewGenesisState(params Params, signingInfos []GenesisSigningInfo) GenesisState {
+	return GenesisState{
+		Params:       params,
+		SigningInfos: signingInfos,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:       DefaultParams(),
+		SigningInfos: []GenesisSigningInfo{},
+	}
+}
+
+// ValidateGenesis - check the parameters are valid and the signing infos
+// are unique and well formed, with signing bit arrays matching their
+// counters
+func ValidateGenesis(data GenesisState) error {
+	if err := validateParams(data.Params); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(data.SigningInfos))
+	for _, info := range data.SigningInfos {
+		address := info.Address.String()
+		if seen[address] {
+			return fmt.Errorf("duplicate signing info in genesis state: validator %v", address)
+		}
+		seen[address] = true
+
+		signInfo := info.SigningInfo
+		if signInfo.StartHeight < 0 || signInfo.IndexOffset < 0 || signInfo.JailedUntil < 0 || signInfo.SignedBlocksCounter < 0 {
+			return fmt.Errorf("invalid signing info in genesis state: validator %v", address)
+		}
+
+		seenIndexes := make(map[int64]bool, len(info.SignedBlocks))
+		signedBlocks := int64(0)
+		for _, block := range info.SignedBlocks {
+			if block.Index < 0 || seenIndexes[block.Index] {
+				return fmt.Errorf("invalid signing bit array index %d in genesis state: validator %v", block.Index, address)
+			}
+			seenIndexes[block.Index] = true
+			if block.Signed {
+				signedBlocks++
+			}
+		}
+		if signedBlocks != signInfo.SignedBlocksCounter {
+			return fmt.Errorf("signing bit array of %d signed blocks doesn't match the counter of %d in genesis state: validator %v",
+				signedBlocks, signInfo.SignedBlocksCounter, address)
+		}
+	}
+	return nil
+}
+
+// InitGenesis - store the genesis parameters, signing infos and signing bit
+// arrays
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	for _, info := range data.SigningInfos {
+		keeper.setValidatorSigningInfo(ctx, info.Address, info.SigningInfo)
+		for _, block := range info.SignedBlocks {
+			keeper.setValidatorSigningBitArray(ctx, info.Address, block.Index, block.Signed)
+		}
+	}
+}
+
+// WriteGenesis - output the parameters, and the signing infos and signing
+// bit arrays of all the validators
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	signingInfos := []GenesisSigningInfo{}
+	keeper.iterateValidatorSigningInfos(ctx, func(address sdk.Address, info ValidatorSigningInfo) (stop bool) {
+		signedBlocks := []SignedBlock{}
+		keeper.iterateValidatorSigningBitArray(ctx, address, func(index int64, signed bool) (stop bool) {
+			signedBlocks = append(signedBlocks, SignedBlock{index, signed})
+			return false
+		})
+		signingInfos = append(signingInfos, GenesisSigningInfo{address, info, signedBlocks})
+		return false
+	})
+	return NewGenesisState(keeper.GetParams(ctx), signingInfos)
+}
+
+func validateParams(params Params) error {
+	if params.MaxEvidenceAge < 0 {
+		return fmt.Errorf("invalid max evidence age %d, must not be negative", params.MaxEvidenceAge)
+	}
+	if params.SignedBlocksWindow <= 0 {
+		return fmt.Errorf("invalid signed blocks window %d, must be positive", params.SignedBlocksWindow)
+	}
+	if params.DoubleSignUnbondDuration < 0 || params.DowntimeUnbondDuration < 0 {
+		return fmt.Errorf("invalid unbond durations %d and %d, must not be negative",
+			params.DoubleSignUnbondDuration, params.DowntimeUnbondDuration)
+	}
+	if err := validateFraction("min signed per window", params.MinSignedPerWindow); err != nil {
+		return err
+	}
+	if err := validateFraction("slash fraction double sign", params.SlashFractionDoubleSign); err != nil {
+		return err
+	}
+	return validateFraction("slash fraction downtime", params.SlashFractionDowntime)
+}
+
+func validateFraction(name string, fraction sdk.Rat) error {
+	if fraction.Rat == nil || fraction.LT(sdk.ZeroRat()) || fraction.GT(sdk.OneRat()) {
+		return fmt.Errorf("invalid %s %v, must be between 0 and 1", name, fraction)
+	}
+	return nil
+}
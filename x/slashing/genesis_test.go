@@ -0,0 +1,78 @@
+package slashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	genesisState := DefaultGenesisState()
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.SigningInfos = []GenesisSigningInfo{{
+		Address:      addrs[0],
+		SigningInfo:  NewValidatorSigningInfo(1, 3, 0, 1, false),
+		SignedBlocks: []SignedBlock{{0, false}, {1, true}},
+	}}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	// duplicate signing info
+	badState := genesisState
+	badState.SigningInfos = []GenesisSigningInfo{genesisState.SigningInfos[0], genesisState.SigningInfos[0]}
+	require.NotNil(t, ValidateGenesis(badState))
+
+	// duplicate bit array index
+	badState = genesisState
+	badState.SigningInfos = []GenesisSigningInfo{{addrs[0], NewValidatorSigningInfo(1, 3, 0, 1, false), []SignedBlock{{1, true}, {1, false}}}}
+	require.NotNil(t, ValidateGenesis(badState))
+
+	// bit array not matching the counter
+	badState = genesisState
+	badState.SigningInfos = []GenesisSigningInfo{{addrs[0], NewValidatorSigningInfo(1, 3, 0, 2, false), []SignedBlock{{1, true}}}}
+	require.NotNil(t, ValidateGenesis(badState))
+
+	// bad params
+	badState = genesisState
+	badState.Params.SignedBlocksWindow = 0
+	require.NotNil(t, ValidateGenesis(badState))
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(100)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// sign a few blocks, then jail the validator
+	for height := int64(0); height < 5; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, val, amt.Int64(), height%2 == 0)
+	}
+	sk.Revoke(ctx, val)
+	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
+	require.True(t, found)
+	info.JailedUntil = 100
+	keeper.setValidatorSigningInfo(ctx, val.Address(), info)
+
+	exported := WriteGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(exported))
+	require.Equal(t, 1, len(exported.SigningInfos))
+	require.Equal(t, info, exported.SigningInfos[0].SigningInfo)
+	require.Equal(t, []SignedBlock{{0, true}, {2, true}, {4, true}}, exported.SigningInfos[0].SignedBlocks)
+
+	// import the exported state in a new chain
+	newCtx, _, newSk, newKeeper := createTestInput(t)
+	stake.InitGenesis(newCtx, newSk, stake.WriteGenesis(ctx, sk))
+	InitGenesis(newCtx, newKeeper, exported)
+	require.Equal(t, exported, WriteGenesis(newCtx, newKeeper))
+
+	// the validator stays jailed
+	require.True(t, newSk.Validator(newCtx, addr).GetRevoked())
+	got = NewHandler(newKeeper)(newCtx, NewMsgUnrevoke(addr))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorJailed), got.Code)
+}
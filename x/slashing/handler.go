@@ -10,6 +10,8 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgUnrevoke:
 			return handleMsgUnrevoke(ctx, msg, k)
+		case MsgSubmitEvidence:
+			return handleMsgSubmitEvidence(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
@@ -38,6 +40,11 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 		return ErrNoValidatorForAddress(k.codespace).Result()
 	}
 
+	// Cannot be unrevoked after a double sign
+	if info.Tombstoned {
+		return ErrValidatorTombstoned(k.codespace).Result()
+	}
+
 	// Cannot be unrevoked until out of jail
 	if ctx.BlockHeader().Time < info.JailedUntil {
 		return ErrValidatorJailed(k.codespace).Result()
@@ -60,3 +67,69 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 		Tags: tags,
 	}
 }
+
+// Anyone can submit the evidence of a validator signing two conflicting
+// votes, the validator is then punished as for the evidence reported by
+// Tendermint
+func handleMsgSubmitEvidence(ctx sdk.Context, msg MsgSubmitEvidence, k Keeper) sdk.Result {
+
+	// Validator must exist
+	validator := k.validatorSet.ValidatorByPubKey(ctx, msg.PubKey)
+	if validator == nil {
+		return ErrNoValidatorForAddress(k.codespace).Result()
+	}
+
+	// Signing info must exist, the validator must have been bonded
+	address := msg.PubKey.Address()
+	info, found := k.getValidatorSigningInfo(ctx, address)
+	if !found {
+		return ErrNoSigningInfo(k.codespace).Result()
+	}
+
+	infractionHeight := msg.VoteA.Height
+	if infractionHeight > ctx.BlockHeight() {
+		return ErrInvalidEvidence(k.codespace, "votes from a future height").Result()
+	}
+	if !verifyEvidenceSignatures(ctx, msg) {
+		return ErrInvalidEvidence(k.codespace, "invalid vote signature").Result()
+	}
+
+	// Evidence must be recent enough, see handleDoubleSign. The infraction
+	// happened at the time of the block at its height, the timestamps of the
+	// votes are set by the double signer.
+	infractionTime, found := k.getBlockTime(ctx, infractionHeight)
+	if !found || ctx.BlockHeader().Time-infractionTime > k.MaxEvidenceAge(ctx) {
+		return ErrEvidenceTooOld(k.codespace).Result()
+	}
+
+	// Evidence can only be submitted once
+	hash := evidenceHash(msg.VoteA, msg.VoteB)
+	if k.hasEvidence(ctx, hash) {
+		return ErrDuplicateEvidence(k.codespace).Result()
+	}
+
+	// Validator can only be punished for its first double sign
+	if info.Tombstoned {
+		return ErrValidatorTombstoned(k.codespace).Result()
+	}
+
+	// Validator is punished with its power at the infraction height, it
+	// must have been signing blocks at that height
+	power, found := k.getValidatorPower(ctx, address, infractionHeight)
+	if !found || power == 0 {
+		return ErrInvalidEvidence(k.codespace, "no validator power at the infraction height").Result()
+	}
+
+	if ctx.IsCheckTx() {
+		return sdk.Result{}
+	}
+
+	k.setEvidence(ctx, hash)
+	k.handleDoubleSign(ctx, msg.PubKey, infractionHeight, infractionTime, power)
+
+	tags := sdk.NewTags("action", []byte("submit-evidence"), "validator", []byte(validator.GetOwner().String()))
+
+	return sdk.Result{
+		Tags: tags,
+	}
+}
@@ -2,8 +2,12 @@ package slashing
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake"
@@ -27,3 +31,176 @@ func TestCannotUnrevokeUnlessRevoked(t *testing.T) {
 	require.False(t, got.IsOK(), "allowed unrevoke of non-revoked validator")
 	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorNotRevoked), got.Code)
 }
+
+func TestHandleMsgSubmitEvidence(t *testing.T) {
+	// initial setup
+	ctx, _, sk, keeper := createTestInput(t)
+	slh := NewHandler(keeper)
+	amtInt := int64(100)
+	priv, addr, amt := crypto.GenPrivKeyEd25519(), addrs[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, priv.PubKey(), amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// the validator signs the block 2 with its power
+	ctx = ctx.WithBlockHeight(3)
+	keeper.recordValidatorPower(ctx, priv.PubKey().Address(), 2, amtInt)
+	for height := int64(1); height <= 3; height++ {
+		keeper.recordBlockTime(ctx.WithBlockHeight(height))
+	}
+
+	voteA := newTestVote(priv, ctx.ChainID(), 2, []byte("blockA"))
+	voteB := newTestVote(priv, ctx.ChainID(), 2, []byte("blockB"))
+
+	// votes signed for another chain are rejected
+	wrongChain := newTestVote(priv, "other-chain", 2, []byte("blockB"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, wrongChain))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+
+	// votes from the future are rejected
+	future := newTestVote(priv, ctx.ChainID(), 4, []byte("blockA"))
+	futureB := newTestVote(priv, ctx.ChainID(), 4, []byte("blockB"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), future, futureB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+
+	// votes of a height at which the validator wasn't signing are rejected
+	early := newTestVote(priv, ctx.ChainID(), 1, []byte("blockA"))
+	earlyB := newTestVote(priv, ctx.ChainID(), 1, []byte("blockB"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), early, earlyB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+
+	// the signature verifications of the evidence cost gas
+	gasCtx := ctx.WithGasMeter(sdk.NewGasMeter(10000))
+	got = slh(gasCtx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, wrongChain))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+	require.True(t, gasCtx.GasMeter().GasConsumed() >= 2*evidenceVerifyCost)
+
+	// the power of the validator grows after the infraction
+	got = stake.NewHandler(sk)(ctx, stake.NewMsgDelegate(addrs[1], addr, sdk.NewCoin("steak", 100)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// the validator is revoked and slashed for its power at the infraction
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteB))
+	require.True(t, got.IsOK())
+	require.True(t, sk.Validator(ctx, addr).GetRevoked())
+
+	// the validator is tombstoned and can't unrevoke itself
+	got = slh(ctx, NewMsgUnrevoke(addr))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), got.Code)
+	sk.Unrevoke(ctx, priv.PubKey())
+	power := sdk.NewRat(200 - amtInt/20)
+	require.True(t, power.Equal(sk.Validator(ctx, addr).GetPower()))
+
+	// the same evidence can't be submitted twice, even with swapped votes
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteB, voteA))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeDuplicateEvidence), got.Code)
+	require.True(t, power.Equal(sk.Validator(ctx, addr).GetPower()))
+
+	// other evidence of a tombstoned validator is rejected
+	voteD := newTestVote(priv, ctx.ChainID(), 2, []byte("blockD"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteD))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), got.Code)
+	require.True(t, power.Equal(sk.Validator(ctx, addr).GetPower()))
+
+	// evidence older than the max age is rejected
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + keeper.MaxEvidenceAge(ctx)}).WithBlockHeight(3)
+	voteC := newTestVote(priv, ctx.ChainID(), 2, []byte("blockC"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteC))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceTooOld), got.Code)
+	require.True(t, power.Equal(sk.Validator(ctx, addr).GetPower()))
+
+	// unknown validators are rejected
+	other := crypto.GenPrivKeyEd25519()
+	voteA, voteB = newTestVote(other, ctx.ChainID(), 1, []byte("blockA")), newTestVote(other, ctx.ChainID(), 1, []byte("blockB"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], other.PubKey(), voteA, voteB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidValidator), got.Code)
+}
+
+// Test that the age of the evidence is the one of the block at the infraction
+// height, not the one of the timestamps set by the double signer
+func TestHandleMsgSubmitEvidenceAge(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	slh := NewHandler(keeper)
+	amtInt := int64(100)
+	priv, addr, amt := crypto.GenPrivKeyEd25519(), addrs[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, priv.PubKey(), amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// the validator signs the block 2 at time 0
+	keeper.recordBlockTime(ctx.WithBlockHeight(2))
+	keeper.recordValidatorPower(ctx, priv.PubKey().Address(), 2, amtInt)
+
+	// past the max evidence age, the votes are timestamped as recent
+	now := 1 + keeper.MaxEvidenceAge(ctx)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: now}).WithBlockHeight(10)
+	voteA := newTestVoteWithTime(priv, ctx.ChainID(), 2, []byte("blockA"), time.Unix(now, 0))
+	voteB := newTestVoteWithTime(priv, ctx.ChainID(), 2, []byte("blockB"), time.Unix(now, 0))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceTooOld), got.Code)
+	require.False(t, sk.Validator(ctx, addr).GetRevoked())
+
+	// so are the votes of a height which block time wasn't recorded
+	voteA = newTestVoteWithTime(priv, ctx.ChainID(), 5, []byte("blockA"), time.Unix(now, 0))
+	voteB = newTestVoteWithTime(priv, ctx.ChainID(), 5, []byte("blockB"), time.Unix(now, 0))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceTooOld), got.Code)
+	require.False(t, sk.Validator(ctx, addr).GetRevoked())
+
+	// the times too old to be used by evidence are pruned
+	_, found := keeper.getBlockTime(ctx, 2)
+	require.True(t, found)
+	keeper.recordBlockTime(ctx)
+	_, found = keeper.getBlockTime(ctx, 2)
+	require.False(t, found)
+	blockTime, found := keeper.getBlockTime(ctx, 10)
+	require.True(t, found)
+	require.Equal(t, now, blockTime)
+}
+
+// Test that a validator isn't punished for the heights after it left the
+// signing set with its power from before
+func TestHandleMsgSubmitEvidenceAfterLeaving(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	slh := NewHandler(keeper)
+	amtInt := int64(100)
+	priv, addr, amt := crypto.GenPrivKeyEd25519(), addrs[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, priv.PubKey(), amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// the validator signs the block 2 only
+	val := abci.Validator{PubKey: tmtypes.TM2PB.PubKey(priv.PubKey()), Power: amtInt}
+	signing := []abci.SigningValidator{{Validator: val, SignedLastBlock: true}}
+	BeginBlocker(ctx.WithBlockHeight(2), abci.RequestBeginBlock{}, keeper)
+	BeginBlocker(ctx.WithBlockHeight(3), abci.RequestBeginBlock{Validators: signing}, keeper)
+	BeginBlocker(ctx.WithBlockHeight(4), abci.RequestBeginBlock{}, keeper)
+	BeginBlocker(ctx.WithBlockHeight(5), abci.RequestBeginBlock{}, keeper)
+
+	// it has no power from the block 3 on
+	address := priv.PubKey().Address()
+	power, found := keeper.getValidatorPower(ctx, address, 2)
+	require.True(t, found)
+	require.Equal(t, amtInt, power)
+	for height := int64(3); height <= 4; height++ {
+		power, found = keeper.getValidatorPower(ctx, address, height)
+		require.True(t, found)
+		require.Equal(t, int64(0), power)
+	}
+
+	// the evidence of a height after it left is rejected
+	ctx = ctx.WithBlockHeight(5)
+	voteA := newTestVote(priv, ctx.ChainID(), 4, []byte("blockA"))
+	voteB := newTestVote(priv, ctx.ChainID(), 4, []byte("blockB"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+	require.False(t, sk.Validator(ctx, addr).GetRevoked())
+
+	// but not the one of the height it signed
+	voteA = newTestVote(priv, ctx.ChainID(), 2, []byte("blockA"))
+	voteB = newTestVote(priv, ctx.ChainID(), 2, []byte("blockB"))
+	got = slh(ctx, NewMsgSubmitEvidence(addrs[1], priv.PubKey(), voteA, voteB))
+	require.True(t, got.IsOK(), got.Log)
+	require.True(t, sk.Validator(ctx, addr).GetRevoked())
+}
@@ -0,0 +1,35 @@
+package slashing
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Hooks starts the signing info of the validators when they are bonded,
+// instead of discovering them on their first signature
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the slashing keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// OnValidatorBonded starts the signing info of a validator bonded for the
+// first time, the validators which were bonded before keep theirs
+func (h Hooks) OnValidatorBonded(ctx sdk.Context, address sdk.Address, _ sdk.Address) {
+	if _, found := h.k.getValidatorSigningInfo(ctx, address); !found {
+		signInfo := NewValidatorSigningInfo(ctx.BlockHeight(), 0, 0, 0, false)
+		h.k.setValidatorSigningInfo(ctx, address, signInfo)
+	}
+}
+
+// nolint - unused hooks
+func (h Hooks) OnValidatorCreated(_ sdk.Context, _ sdk.Address)                        {}
+func (h Hooks) OnValidatorBeginUnbonding(_ sdk.Context, _ sdk.Address, _ sdk.Address)  {}
+func (h Hooks) OnValidatorRemoved(_ sdk.Context, _ sdk.Address)                        {}
+func (h Hooks) OnDelegationCreated(_ sdk.Context, _ sdk.Address, _ sdk.Address)        {}
+func (h Hooks) OnDelegationSharesModified(_ sdk.Context, _ sdk.Address, _ sdk.Address) {}
+func (h Hooks) OnDelegationRemoved(_ sdk.Context, _ sdk.Address, _ sdk.Address)        {}
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/tendermint/crypto"
 )
 
@@ -13,17 +14,19 @@ type Keeper struct {
 	storeKey     sdk.StoreKey
 	cdc          *wire.Codec
 	validatorSet sdk.ValidatorSet
+	paramspace   params.Subspace
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
 // NewKeeper creates a slashing keeper
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, paramspace params.Subspace, codespace sdk.CodespaceType) Keeper {
 	keeper := Keeper{
 		storeKey:     key,
 		cdc:          cdc,
 		validatorSet: vs,
+		paramspace:   paramspace.WithKeyTable(ParamKeyTable()),
 		codespace:    codespace,
 	}
 	return keeper
@@ -35,28 +38,37 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, pubkey crypto.PubKey, infracti
 	time := ctx.BlockHeader().Time
 	age := time - timestamp
 	address := pubkey.Address()
+	maxEvidenceAge := k.MaxEvidenceAge(ctx)
 
 	// Double sign too old
-	if age > MaxEvidenceAge {
-		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, age of %d past max age of %d", pubkey.Address(), infractionHeight, age, MaxEvidenceAge))
+	if age > maxEvidenceAge {
+		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, age of %d past max age of %d", pubkey.Address(), infractionHeight, age, maxEvidenceAge))
+		return
+	}
+
+	signInfo, found := k.getValidatorSigningInfo(ctx, address)
+	if !found {
+		panic(fmt.Sprintf("Expected signing info for validator %s but not found", address))
+	}
+
+	// Validator already punished for a double sign
+	if signInfo.Tombstoned {
+		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, validator already tombstoned", pubkey.Address(), infractionHeight))
 		return
 	}
 
 	// Double sign confirmed
-	logger.Info(fmt.Sprintf("Confirmed double sign from %s at height %d, age of %d less than max age of %d", pubkey.Address(), infractionHeight, age, MaxEvidenceAge))
+	logger.Info(fmt.Sprintf("Confirmed double sign from %s at height %d, age of %d less than max age of %d", pubkey.Address(), infractionHeight, age, maxEvidenceAge))
 
 	// Slash validator
-	k.validatorSet.Slash(ctx, pubkey, infractionHeight, power, SlashFractionDoubleSign)
+	k.validatorSet.Slash(ctx, pubkey, infractionHeight, power, k.SlashFractionDoubleSign(ctx))
 
 	// Revoke validator
 	k.validatorSet.Revoke(ctx, pubkey)
 
-	// Jail validator
-	signInfo, found := k.getValidatorSigningInfo(ctx, address)
-	if !found {
-		panic(fmt.Sprintf("Expected signing info for validator %s but not found", address))
-	}
-	signInfo.JailedUntil = time + DoubleSignUnbondDuration
+	// Jail and tombstone validator, it can't be unrevoked anymore
+	signInfo.JailedUntil = time + k.DoubleSignUnbondDuration(ctx)
+	signInfo.Tombstoned = true
 	k.setValidatorSigningInfo(ctx, address, signInfo)
 }
 
@@ -67,13 +79,14 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
 	address := pubkey.Address()
 
 	// Local index, so counts blocks validator *should* have signed
-	// Will use the 0-value default signing info if not present, except for start height
+	// The signing info is started when the validator is bonded, see Hooks
 	signInfo, found := k.getValidatorSigningInfo(ctx, address)
 	if !found {
-		// If this validator has never been seen before, construct a new SigningInfo with the correct start height
-		signInfo = NewValidatorSigningInfo(height, 0, 0, 0)
+		panic(fmt.Sprintf("Expected signing info for validator %s but not found", address))
 	}
-	index := signInfo.IndexOffset % SignedBlocksWindow
+	signedBlocksWindow := k.SignedBlocksWindow(ctx)
+	minSignedPerWindow := k.MinSignedPerWindow(ctx)
+	index := signInfo.IndexOffset % signedBlocksWindow
 	signInfo.IndexOffset++
 
 	// Update signed block bit array & counter
@@ -93,15 +106,15 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
 	}
 
 	if !signed {
-		logger.Info(fmt.Sprintf("Absent validator %s at height %d, %d signed, threshold %d", pubkey.Address(), height, signInfo.SignedBlocksCounter, MinSignedPerWindow))
+		logger.Info(fmt.Sprintf("Absent validator %s at height %d, %d signed, threshold %d", pubkey.Address(), height, signInfo.SignedBlocksCounter, minSignedPerWindow))
 	}
-	minHeight := signInfo.StartHeight + SignedBlocksWindow
-	if height > minHeight && signInfo.SignedBlocksCounter < MinSignedPerWindow {
+	minHeight := signInfo.StartHeight + signedBlocksWindow
+	if height > minHeight && signInfo.SignedBlocksCounter < minSignedPerWindow {
 		// Downtime confirmed, slash, revoke, and jail the validator
-		logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d", pubkey.Address(), minHeight, MinSignedPerWindow))
-		k.validatorSet.Slash(ctx, pubkey, height, power, SlashFractionDowntime)
+		logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d", pubkey.Address(), minHeight, minSignedPerWindow))
+		k.validatorSet.Slash(ctx, pubkey, height, power, k.SlashFractionDowntime(ctx))
 		k.validatorSet.Revoke(ctx, pubkey)
-		signInfo.JailedUntil = ctx.BlockHeader().Time + DowntimeUnbondDuration
+		signInfo.JailedUntil = ctx.BlockHeader().Time + k.DowntimeUnbondDuration(ctx)
 	}
 
 	// Set the updated signing info
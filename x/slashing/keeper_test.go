@@ -11,15 +11,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
-// Have to change these parameters for tests
-// lest the tests take forever
-func init() {
-	SignedBlocksWindow = 1000
-	MinSignedPerWindow = SignedBlocksWindow / 2
-	DowntimeUnbondDuration = 60 * 60
-	DoubleSignUnbondDuration = 60 * 60
-}
-
 // Test that a validator is slashed correctly
 // when we discover evidence of infraction
 func TestHandleDoubleSign(t *testing.T) {
@@ -34,8 +25,9 @@ func TestHandleDoubleSign(t *testing.T) {
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.Sub(amt)}})
 	require.True(t, sdk.NewRatFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
 
-	// handle a signature to set signing info
-	keeper.handleValidatorSignature(ctx, val, amtInt, true)
+	// the signing info is set once bonded
+	_, found := keeper.getValidatorSigningInfo(ctx, val.Address())
+	require.True(t, found)
 
 	// double sign less than max age
 	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
@@ -46,13 +38,65 @@ func TestHandleDoubleSign(t *testing.T) {
 	sk.Unrevoke(ctx, val)
 	// power should be reduced
 	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
-	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + MaxEvidenceAge})
+
+	// should be tombstoned
+	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
+	require.True(t, found)
+	require.True(t, info.Tombstoned)
+
+	// another double sign is ignored
+	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
+	require.False(t, sk.Validator(ctx, addr).GetRevoked())
+	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + keeper.MaxEvidenceAge(ctx)})
 
 	// double sign past max age
 	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
 	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
 }
 
+// Test that the powers of a validator are recorded when they change and
+// pruned past the max evidence age
+func TestValidatorPowers(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+	address := pks[0].Address()
+	_, found := keeper.getValidatorPower(ctx, address, 5)
+	require.False(t, found)
+
+	keeper.recordValidatorPower(ctx, address, 1, 10)
+	keeper.recordValidatorPower(ctx, address, 2, 10)
+	keeper.recordValidatorPower(ctx, address, 3, 20)
+	tests := []struct {
+		height int64
+		power  int64
+		found  bool
+	}{{0, 0, false}, {1, 10, true}, {2, 10, true}, {3, 20, true}, {100, 20, true}}
+	for i, tc := range tests {
+		power, found := keeper.getValidatorPower(ctx, address, tc.height)
+		require.Equal(t, tc.found, found, "test: %v", i)
+		require.Equal(t, tc.power, power, "test: %v", i)
+	}
+
+	// the power of height 1 is past the max evidence age, the one of height
+	// 3 is still the power of the validator at the cutoff
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + keeper.MaxEvidenceAge(ctx)})
+	keeper.recordValidatorPower(ctx, address, 10, 30)
+	tests = []struct {
+		height int64
+		power  int64
+		found  bool
+	}{{1, 0, false}, {3, 20, true}, {9, 20, true}, {10, 30, true}}
+	for i, tc := range tests {
+		power, found := keeper.getValidatorPower(ctx, address, tc.height)
+		require.Equal(t, tc.found, found, "test: %v", i)
+		require.Equal(t, tc.power, power, "test: %v", i)
+	}
+
+	// other validators are independent
+	_, found = keeper.getValidatorPower(ctx, pks[1].Address(), 10)
+	require.False(t, found)
+}
+
 // Test a validator through uptime, downtime, revocation,
 // unrevocation, starting height reset, and revocation again
 func TestHandleAbsentValidator(t *testing.T) {
@@ -69,32 +113,34 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.Sub(amt)}})
 	require.True(t, sdk.NewRatFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
 	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
-	require.False(t, found)
+	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
 	require.Equal(t, int64(0), info.IndexOffset)
 	require.Equal(t, int64(0), info.SignedBlocksCounter)
 	require.Equal(t, int64(0), info.JailedUntil)
 	height := int64(0)
+	signedBlocksWindow := keeper.SignedBlocksWindow(ctx)
+	minSignedPerWindow := keeper.MinSignedPerWindow(ctx)
 
 	// 1000 first blocks OK
-	for ; height < SignedBlocksWindow; height++ {
+	for ; height < signedBlocksWindow; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, true)
 	}
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow, info.SignedBlocksCounter)
 
 	// 500 blocks missed
-	for ; height < SignedBlocksWindow+(SignedBlocksWindow-MinSignedPerWindow); height++ {
+	for ; height < signedBlocksWindow+(signedBlocksWindow-minSignedPerWindow); height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
 	}
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-MinSignedPerWindow, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow-minSignedPerWindow, info.SignedBlocksCounter)
 
 	// validator should be bonded still
 	validator, _ := sk.GetValidatorByPubKey(ctx, val)
@@ -108,7 +154,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-MinSignedPerWindow-1, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow-minSignedPerWindow-1, info.SignedBlocksCounter)
 
 	// validator should have been revoked
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
@@ -119,7 +165,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.False(t, got.IsOK())
 
 	// unrevocation should succeed after jail expiration
-	ctx = ctx.WithBlockHeader(abci.Header{Time: DowntimeUnbondDuration + 1})
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.DowntimeUnbondDuration(ctx) + 1})
 	got = slh(ctx, NewMsgUnrevoke(addr))
 	require.True(t, got.IsOK())
 
@@ -135,7 +181,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, height, info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-MinSignedPerWindow-1, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow-minSignedPerWindow-1, info.SignedBlocksCounter)
 
 	// validator should not be immediately revoked again
 	height++
@@ -145,14 +191,14 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.Equal(t, sdk.Bonded, validator.GetStatus())
 
 	// 500 signed blocks
-	nextHeight := height + MinSignedPerWindow + 1
+	nextHeight := height + minSignedPerWindow + 1
 	for ; height < nextHeight; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
 	}
 
 	// validator should be revoked again after 500 unsigned blocks
-	nextHeight = height + MinSignedPerWindow + 1
+	nextHeight = height + minSignedPerWindow + 1
 	for ; height <= nextHeight; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
@@ -168,24 +214,25 @@ func TestHandleNewValidator(t *testing.T) {
 	// initial setup
 	ctx, ck, sk, keeper := createTestInput(t)
 	addr, val, amt := addrs[0], pks[0], int64(100)
+	signedBlocksWindow := keeper.SignedBlocksWindow(ctx)
+
+	// 1000 first blocks not a validator
+	ctx = ctx.WithBlockHeight(signedBlocksWindow + 1)
+
+	// Now a validator, for two blocks
 	sh := stake.NewHandler(sk)
 	got := sh(ctx, newTestMsgCreateValidator(addr, val, sdk.NewInt(amt)))
 	require.True(t, got.IsOK())
 	stake.EndBlocker(ctx, sk)
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.SubRaw(amt)}})
 	require.Equal(t, sdk.NewRat(amt), sk.Validator(ctx, addr).GetPower())
-
-	// 1000 first blocks not a validator
-	ctx = ctx.WithBlockHeight(SignedBlocksWindow + 1)
-
-	// Now a validator, for two blocks
 	keeper.handleValidatorSignature(ctx, val, 100, true)
-	ctx = ctx.WithBlockHeight(SignedBlocksWindow + 2)
+	ctx = ctx.WithBlockHeight(signedBlocksWindow + 2)
 	keeper.handleValidatorSignature(ctx, val, 100, false)
 
 	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
-	require.Equal(t, int64(SignedBlocksWindow+1), info.StartHeight)
+	require.Equal(t, signedBlocksWindow+1, info.StartHeight)
 	require.Equal(t, int64(2), info.IndexOffset)
 	require.Equal(t, int64(1), info.SignedBlocksCounter)
 	require.Equal(t, int64(0), info.JailedUntil)
@@ -1,17 +1,26 @@
 package slashing
 
 import (
+	"bytes"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 var cdc = wire.NewCodec()
 
+func init() {
+	// the votes of the evidence hold signatures
+	wire.RegisterCrypto(cdc)
+}
+
 // name to identify transaction types
 const MsgType = "slashing"
 
 // verify interface at compile time
-var _ sdk.Msg = &MsgUnrevoke{}
+var _, _ sdk.Msg = &MsgUnrevoke{}, &MsgSubmitEvidence{}
 
 // MsgUnrevoke - struct for unrevoking revoked validator
 type MsgUnrevoke struct {
@@ -48,3 +57,73 @@ func (msg MsgUnrevoke) ValidateBasic() sdk.Error {
 	}
 	return nil
 }
+
+//______________________________________________________________________
+
+// MsgSubmitEvidence - struct for submitting the evidence of a validator
+// signing two conflicting votes at the same height and round
+type MsgSubmitEvidence struct {
+	Submitter sdk.Address   `json:"submitter"` // address of the account submitting the evidence
+	PubKey    crypto.PubKey `json:"pub_key"`   // pubkey of the validator which signed the votes
+	VoteA     *tmtypes.Vote `json:"vote_a"`
+	VoteB     *tmtypes.Vote `json:"vote_b"`
+}
+
+func NewMsgSubmitEvidence(submitter sdk.Address, pubKey crypto.PubKey, voteA, voteB *tmtypes.Vote) MsgSubmitEvidence {
+	return MsgSubmitEvidence{
+		Submitter: submitter,
+		PubKey:    pubKey,
+		VoteA:     voteA,
+		VoteB:     voteB,
+	}
+}
+
+//nolint
+func (msg MsgSubmitEvidence) Type() string              { return MsgType }
+func (msg MsgSubmitEvidence) GetSigners() []sdk.Address { return []sdk.Address{msg.Submitter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgSubmitEvidence) GetSignBytes() []byte {
+	b, err := cdc.MarshalJSON(struct {
+		Submitter string        `json:"submitter"`
+		PubKey    string        `json:"pub_key"`
+		VoteA     *tmtypes.Vote `json:"vote_a"`
+		VoteB     *tmtypes.Vote `json:"vote_b"`
+	}{
+		Submitter: sdk.MustBech32ifyAcc(msg.Submitter),
+		PubKey:    sdk.MustBech32ifyValPub(msg.PubKey),
+		VoteA:     msg.VoteA,
+		VoteB:     msg.VoteB,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check, the signatures of the votes are verified by the
+// handler, as they depend on the chain ID
+func (msg MsgSubmitEvidence) ValidateBasic() sdk.Error {
+	if msg.Submitter == nil {
+		return sdk.ErrInvalidAddress("nil submitter address")
+	}
+	if msg.PubKey == nil || msg.VoteA == nil || msg.VoteB == nil {
+		return ErrInvalidEvidence(DefaultCodespace, "pubkey and votes must be included")
+	}
+
+	voteA, voteB := msg.VoteA, msg.VoteB
+	if voteA.Height != voteB.Height || voteA.Round != voteB.Round || voteA.Type != voteB.Type {
+		return ErrInvalidEvidence(DefaultCodespace, "votes are not for the same height, round and type")
+	}
+	address := msg.PubKey.Address()
+	if !bytes.Equal(voteA.ValidatorAddress, address) || !bytes.Equal(voteB.ValidatorAddress, address) {
+		return ErrInvalidEvidence(DefaultCodespace, "votes are not from the validator of the pubkey")
+	}
+	if voteA.BlockID.Equals(voteB.BlockID) {
+		return ErrInvalidEvidence(DefaultCodespace, "votes are for the same block")
+	}
+	if voteA.Signature == nil || voteB.Signature == nil {
+		return ErrInvalidEvidence(DefaultCodespace, "votes must be signed")
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -14,3 +16,40 @@ func TestMsgUnrevokeGetSignBytes(t *testing.T) {
 	bytes := msg.GetSignBytes()
 	require.Equal(t, string(bytes), `{"address":"cosmosvaladdr1v93xxeqamr0mv"}`)
 }
+
+func TestMsgSubmitEvidenceValidateBasic(t *testing.T) {
+	priv, other := crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()
+	voteA := newTestVote(priv, "", 1, []byte("blockA"))
+	voteB := newTestVote(priv, "", 1, []byte("blockB"))
+	otherHeight := newTestVote(priv, "", 2, []byte("blockB"))
+	otherValidator := newTestVote(other, "", 1, []byte("blockB"))
+	unsigned := newTestVote(priv, "", 1, []byte("blockB"))
+	unsigned.Signature = nil
+
+	tests := []struct {
+		name         string
+		submitter    sdk.Address
+		pubKey       crypto.PubKey
+		voteA, voteB *tmtypes.Vote
+		expectPass   bool
+	}{
+		{"conflicting votes", addrs[0], priv.PubKey(), voteA, voteB, true},
+		{"nil submitter", nil, priv.PubKey(), voteA, voteB, false},
+		{"nil pubkey", addrs[0], nil, voteA, voteB, false},
+		{"missing vote", addrs[0], priv.PubKey(), voteA, nil, false},
+		{"same block", addrs[0], priv.PubKey(), voteA, voteA, false},
+		{"different heights", addrs[0], priv.PubKey(), voteA, otherHeight, false},
+		{"different validators", addrs[0], priv.PubKey(), voteA, otherValidator, false},
+		{"wrong pubkey", addrs[0], other.PubKey(), voteA, voteB, false},
+		{"unsigned vote", addrs[0], priv.PubKey(), voteA, unsigned, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSubmitEvidence(tc.submitter, tc.pubKey, tc.voteA, tc.voteB)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
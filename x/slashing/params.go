@@ -2,41 +2,135 @@ package slashing
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
+// default name of the slashing subspace of the params store
+const DefaultParamspace = "slashing"
+
+// keys of the slashing parameters in the params store
+// nolint
 var (
-	// MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
-	// TODO Should this be a governance parameter or just modifiable with SoftwareUpgradeProposals?
-	// MaxEvidenceAge = 60 * 60 * 24 * 7 * 3
-	// TODO Temporarily set to 2 minutes for testnets.
-	MaxEvidenceAge int64 = 60 * 2
-
-	// SignedBlocksWindow - sliding window for downtime slashing
-	// TODO Governance parameter?
-	// TODO Temporarily set to 40000 blocks for testnets
-	SignedBlocksWindow int64 = 40000
-
-	// Downtime slashing threshold - 50%
-	// TODO Governance parameter?
-	MinSignedPerWindow = SignedBlocksWindow / 2
-
-	// Downtime unbond duration
-	// TODO Governance parameter?
-	// TODO Temporarily set to five minutes for testnets
-	DowntimeUnbondDuration int64 = 60 * 5
-
-	// Double-sign unbond duration
-	// TODO Governance parameter?
-	// TODO Temporarily set to five minutes for testnets
-	DoubleSignUnbondDuration int64 = 60 * 5
+	KeyMaxEvidenceAge           = []byte("MaxEvidenceAge")
+	KeySignedBlocksWindow       = []byte("SignedBlocksWindow")
+	KeyMinSignedPerWindow       = []byte("MinSignedPerWindow")
+	KeyDoubleSignUnbondDuration = []byte("DoubleSignUnbondDuration")
+	KeyDowntimeUnbondDuration   = []byte("DowntimeUnbondDuration")
+	KeySlashFractionDoubleSign  = []byte("SlashFractionDoubleSign")
+	KeySlashFractionDowntime    = []byte("SlashFractionDowntime")
 )
 
-var (
-	// SlashFractionDoubleSign - currently 5%
-	// TODO Governance parameter?
-	SlashFractionDoubleSign = sdk.NewRat(1).Quo(sdk.NewRat(20))
+// ParamKeyTable registers the types of the slashing parameters and their
+// validation
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().
+		RegisterParamSet(&Params{}).
+		RegisterValidation(func(ctx sdk.Context, space params.Subspace) error {
+			var p Params
+			space.GetParamSet(ctx, &p)
+			return validateParams(p)
+		})
+}
 
-	// SlashFractionDowntime - currently 1%
-	// TODO Governance parameter?
-	SlashFractionDowntime = sdk.NewRat(1).Quo(sdk.NewRat(100))
-)
+// Params of the slashing module
+type Params struct {
+	MaxEvidenceAge           int64   `json:"max_evidence_age"`            // max age of a double sign evidence, in seconds
+	SignedBlocksWindow       int64   `json:"signed_blocks_window"`        // sliding window for downtime slashing
+	MinSignedPerWindow       sdk.Rat `json:"min_signed_per_window"`       // downtime slashing threshold, fraction of the window
+	DoubleSignUnbondDuration int64   `json:"double_sign_unbond_duration"` // jail duration for double signing, in seconds
+	DowntimeUnbondDuration   int64   `json:"downtime_unbond_duration"`    // jail duration for downtime, in seconds
+	SlashFractionDoubleSign  sdk.Rat `json:"slash_fraction_double_sign"`
+	SlashFractionDowntime    sdk.Rat `json:"slash_fraction_downtime"`
+}
+
+var _ params.ParamSet = (*Params)(nil)
+
+// KeyValuePairs implements params.ParamSet
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{Key: KeyMaxEvidenceAge, Value: &p.MaxEvidenceAge},
+		{Key: KeySignedBlocksWindow, Value: &p.SignedBlocksWindow},
+		{Key: KeyMinSignedPerWindow, Value: &p.MinSignedPerWindow},
+		{Key: KeyDoubleSignUnbondDuration, Value: &p.DoubleSignUnbondDuration},
+		{Key: KeyDowntimeUnbondDuration, Value: &p.DowntimeUnbondDuration},
+		{Key: KeySlashFractionDoubleSign, Value: &p.SlashFractionDoubleSign},
+		{Key: KeySlashFractionDowntime, Value: &p.SlashFractionDowntime},
+	}
+}
+
+// DefaultParams returns the default slashing parameters
+func DefaultParams() Params {
+	return Params{
+		// TODO Temporarily set to 2 minutes for testnets, should be 3 weeks
+		MaxEvidenceAge: 60 * 2,
+
+		// TODO Temporarily set to 40000 blocks for testnets
+		SignedBlocksWindow: 40000,
+
+		// 50% of the window
+		MinSignedPerWindow: sdk.NewRat(1, 2),
+
+		// TODO Temporarily set to five minutes for testnets
+		DoubleSignUnbondDuration: 60 * 5,
+		DowntimeUnbondDuration:   60 * 5,
+
+		// 5% and 1%
+		SlashFractionDoubleSign: sdk.NewRat(1, 20),
+		SlashFractionDowntime:   sdk.NewRat(1, 100),
+	}
+}
+
+// MaxEvidenceAge - max age for evidence
+func (k Keeper) MaxEvidenceAge(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeyMaxEvidenceAge, &res)
+	return
+}
+
+// SignedBlocksWindow - sliding window for downtime slashing
+func (k Keeper) SignedBlocksWindow(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeySignedBlocksWindow, &res)
+	return
+}
+
+// MinSignedPerWindow - downtime slashing threshold, in blocks
+func (k Keeper) MinSignedPerWindow(ctx sdk.Context) int64 {
+	var minSignedPerWindow sdk.Rat
+	k.paramspace.Get(ctx, KeyMinSignedPerWindow, &minSignedPerWindow)
+	signedBlocksWindow := k.SignedBlocksWindow(ctx)
+	return sdk.NewRat(signedBlocksWindow).Mul(minSignedPerWindow).RoundInt64()
+}
+
+// DoubleSignUnbondDuration - jail duration for double signing
+func (k Keeper) DoubleSignUnbondDuration(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeyDoubleSignUnbondDuration, &res)
+	return
+}
+
+// DowntimeUnbondDuration - jail duration for downtime
+func (k Keeper) DowntimeUnbondDuration(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeyDowntimeUnbondDuration, &res)
+	return
+}
+
+// SlashFractionDoubleSign - fraction of the stake slashed for double signing
+func (k Keeper) SlashFractionDoubleSign(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeySlashFractionDoubleSign, &res)
+	return
+}
+
+// SlashFractionDowntime - fraction of the stake slashed for downtime
+func (k Keeper) SlashFractionDowntime(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeySlashFractionDowntime, &res)
+	return
+}
+
+// GetParams returns all the slashing parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return
+}
+
+// SetParams sets all the slashing parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,48 @@
+package slashing
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the slashing Querier
+const (
+	QuerySigningInfo = "signingInfo"
+)
+
+// Params for query 'custom/slashing/signingInfo'
+type QuerySigningInfoParams struct {
+	ValidatorAddr sdk.Address // validator (consensus) address, not the owner
+}
+
+// NewQuerier returns the querier for the slashing module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no slashing query endpoint specified")
+		}
+		switch path[0] {
+		case QuerySigningInfo:
+			return querySigningInfo(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown slashing query endpoint %s", path[0]))
+		}
+	}
+}
+
+func querySigningInfo(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params QuerySigningInfoParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	info, found := k.getValidatorSigningInfo(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, ErrNoSigningInfo(k.codespace)
+	}
+
+	return sdk.MarshalQueryResult(k.cdc, info)
+}
@@ -27,6 +27,22 @@ func (k Keeper) setValidatorSigningInfo(ctx sdk.Context, address sdk.Address, in
 	store.Set(GetValidatorSigningInfoKey(address), bz)
 }
 
+// iterate over the signing infos of all the validators, stopping when the
+// handler returns true
+func (k Keeper) iterateValidatorSigningInfos(ctx sdk.Context, handler func(address sdk.Address, info ValidatorSigningInfo) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		address := sdk.Address(iter.Key()[len(ValidatorSigningInfoKey):])
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &info)
+		if handler(address, info) {
+			break
+		}
+	}
+}
+
 // Stored by *validator* address (not owner address)
 func (k Keeper) getValidatorSigningBitArray(ctx sdk.Context, address sdk.Address, index int64) (signed bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -47,13 +63,31 @@ func (k Keeper) setValidatorSigningBitArray(ctx sdk.Context, address sdk.Address
 	store.Set(GetValidatorSigningBitArrayKey(address, index), bz)
 }
 
+// iterate over the entries of the signing bit array of a validator, stopping
+// when the handler returns true
+func (k Keeper) iterateValidatorSigningBitArray(ctx sdk.Context, address sdk.Address, handler func(index int64, signed bool) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := append(ValidatorSigningBitArrayKey, address.Bytes()...)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		index := int64(binary.LittleEndian.Uint64(iter.Key()[len(prefix):]))
+		var signed bool
+		k.cdc.MustUnmarshalBinary(iter.Value(), &signed)
+		if handler(index, signed) {
+			break
+		}
+	}
+}
+
 // Construct a new `ValidatorSigningInfo` struct
-func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil int64, signedBlocksCounter int64) ValidatorSigningInfo {
+func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil int64, signedBlocksCounter int64, tombstoned bool) ValidatorSigningInfo {
 	return ValidatorSigningInfo{
 		StartHeight:         startHeight,
 		IndexOffset:         indexOffset,
 		JailedUntil:         jailedUntil,
 		SignedBlocksCounter: signedBlocksCounter,
+		Tombstoned:          tombstoned,
 	}
 }
 
@@ -63,22 +97,58 @@ type ValidatorSigningInfo struct {
 	IndexOffset         int64 `json:"index_offset"`          // index offset into signed block bit array
 	JailedUntil         int64 `json:"jailed_until"`          // timestamp validator cannot be unrevoked until
 	SignedBlocksCounter int64 `json:"signed_blocks_counter"` // signed blocks counter (to avoid scanning the array every time)
+	Tombstoned          bool  `json:"tombstoned"`            // whether the validator double signed, it can never be unrevoked
 }
 
 // Return human readable signing info
 func (i ValidatorSigningInfo) HumanReadableString() string {
-	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %d, signed blocks counter: %d",
-		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter)
+	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %d, signed blocks counter: %d, tombstoned: %v",
+		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter, i.Tombstoned)
 }
 
+// key prefixes of the slashing store
+var (
+	ValidatorSigningInfoKey     = []byte{0x01} // prefix for the signing infos
+	ValidatorSigningBitArrayKey = []byte{0x02} // prefix for the signing bit arrays
+	EvidenceKey                 = []byte{0x03} // prefix for the hashes of the submitted evidence
+	ValidatorPowerKey           = []byte{0x04} // prefix for the recorded powers of the validators
+	BlockTimeKey                = []byte{0x05} // prefix for the recorded times of the blocks
+	SigningSetKey               = []byte{0x06} // key for the addresses of the last signing validators
+)
+
 // Stored by *validator* address (not owner address)
 func GetValidatorSigningInfoKey(v sdk.Address) []byte {
-	return append([]byte{0x01}, v.Bytes()...)
+	return append(ValidatorSigningInfoKey, v.Bytes()...)
 }
 
 // Stored by *validator* address (not owner address)
 func GetValidatorSigningBitArrayKey(v sdk.Address, i int64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, uint64(i))
-	return append([]byte{0x02}, append(v.Bytes(), b...)...)
+	return append(ValidatorSigningBitArrayKey, append(v.Bytes(), b...)...)
+}
+
+// Stored by evidence hash
+func GetEvidenceKey(hash []byte) []byte {
+	return append(EvidenceKey, hash...)
+}
+
+// Stored by *validator* address, then by big endian height so that the
+// powers of a validator are ordered by height
+func GetValidatorPowerKey(v sdk.Address, height int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	return append(GetValidatorPowersKey(v), b...)
+}
+
+// prefix of the recorded powers of a validator
+func GetValidatorPowersKey(v sdk.Address) []byte {
+	return append(ValidatorPowerKey, v.Bytes()...)
+}
+
+// Stored by big endian height so that the times are ordered by height
+func GetBlockTimeKey(height int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	return append(BlockTimeKey, b...)
 }
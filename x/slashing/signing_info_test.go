@@ -15,6 +15,7 @@ func TestGetSetValidatorSigningInfo(t *testing.T) {
 		IndexOffset:         int64(3),
 		JailedUntil:         int64(2),
 		SignedBlocksCounter: int64(10),
+		Tombstoned:          true,
 	}
 	keeper.setValidatorSigningInfo(ctx, addrs[0], newInfo)
 	info, found = keeper.getValidatorSigningInfo(ctx, addrs[0])
@@ -23,6 +24,7 @@ func TestGetSetValidatorSigningInfo(t *testing.T) {
 	require.Equal(t, info.IndexOffset, int64(3))
 	require.Equal(t, info.JailedUntil, int64(2))
 	require.Equal(t, info.SignedBlocksCounter, int64(10))
+	require.True(t, info.Tombstoned)
 }
 
 func TestGetSetValidatorSigningBitArray(t *testing.T) {
@@ -0,0 +1,33 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+)
+
+// SimulateMsgUnrevoke unrevokes a random revoked validator, which fails if
+// it's still jailed
+func SimulateMsgUnrevoke(k slashing.Keeper, vs sdk.ValidatorSet) simulation.Operation {
+	handler := slashing.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		var revoked []sdk.Address
+		vs.IterateValidators(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+			if validator.GetRevoked() {
+				revoked = append(revoked, validator.GetOwner())
+			}
+			return false
+		})
+		if len(revoked) == 0 {
+			return simulation.Noop("slashing/MsgUnrevoke", "no revoked validators", event)
+		}
+
+		msg := slashing.NewMsgUnrevoke(revoked[r.Intn(len(revoked))])
+		return simulation.RunMsg(ctx, handler, msg, "slashing/MsgUnrevoke", event)
+	}
+}
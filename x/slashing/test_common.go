@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -11,12 +12,14 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -50,18 +53,21 @@ func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, Keep
 	keyAcc := sdk.NewKVStoreKey("acc")
 	keyStake := sdk.NewKVStoreKey("stake")
 	keySlashing := sdk.NewKVStoreKey("slashing")
+	keyParams := sdk.NewKVStoreKey("params")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySlashing, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewTMLogger(os.Stdout))
 	cdc := createTestCodec()
 	accountMapper := auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{})
 	ck := bank.NewKeeper(accountMapper)
-	sk := stake.NewKeeper(cdc, keyStake, ck, stake.DefaultCodespace)
+	pk := params.NewKeeper(cdc, keyParams)
+	sk := stake.NewKeeper(cdc, keyStake, ck, pk.Subspace(stake.DefaultParamspace), stake.DefaultCodespace)
 	genesis := stake.DefaultGenesisState()
 	genesis.Pool.LooseTokens = initCoins.MulRaw(int64(len(addrs))).Int64()
 	stake.InitGenesis(ctx, sk, genesis)
@@ -71,10 +77,22 @@ func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, Keep
 		})
 	}
 	require.Nil(t, err)
-	keeper := NewKeeper(cdc, keySlashing, sk, DefaultCodespace)
+	keeper := NewKeeper(cdc, keySlashing, sk, pk.Subspace(DefaultParamspace), DefaultCodespace)
+	sk.SetHooks(keeper.Hooks())
+	keeper.SetParams(ctx, testParams())
 	return ctx, ck, sk, keeper
 }
 
+// shorter window and durations than the default ones, lest the tests take
+// forever
+func testParams() Params {
+	params := DefaultParams()
+	params.SignedBlocksWindow = 1000
+	params.DowntimeUnbondDuration = 60 * 60
+	params.DoubleSignUnbondDuration = 60 * 60
+	return params
+}
+
 func newPubKey(pk string) (res crypto.PubKey) {
 	pkBytes, err := hex.DecodeString(pk)
 	if err != nil {
@@ -93,8 +111,30 @@ func testAddr(addr string) sdk.Address {
 func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt sdk.Int) stake.MsgCreateValidator {
 	return stake.MsgCreateValidator{
 		Description:    stake.Description{},
+		Commission:     stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat()),
 		ValidatorAddr:  address,
 		PubKey:         pubKey,
 		SelfDelegation: sdk.Coin{"steak", amt},
 	}
 }
+
+// precommit vote for the block with the given hash, signed with the private key
+func newTestVote(priv crypto.PrivKey, chainID string, height int64, blockHash []byte) *tmtypes.Vote {
+	return newTestVoteWithTime(priv, chainID, height, blockHash, time.Unix(0, 0))
+}
+
+func newTestVoteWithTime(priv crypto.PrivKey, chainID string, height int64, blockHash []byte, timestamp time.Time) *tmtypes.Vote {
+	vote := &tmtypes.Vote{
+		ValidatorAddress: priv.PubKey().Address(),
+		Height:           height,
+		Timestamp:        timestamp,
+		Type:             tmtypes.VoteTypePrecommit,
+		BlockID:          tmtypes.BlockID{Hash: blockHash},
+	}
+	sig, err := priv.Sign(vote.SignBytes(chainID))
+	if err != nil {
+		panic(err)
+	}
+	vote.Signature = sig
+	return vote
+}
@@ -19,6 +19,7 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 	// Iterate over all the validators  which *should* have signed this block
 	// Store whether or not they have actually signed it and slash/unbond any
 	// which have missed too many blocks in a row (downtime slashing)
+	signingSet := make([]sdk.Address, 0, len(req.Validators))
 	for _, signingValidator := range req.Validators {
 		present := signingValidator.SignedLastBlock
 		pubkey, err := tmtypes.PB2TM.PubKey(signingValidator.Validator.PubKey)
@@ -26,7 +27,13 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 			panic(err)
 		}
 		sk.handleValidatorSignature(ctx, pubkey, signingValidator.Validator.Power, present)
+		// the validators are the ones of the last block, with their power
+		sk.recordValidatorPower(ctx, pubkey.Address(), ctx.BlockHeight()-1, signingValidator.Validator.Power)
+		signingSet = append(signingSet, pubkey.Address())
 	}
+	// the validators which left the signing set have no power anymore
+	sk.updateSigningSet(ctx, ctx.BlockHeight()-1, signingSet)
+	sk.recordBlockTime(ctx)
 
 	// Iterate through any newly discovered evidence of infraction
 	// Slash any validators (and since-unbonded stake within the unbonding period)
@@ -19,17 +19,23 @@ const (
 	FlagIdentity = "keybase-sig"
 	FlagWebsite  = "website"
 	FlagDetails  = "details"
+
+	FlagCommissionRate          = "commission-rate"
+	FlagCommissionMaxRate       = "commission-max-rate"
+	FlagCommissionMaxChangeRate = "commission-max-change-rate"
 )
 
 // common flagsets to add to various functions
 var (
-	fsPk           = flag.NewFlagSet("", flag.ContinueOnError)
-	fsAmount       = flag.NewFlagSet("", flag.ContinueOnError)
-	fsShares       = flag.NewFlagSet("", flag.ContinueOnError)
-	fsDescription  = flag.NewFlagSet("", flag.ContinueOnError)
-	fsValidator    = flag.NewFlagSet("", flag.ContinueOnError)
-	fsDelegator    = flag.NewFlagSet("", flag.ContinueOnError)
-	fsRedelegation = flag.NewFlagSet("", flag.ContinueOnError)
+	fsPk             = flag.NewFlagSet("", flag.ContinueOnError)
+	fsAmount         = flag.NewFlagSet("", flag.ContinueOnError)
+	fsShares         = flag.NewFlagSet("", flag.ContinueOnError)
+	fsDescription    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommission     = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommissionEdit = flag.NewFlagSet("", flag.ContinueOnError)
+	fsValidator      = flag.NewFlagSet("", flag.ContinueOnError)
+	fsDelegator      = flag.NewFlagSet("", flag.ContinueOnError)
+	fsRedelegation   = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -41,6 +47,10 @@ func init() {
 	fsDescription.String(FlagIdentity, "[do-not-modify]", "optional keybase signature")
 	fsDescription.String(FlagWebsite, "[do-not-modify]", "optional website")
 	fsDescription.String(FlagDetails, "[do-not-modify]", "optional details")
+	fsCommission.String(FlagCommissionRate, "0", "commission rate charged to the delegators, ex. 0.1 for 10%")
+	fsCommission.String(FlagCommissionMaxRate, "0", "maximum commission rate which the validator can ever charge")
+	fsCommission.String(FlagCommissionMaxChangeRate, "0", "maximum daily change of the commission rate")
+	fsCommissionEdit.String(FlagCommissionRate, "", "new commission rate, left unchanged if empty")
 	fsValidator.String(FlagAddressValidator, "", "hex address of the validator")
 	fsDelegator.String(FlagAddressDelegator, "", "hex address of the delegator")
 	fsRedelegation.String(FlagAddressValidatorSrc, "", "hex address of the source validator")
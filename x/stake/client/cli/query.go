@@ -197,6 +197,39 @@ func GetCmdQueryDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
+// get the command to query all the delegations made to one validator
+func GetCmdQueryValidatorDelegations(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-delegations [owner-addr]",
+		Short: "Query all delegations made to one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			params := stake.QueryValidatorParams{ValidatorAddr: validatorAddr}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			path := fmt.Sprintf("custom/%s/%s", queryRoute, stake.QueryValidatorDelegations)
+			res, err := ctx.QueryWithData(path, bz)
+			if err != nil {
+				return err
+			}
+
+			// the querier already returns indented JSON
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	return cmd
+}
+
 // get the command to query a single unbonding-delegation record
 func GetCmdQueryUnbondingDelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
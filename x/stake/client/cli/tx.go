@@ -50,7 +50,11 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgCreateValidator(validatorAddr, pk, amount, description)
+			commission, err := getCommission()
+			if err != nil {
+				return err
+			}
+			msg := stake.NewMsgCreateValidator(validatorAddr, pk, amount, description, commission)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
@@ -64,10 +68,33 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsPk)
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().AddFlagSet(fsCommission)
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
 
+// get the commission rates of a new validator from the flags
+func getCommission() (commission stake.CommissionMsg, err error) {
+	rate, err := getRate(FlagCommissionRate)
+	if err != nil {
+		return commission, err
+	}
+	maxRate, err := getRate(FlagCommissionMaxRate)
+	if err != nil {
+		return commission, err
+	}
+	maxChangeRate, err := getRate(FlagCommissionMaxChangeRate)
+	if err != nil {
+		return commission, err
+	}
+	return stake.NewCommissionMsg(rate, maxRate, maxChangeRate), nil
+}
+
+// get a rate from a decimal flag, ex. 0.1 for 10%
+func getRate(flag string) (sdk.Rat, error) {
+	return sdk.NewRatFromDecimal(viper.GetString(flag), types.MaxBondDenominatorPrecision)
+}
+
 // create edit validator command
 func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -85,7 +112,15 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgEditValidator(validatorAddr, description)
+			var commissionRate *sdk.Rat
+			if viper.GetString(FlagCommissionRate) != "" {
+				rate, err := getRate(FlagCommissionRate)
+				if err != nil {
+					return err
+				}
+				commissionRate = &rate
+			}
+			msg := stake.NewMsgEditValidator(validatorAddr, description, commissionRate)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
@@ -100,6 +135,7 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().AddFlagSet(fsCommissionEdit)
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
@@ -255,8 +291,9 @@ func getShares(storeName string, cdc *wire.Codec, sharesAmountStr, sharesPercent
 // redelegate command
 func GetCmdCompleteRedelegate(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "complete",
-		Short: "complete redelegation",
+		Use:        "complete",
+		Short:      "complete redelegation",
+		Deprecated: "redelegations are completed automatically once they mature",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			delegatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
@@ -352,8 +389,9 @@ func GetCmdBeginUnbonding(storeName string, cdc *wire.Codec) *cobra.Command {
 // create edit validator command
 func GetCmdCompleteUnbonding(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "complete",
-		Short: "complete unbonding",
+		Use:        "complete",
+		Short:      "complete unbonding",
+		Deprecated: "unbondings are completed automatically once they mature",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			delegatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
@@ -37,6 +38,11 @@ func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec
 		"/stake/validators",
 		validatorsHandlerFn(ctx, cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{addr}/delegations",
+		validatorDelegationsHandlerFn(ctx, cdc),
+	).Methods("GET")
 }
 
 // http request handler to query a delegation
@@ -227,15 +233,11 @@ type StakeValidatorOutput struct {
 	Description        stake.Description `json:"description"`           // description terms for the validator
 	BondHeight         int64             `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16             `json:"bond_intra_tx_counter"` // block-local tx index of validator change
-	ProposerRewardPool sdk.Coins         `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
-
-	// fee related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
+	Commission            sdk.Rat `json:"commission"`              // the commission rate of fees charged to any delegators
+	CommissionMax         sdk.Rat `json:"commission_max"`          // maximum commission rate which this validator can ever charge
+	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // maximum daily change of the validator commission
+	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // commission rate change today, reset each day (UTC time)
 }
 
 func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput, error) {
@@ -259,22 +261,26 @@ func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput
 		Description:        validator.Description,
 		BondHeight:         validator.BondHeight,
 		BondIntraTxCounter: validator.BondIntraTxCounter,
-		ProposerRewardPool: validator.ProposerRewardPool,
 
 		Commission:            validator.Commission,
 		CommissionMax:         validator.CommissionMax,
 		CommissionChangeRate:  validator.CommissionChangeRate,
 		CommissionChangeToday: validator.CommissionChangeToday,
-
-		PrevBondedShares: validator.PrevBondedShares,
 	}, nil
 }
 
 // TODO bech32
-// http request handler to query list of validators
+// http request handler to query a page of the list of validators
 func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		kvs, err := ctx.QuerySubspace(cdc, stake.ValidatorsKey, storeName)
+		startKey, limit, err := client.ParsePageParams(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		kvs, nextKey, err := ctx.QuerySubspacePage(cdc, stake.ValidatorsKey, startKey, limit, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(fmt.Sprintf("couldn't query validators. Error: %s", err.Error())))
@@ -315,6 +321,42 @@ func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerF
 			return
 		}
 
+		client.SetNextKeyHeader(w, nextKey)
 		w.Write(output)
 	}
 }
+
+// http request handler to query all the delegations made to a validator
+func validatorDelegationsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		bech32validator := vars["addr"]
+
+		validatorAddr, err := sdk.GetValAddressBech32(bech32validator)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		params := stake.QueryValidatorParams{ValidatorAddr: validatorAddr}
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		path := fmt.Sprintf("custom/%s/%s", storeName, stake.QueryValidatorDelegations)
+		res, err := ctx.QueryWithData(path, bz)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query validator delegations. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(res)
+	}
+}
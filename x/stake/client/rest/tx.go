@@ -60,9 +60,9 @@ type EditDelegationsBody struct {
 	Gas                 int64                        `json:"gas"`
 	Delegations         []msgDelegationsInput        `json:"delegations"`
 	BeginUnbondings     []msgBeginUnbondingInput     `json:"begin_unbondings"`
-	CompleteUnbondings  []msgCompleteUnbondingInput  `json:"complete_unbondings"`
+	CompleteUnbondings  []msgCompleteUnbondingInput  `json:"complete_unbondings"` // deprecated, completed once mature
 	BeginRedelegates    []msgBeginRedelegateInput    `json:"begin_redelegates"`
-	CompleteRedelegates []msgCompleteRedelegateInput `json:"complete_redelegates"`
+	CompleteRedelegates []msgCompleteRedelegateInput `json:"complete_redelegates"` // deprecated, completed once mature
 }
 
 func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreContext) http.HandlerFunc {
@@ -1,6 +1,8 @@
 package stake
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -17,15 +19,10 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	keeper.InitIntraTxCounter(ctx)
 
 	for _, validator := range data.Validators {
-		keeper.SetValidator(ctx, validator)
+		keeper.CreateValidator(ctx, validator)
 
-		// Manually set indexes for the first time
-		keeper.SetValidatorByPubKeyIndex(ctx, validator)
+		// Manually set the power index for the first time
 		keeper.SetValidatorByPowerIndex(ctx, validator, data.Pool)
-
-		if validator.Status() == sdk.Bonded {
-			keeper.SetValidatorBondedIndex(ctx, validator)
-		}
 	}
 
 	for _, bond := range data.Bonds {
@@ -52,6 +49,30 @@ func WriteGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	}
 }
 
+// ValidateGenesis validates the provided staking genesis state to ensure the
+// expected invariants holds. (i.e. params in correct bounds, no duplicate validators)
+func ValidateGenesis(data types.GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	owners := make(map[string]bool, len(data.Validators))
+	for _, validator := range data.Validators {
+		owner := validator.Owner.String()
+		if owners[owner] {
+			return fmt.Errorf("duplicate validator in genesis state: owner %v", owner)
+		}
+		owners[owner] = true
+	}
+
+	for _, bond := range data.Bonds {
+		if !owners[bond.ValidatorAddr.String()] {
+			return fmt.Errorf("delegation to unknown validator in genesis state: %v", bond.ValidatorAddr)
+		}
+	}
+	return nil
+}
+
 // WriteValidators returns a slice of bonded genesis validators.
 func WriteValidators(ctx sdk.Context, keeper Keeper) (vals []tmtypes.GenesisValidator) {
 	keeper.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
@@ -0,0 +1,54 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	keep "github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	genesisState := types.DefaultGenesisState()
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	validator := types.NewValidator(keep.Addrs[0], keep.PKs[0], types.Description{})
+	genesisState.Validators = []types.Validator{validator}
+	genesisState.Bonds = []types.Delegation{{keep.Addrs[1], keep.Addrs[0], sdk.NewRat(1), 0}}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	// delegation to an unknown validator
+	badState := genesisState
+	badState.Bonds = []types.Delegation{{keep.Addrs[1], keep.Addrs[2], sdk.NewRat(1), 0}}
+	require.NotNil(t, ValidateGenesis(badState))
+
+	// duplicate validator
+	badState = genesisState
+	badState.Validators = []types.Validator{validator, validator}
+	require.NotNil(t, ValidateGenesis(badState))
+
+	// bad params
+	badState = genesisState
+	badState.Params.BondDenom = ""
+	require.NotNil(t, ValidateGenesis(badState))
+	badState = genesisState
+	badState.Params.InflationMin = sdk.NewRat(1)
+	require.NotNil(t, ValidateGenesis(badState))
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+
+	genesisState := types.DefaultGenesisState()
+	validator := types.NewValidator(keep.Addrs[0], keep.PKs[0], types.Description{})
+	genesisState.Validators = []types.Validator{validator}
+	InitGenesis(ctx, keeper, genesisState)
+
+	exported := WriteGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(exported))
+	require.True(t, genesisState.Params.Equal(exported.Params))
+	require.Equal(t, 1, len(exported.Validators))
+	require.Equal(t, validator.Owner, exported.Validators[0].Owner)
+}
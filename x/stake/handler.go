@@ -8,6 +8,8 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+const secondsPerDay = 24 * 60 * 60
+
 func NewHandler(k keeper.Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		// NOTE msg already has validate basic run
@@ -32,8 +34,9 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 	}
 }
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Validator) {
+// Called every block, process inflation, update validator set, complete
+// the mature unbonding delegations and redelegations
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Validator, endBlockerTags sdk.Tags) {
 	pool := k.GetPool(ctx)
 
 	// Process types.Validator Provisions
@@ -43,12 +46,22 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Valid
 		pool = k.ProcessProvisions(ctx)
 	}
 
+	// reset the commission changes of the validators on a new day (UTC time)
+	if blockTime/secondsPerDay > pool.DateLastCommissionReset/secondsPerDay {
+		k.ResetCommissionChangesToday(ctx)
+		pool.DateLastCommissionReset = blockTime
+	}
+
 	// save the params
 	k.SetPool(ctx, pool)
 
 	// reset the intra-transaction counter
 	k.SetIntraTxCounter(ctx, 0)
 
+	// complete the unbonding delegations and redelegations which matured
+	endBlockerTags = k.CompleteMatureUnbondings(ctx)
+	endBlockerTags = endBlockerTags.AppendTags(k.CompleteMatureRedelegations(ctx))
+
 	// calculate validator set changes
 	ValidatorUpdates = k.GetTendermintUpdates(ctx)
 	k.ClearTendermintUpdates(ctx)
@@ -72,8 +85,8 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	}
 
 	validator := NewValidator(msg.ValidatorAddr, msg.PubKey, msg.Description)
-	k.SetValidator(ctx, validator)
-	k.SetValidatorByPubKeyIndex(ctx, validator)
+	validator = validator.SetInitialCommission(msg.Commission)
+	k.CreateValidator(ctx, validator)
 
 	// move coins from the msg.Address account to a (self-delegation) delegator account
 	// the validator account and global shares are updated within here
@@ -101,19 +114,30 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 		return ErrNoValidatorFound(k.Codespace()).Result()
 	}
 
-	// replace all editable fields (clients should autofill existing values)
-	description, err := validator.Description.UpdateDescription(msg.Description)
-	if err != nil {
-		return err.Result()
+	// replace all editable fields (clients should autofill existing values),
+	// the description is left unchanged by commission only edits
+	if msg.Description != (types.Description{}) {
+		description, err := validator.Description.UpdateDescription(msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+		validator.Description = description
+	}
+
+	if msg.CommissionRate != nil {
+		var err sdk.Error
+		validator, err = validator.UpdateCommission(*msg.CommissionRate)
+		if err != nil {
+			return err.Result()
+		}
 	}
-	validator.Description = description
 
 	k.UpdateValidator(ctx, validator)
 	tags := sdk.NewTags(
 		tags.Action, tags.ActionEditValidator,
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
 	)
 	return sdk.Result{
 		Tags: tags,
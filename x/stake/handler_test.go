@@ -9,14 +9,22 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	keep "github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/tags"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
 //______________________________________________________________________
 
 func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt int64) MsgCreateValidator {
+	commission := NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	return newTestMsgCreateValidatorWithCommission(address, pubKey, amt, commission)
+}
+
+func newTestMsgCreateValidatorWithCommission(address sdk.Address, pubKey crypto.PubKey,
+	amt int64, commission CommissionMsg) MsgCreateValidator {
 	return MsgCreateValidator{
 		Description:    Description{},
+		Commission:     commission,
 		ValidatorAddr:  address,
 		PubKey:         pubKey,
 		SelfDelegation: sdk.Coin{"steak", sdk.NewInt(amt)},
@@ -139,6 +147,62 @@ func TestDuplicatesMsgCreateValidator(t *testing.T) {
 	require.False(t, got.IsOK(), "%v", got)
 }
 
+func TestEditValidatorCommission(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := keep.Addrs[0]
+
+	// max rate of 50%, the rate can change by 10% a day
+	commission := NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(1, 2), sdk.NewRat(1, 10))
+	msgCreateValidator := newTestMsgCreateValidatorWithCommission(validatorAddr, keep.PKs[0], 10, commission)
+	msgCreateValidator.Description = NewDescription("moniker", "", "", "")
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "%v", got)
+
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 10), validator.Commission))
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 2), validator.CommissionMax))
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 10), validator.CommissionChangeRate))
+	require.True(sdk.RatEq(t, sdk.ZeroRat(), validator.CommissionChangeToday))
+
+	// edits the commission only and checks the resulting rate
+	editCommission := func(rate sdk.Rat, expectPass bool, expRate sdk.Rat) {
+		msgEditValidator := NewMsgEditValidator(validatorAddr, Description{}, &rate)
+		got := handleMsgEditValidator(ctx, msgEditValidator, keeper)
+		require.Equal(t, expectPass, got.IsOK(), "%v", got)
+		validator, found := keeper.GetValidator(ctx, validatorAddr)
+		require.True(t, found)
+		require.True(sdk.RatEq(t, expRate, validator.Commission))
+		require.Equal(t, "moniker", validator.Description.Moniker)
+	}
+
+	// the rate can't go above the max rate
+	editCommission(sdk.NewRat(6, 10), false, sdk.NewRat(1, 10))
+
+	// the changes of the day can't exceed the max change rate, in either direction
+	editCommission(sdk.NewRat(15, 100), true, sdk.NewRat(15, 100))
+	editCommission(sdk.NewRat(25, 100), false, sdk.NewRat(15, 100))
+	editCommission(sdk.NewRat(1, 10), true, sdk.NewRat(1, 10))
+	editCommission(sdk.NewRat(11, 100), false, sdk.NewRat(1, 10))
+
+	// the changes are reset on the next day
+	header := ctx.BlockHeader()
+	header.Time += secondsPerDay
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	validator, found = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(sdk.RatEq(t, sdk.ZeroRat(), validator.CommissionChangeToday))
+	editCommission(sdk.NewRat(2, 10), true, sdk.NewRat(2, 10))
+
+	// the max rate still applies on the next day
+	header.Time += secondsPerDay
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	editCommission(sdk.NewRat(51, 100), false, sdk.NewRat(2, 10))
+	editCommission(sdk.NewRat(3, 10), true, sdk.NewRat(3, 10))
+}
+
 func TestIncrementsMsgDelegate(t *testing.T) {
 	initBond := int64(1000)
 	ctx, accMapper, keeper := keep.CreateTestInput(t, false, initBond)
@@ -474,6 +538,67 @@ func TestUnbondingPeriod(t *testing.T) {
 	require.True(t, got.IsOK(), "expected no error")
 }
 
+func TestEndBlockerCompletesMatureUnbondings(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, validatorAddr2, delegatorAddr := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2]
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	// create the validators and delegate to the first one
+	got := handleMsgCreateValidator(ctx, newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	got = handleMsgCreateValidator(ctx, newTestMsgCreateValidator(validatorAddr2, keep.PKs[1], 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+
+	// begin unbonding part of the delegation and redelegating the rest
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(4)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	got = handleMsgBeginRedelegate(ctx, NewMsgBeginRedelegate(delegatorAddr, validatorAddr, validatorAddr2, sdk.NewRat(6)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+
+	// nothing is completed 6 seconds later
+	origHeader := ctx.BlockHeader()
+	header := origHeader
+	header.Time += 6
+	ctx = ctx.WithBlockHeader(header)
+	_, endBlockerTags := EndBlocker(ctx, keeper)
+	require.Empty(t, endBlockerTags)
+	_, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	_, found = keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+
+	// both are completed 7 seconds later
+	header.Time = origHeader.Time + 7
+	ctx = ctx.WithBlockHeader(header)
+	_, endBlockerTags = EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags(
+		tags.Action, tags.ActionCompleteUnbonding,
+		tags.Delegator, []byte(delegatorAddr.String()),
+		tags.SrcValidator, []byte(validatorAddr.String()),
+		tags.Action, tags.ActionCompleteRedelegation,
+		tags.Delegator, []byte(delegatorAddr.String()),
+		tags.SrcValidator, []byte(validatorAddr.String()),
+		tags.DstValidator, []byte(validatorAddr2.String()),
+	), endBlockerTags)
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	_, found = keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.False(t, found)
+	require.Equal(t, int64(994), accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(params.BondDenom).Int64())
+
+	// the completed entries are out of the queues
+	header.Time += 7
+	ctx = ctx.WithBlockHeader(header)
+	_, endBlockerTags = EndBlocker(ctx, keeper)
+	require.Empty(t, endBlockerTags)
+}
+
 func TestRedelegationPeriod(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr, validatorAddr2 := keep.Addrs[0], keep.Addrs[1]
@@ -4,6 +4,7 @@ import (
 	"bytes"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/tags"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -62,6 +63,24 @@ func (k Keeper) GetDelegations(ctx sdk.Context, delegator sdk.Address,
 	return delegations[:i] // trim
 }
 
+// load all delegations made to a validator
+// NOTE: The delegations are keyed by delegator, this scans all the
+// delegations of the store, O(n) in their number. It is meant for queries,
+// not for the message handlers or the block hooks.
+func (k Keeper) GetValidatorDelegations(ctx sdk.Context, validator sdk.Address) (delegations []types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegationKey)
+
+	for ; iterator.Valid(); iterator.Next() {
+		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value())
+		if bytes.Equal(delegation.ValidatorAddr, validator) {
+			delegations = append(delegations, delegation)
+		}
+	}
+	iterator.Close()
+	return delegations
+}
+
 // set the delegation
 func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
@@ -212,6 +231,8 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 			ValidatorAddr: validator.Owner,
 			Shares:        sdk.ZeroRat(),
 		}
+	} else {
+		k.onDelegationSharesModified(ctx, delegatorAddr, validator.Owner)
 	}
 
 	// Account new shares, save
@@ -228,6 +249,9 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 
 	k.SetPool(ctx, pool)
 	k.SetDelegation(ctx, delegation)
+	if !found {
+		k.onDelegationCreated(ctx, delegatorAddr, validator.Owner)
+	}
 	k.UpdateValidator(ctx, validator)
 
 	return
@@ -257,6 +281,13 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address
 		return
 	}
 
+	// notify the hooks before the shares change
+	if delegation.Shares.Equal(shares) {
+		k.onDelegationRemoved(ctx, delegatorAddr, validatorAddr)
+	} else {
+		k.onDelegationSharesModified(ctx, delegatorAddr, validatorAddr)
+	}
+
 	// subtract shares from delegator
 	delegation.Shares = delegation.Shares.Sub(shares)
 
@@ -302,17 +333,18 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr sdk
 
 	// create the unbonding delegation
 	params := k.GetParams(ctx)
-	minTime := ctx.BlockHeader().Time + params.UnbondingTime
+	completionTime := ctx.BlockHeader().Time + params.UnbondingTime
 	balance := sdk.Coin{params.BondDenom, sdk.NewInt(returnAmount)}
 
 	ubd := types.UnbondingDelegation{
 		DelegatorAddr:  delegatorAddr,
 		ValidatorAddr:  validatorAddr,
-		MinTime:        minTime,
+		CompletionTime: completionTime,
 		Balance:        balance,
 		InitialBalance: balance,
 	}
 	k.SetUnbondingDelegation(ctx, ubd)
+	k.InsertUnbondingQueue(ctx, ubd)
 	return nil
 }
 
@@ -326,8 +358,8 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr
 
 	// ensure that enough time has passed
 	ctxTime := ctx.BlockHeader().Time
-	if ubd.MinTime > ctxTime {
-		return types.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.MinTime, ctxTime)
+	if ubd.CompletionTime > ctxTime {
+		return types.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.CompletionTime, ctxTime)
 	}
 
 	_, _, err := k.coinKeeper.AddCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
@@ -364,19 +396,20 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAd
 	}
 
 	// create the unbonding delegation
-	minTime := ctx.BlockHeader().Time + params.UnbondingTime
+	completionTime := ctx.BlockHeader().Time + params.UnbondingTime
 
 	red := types.Redelegation{
 		DelegatorAddr:    delegatorAddr,
 		ValidatorSrcAddr: validatorSrcAddr,
 		ValidatorDstAddr: validatorDstAddr,
-		MinTime:          minTime,
+		CompletionTime:   completionTime,
 		SharesDst:        sharesCreated,
 		SharesSrc:        sharesAmount,
 		Balance:          returnCoin,
 		InitialBalance:   returnCoin,
 	}
 	k.SetRedelegation(ctx, red)
+	k.InsertRedelegationQueue(ctx, red)
 	return nil
 }
 
@@ -390,10 +423,93 @@ func (k Keeper) CompleteRedelegation(ctx sdk.Context, delegatorAddr, validatorSr
 
 	// ensure that enough time has passed
 	ctxTime := ctx.BlockHeader().Time
-	if red.MinTime > ctxTime {
-		return types.ErrNotMature(k.Codespace(), "redelegation", "unit-time", red.MinTime, ctxTime)
+	if red.CompletionTime > ctxTime {
+		return types.ErrNotMature(k.Codespace(), "redelegation", "unit-time", red.CompletionTime, ctxTime)
 	}
 
 	k.RemoveRedelegation(ctx, red)
 	return nil
 }
+
+//______________________________________________________________________________________________________
+
+// insert an unbonding delegation in the queue of the unbonding delegations
+// to complete at its completion time
+func (k Keeper) InsertUnbondingQueue(ctx sdk.Context, ubd types.UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetUnbondingQueueKey(ubd.CompletionTime, ubd.DelegatorAddr, ubd.ValidatorAddr), []byte{})
+}
+
+// insert a redelegation in the queue of the redelegations to complete at its
+// completion time
+func (k Keeper) InsertRedelegationQueue(ctx sdk.Context, red types.Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetRedelegationQueueKey(red.CompletionTime, red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr), []byte{})
+}
+
+// remove and return the keys of the queue entries which completion time is
+// at most the block time
+func (k Keeper) dequeueMature(ctx sdk.Context, queueKey []byte) (keys [][]byte) {
+	store := ctx.KVStore(k.storeKey)
+	end := getQueueTimeKey(queueKey, ctx.BlockHeader().Time+1)
+	iterator := store.Iterator(queueKey, end)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return keys
+}
+
+// complete the unbonding delegations which matured, the unbonded coins are
+// sent to the delegators. Returns the tags of each completed unbonding.
+func (k Keeper) CompleteMatureUnbondings(ctx sdk.Context) sdk.Tags {
+	resTags := sdk.EmptyTags()
+	for _, queueKey := range k.dequeueMature(ctx, UnbondingQueueKey) {
+		addrs := queueKey[1+8:] // remove prefix and time bytes
+		delegatorAddr := sdk.Address(addrs[:sdk.AddrLen])
+		validatorAddr := sdk.Address(addrs[sdk.AddrLen:])
+
+		// the unbonding delegation may have been completed with a
+		// MsgCompleteUnbonding, or replaced by a later one
+		err := k.CompleteUnbonding(ctx, delegatorAddr, validatorAddr)
+		if err != nil {
+			continue
+		}
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionCompleteUnbonding,
+			tags.Delegator, []byte(delegatorAddr.String()),
+			tags.SrcValidator, []byte(validatorAddr.String()),
+		))
+	}
+	return resTags
+}
+
+// complete the redelegations which matured. Returns the tags of each
+// completed redelegation.
+func (k Keeper) CompleteMatureRedelegations(ctx sdk.Context) sdk.Tags {
+	resTags := sdk.EmptyTags()
+	for _, queueKey := range k.dequeueMature(ctx, RedelegationQueueKey) {
+		addrs := queueKey[1+8:] // remove prefix and time bytes
+		delegatorAddr := sdk.Address(addrs[:sdk.AddrLen])
+		validatorSrcAddr := sdk.Address(addrs[sdk.AddrLen : 2*sdk.AddrLen])
+		validatorDstAddr := sdk.Address(addrs[2*sdk.AddrLen:])
+
+		// the redelegation may have been completed with a
+		// MsgCompleteRedelegate, or replaced by a later one
+		err := k.CompleteRedelegation(ctx, delegatorAddr, validatorSrcAddr, validatorDstAddr)
+		if err != nil {
+			continue
+		}
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionCompleteRedelegation,
+			tags.Delegator, []byte(delegatorAddr.String()),
+			tags.SrcValidator, []byte(validatorSrcAddr.String()),
+			tags.DstValidator, []byte(validatorDstAddr.String()),
+		))
+	}
+	return resTags
+}
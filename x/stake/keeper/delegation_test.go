@@ -115,7 +115,7 @@ func TestUnbondingDelegation(t *testing.T) {
 		DelegatorAddr:  addrDels[0],
 		ValidatorAddr:  addrVals[0],
 		CreationHeight: 0,
-		MinTime:        0,
+		CompletionTime: 0,
 		Balance:        sdk.NewCoin("steak", 5),
 	}
 
@@ -188,7 +188,7 @@ func TestGetRedelegationsFromValidator(t *testing.T) {
 		ValidatorSrcAddr: addrVals[0],
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   0,
-		MinTime:          0,
+		CompletionTime:   0,
 		SharesSrc:        sdk.NewRat(5),
 		SharesDst:        sdk.NewRat(5),
 	}
@@ -218,7 +218,7 @@ func TestRedelegation(t *testing.T) {
 		ValidatorSrcAddr: addrVals[0],
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   0,
-		MinTime:          0,
+		CompletionTime:   0,
 		SharesSrc:        sdk.NewRat(5),
 		SharesDst:        sdk.NewRat(5),
 	}
@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// set the hooks called on the validator and delegation changes, the hooks
+// are shared by all the copies of the keeper and can only be set once, use
+// sdk.NewMultiStakingHooks to set the hooks of several modules
+func (k Keeper) SetHooks(sh sdk.StakingHooks) {
+	if *k.hooks != nil {
+		panic("cannot set the staking hooks twice")
+	}
+	*k.hooks = sh
+}
+
+// nolint
+func (k Keeper) onValidatorCreated(ctx sdk.Context, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnValidatorCreated(ctx, valAddr)
+	}
+}
+func (k Keeper) onValidatorBonded(ctx sdk.Context, address, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnValidatorBonded(ctx, address, valAddr)
+	}
+}
+func (k Keeper) onValidatorBeginUnbonding(ctx sdk.Context, address, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnValidatorBeginUnbonding(ctx, address, valAddr)
+	}
+}
+func (k Keeper) onValidatorRemoved(ctx sdk.Context, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnValidatorRemoved(ctx, valAddr)
+	}
+}
+func (k Keeper) onDelegationCreated(ctx sdk.Context, delAddr, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+func (k Keeper) onDelegationSharesModified(ctx sdk.Context, delAddr, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+func (k Keeper) onDelegationRemoved(ctx sdk.Context, delAddr, valAddr sdk.Address) {
+	if *k.hooks != nil {
+		(*k.hooks).OnDelegationRemoved(ctx, delAddr, valAddr)
+	}
+}
@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// records the calls of its hooks
+type recordingHooks struct {
+	calls *[]string
+}
+
+// nolint
+func (rh recordingHooks) OnValidatorCreated(_ sdk.Context, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "validatorCreated")
+}
+func (rh recordingHooks) OnValidatorBonded(_ sdk.Context, _, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "validatorBonded")
+}
+func (rh recordingHooks) OnValidatorBeginUnbonding(_ sdk.Context, _, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "validatorBeginUnbonding")
+}
+func (rh recordingHooks) OnValidatorRemoved(_ sdk.Context, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "validatorRemoved")
+}
+func (rh recordingHooks) OnDelegationCreated(_ sdk.Context, _, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "delegationCreated")
+}
+func (rh recordingHooks) OnDelegationSharesModified(_ sdk.Context, _, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "delegationSharesModified")
+}
+func (rh recordingHooks) OnDelegationRemoved(_ sdk.Context, _, _ sdk.Address) {
+	*rh.calls = append(*rh.calls, "delegationRemoved")
+}
+
+func TestStakingHooks(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	bondDenom := keeper.GetParams(ctx).BondDenom
+
+	var calls []string
+	keeper.SetHooks(recordingHooks{&calls})
+	require.Panics(t, func() { keeper.SetHooks(recordingHooks{&calls}) })
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeper.CreateValidator(ctx, validator)
+	require.Equal(t, []string{"validatorCreated"}, calls)
+
+	// the first delegation is created and bonds the validator, the
+	// following ones modify it
+	calls = nil
+	_, err := keeper.Delegate(ctx, addrVals[0], sdk.Coin{bondDenom, sdk.NewInt(10)}, validator)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	_, err = keeper.Delegate(ctx, addrVals[0], sdk.Coin{bondDenom, sdk.NewInt(10)}, validator)
+	require.Nil(t, err)
+	require.Equal(t, []string{"delegationCreated", "validatorBonded", "delegationSharesModified"}, calls)
+
+	// unbonding all the shares removes the delegation, revokes and unbonds
+	// the validator and then removes it
+	calls = nil
+	require.Nil(t, keeper.BeginUnbonding(ctx, addrVals[0], addrVals[0], sdk.NewRat(5)))
+	require.Nil(t, keeper.BeginUnbonding(ctx, addrVals[0], addrVals[0], sdk.NewRat(15)))
+	require.Equal(t, []string{"delegationSharesModified", "delegationRemoved", "validatorBeginUnbonding", "validatorRemoved"}, calls)
+}
+
+func TestMultiStakingHooks(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+
+	var first, second []string
+	keeper.SetHooks(sdk.NewMultiStakingHooks(recordingHooks{&first}, recordingHooks{&second}))
+
+	// every hook is called on all the subscribers
+	keeper.CreateValidator(ctx, types.NewValidator(addrVals[0], PKs[0], types.Description{}))
+	require.Equal(t, []string{"validatorCreated"}, first)
+	require.Equal(t, first, second)
+}
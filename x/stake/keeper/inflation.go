@@ -20,8 +20,9 @@ func (k Keeper) ProcessProvisions(ctx sdk.Context) types.Pool {
 
 	provisions := pool.Inflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat).RoundInt64()
 
-	// TODO add to the fees provisions
+	// the provisions are loose until they are distributed with the fees
 	pool.LooseTokens += provisions
+	pool.UndistributedProvisions += provisions
 	return pool
 }
 
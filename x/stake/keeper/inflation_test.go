@@ -94,6 +94,9 @@ func TestProcessProvisions(t *testing.T) {
 	//get the pool and do the final value checks from checkFinalPoolValues
 	pool = keeper.GetPool(ctx)
 	checkFinalPoolValues(t, pool, initialTotalTokens, cumulativeExpProvs)
+
+	// the provisions are waiting for the fee distribution
+	require.Equal(t, cumulativeExpProvs, pool.UndistributedProvisions)
 }
 
 // Tests that the hourly rate of change of inflation will be positive, negative, or zero, depending on bonded ratio and inflation rate
@@ -0,0 +1,111 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// RegisterInvariants registers the stake invariants under the given
+// module name, the holders report the bond tokens held outside of the
+// accounts by the other modules
+func RegisterInvariants(ir sdk.InvariantRouter, moduleName string, k Keeper, am auth.AccountMapper, holders ...TokenHolder) {
+	ir.RegisterRoute(moduleName, "supply", SupplyInvariant(k, am, holders...))
+	ir.RegisterRoute(moduleName, "pool-shares", PoolSharesInvariant(k))
+	ir.RegisterRoute(moduleName, "delegator-shares", DelegatorSharesInvariant(k))
+}
+
+// TokenHolder returns the amount of a denom held by a module outside of
+// the accounts, such as the collected fees or the proposal deposits. The
+// amount is fractional for the modules which account rewards by share.
+type TokenHolder func(ctx sdk.Context, denom string) sdk.Rat
+
+// SupplyInvariant checks that the loose tokens of the pool equal the bond
+// denom held by the accounts, the unbonding delegations, the provisions not
+// yet distributed and the holders. The fractional holdings are rounded to
+// whole tokens.
+func SupplyInvariant(k Keeper, am auth.AccountMapper, holders ...TokenHolder) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		pool := k.GetPool(ctx)
+		bondDenom := k.GetParams(ctx).BondDenom
+
+		loose := sdk.NewRat(pool.UndistributedProvisions)
+		am.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+			loose = loose.Add(sdk.NewRatFromInt(acc.GetCoins().AmountOf(bondDenom)))
+			return false
+		})
+
+		store := ctx.KVStore(k.storeKey)
+		iterator := sdk.KVStorePrefixIterator(store, UnbondingDelegationKey)
+		for ; iterator.Valid(); iterator.Next() {
+			ubd := types.MustUnmarshalUBD(k.cdc, iterator.Key(), iterator.Value())
+			loose = loose.Add(sdk.NewRatFromInt(ubd.Balance.Amount))
+		}
+		iterator.Close()
+
+		for _, holder := range holders {
+			loose = loose.Add(holder(ctx, bondDenom))
+		}
+
+		if loose.RoundInt64() != pool.LooseTokens {
+			return fmt.Errorf("loose tokens of the pool (%d) differ from the tokens of the accounts, "+
+				"unbonding delegations, undistributed provisions and other holders (%v)", pool.LooseTokens, loose.FloatString())
+		}
+		return nil
+	}
+}
+
+// PoolSharesInvariant checks that the shares of the pool, for each bond
+// status, equal the sum of the pool shares of the validators
+func PoolSharesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		pool := k.GetPool(ctx)
+
+		bonded, unbonding, unbonded := sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat()
+		for _, validator := range k.GetAllValidators(ctx) {
+			bonded = bonded.Add(validator.PoolShares.Bonded())
+			unbonding = unbonding.Add(validator.PoolShares.Unbonding())
+			unbonded = unbonded.Add(validator.PoolShares.Unbonded())
+		}
+
+		switch {
+		case !pool.BondedShares.Equal(bonded):
+			return fmt.Errorf("bonded shares of the pool (%v) differ from the validators (%v)", pool.BondedShares, bonded)
+		case !pool.UnbondingShares.Equal(unbonding):
+			return fmt.Errorf("unbonding shares of the pool (%v) differ from the validators (%v)", pool.UnbondingShares, unbonding)
+		case !pool.UnbondedShares.Equal(unbonded):
+			return fmt.Errorf("unbonded shares of the pool (%v) differ from the validators (%v)", pool.UnbondedShares, unbonded)
+		}
+		return nil
+	}
+}
+
+// DelegatorSharesInvariant checks that the delegator shares of every
+// validator equal the sum of the shares of its delegations
+func DelegatorSharesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		delegatorShares := make(map[string]sdk.Rat)
+		for _, delegation := range k.GetAllDelegations(ctx) {
+			validatorAddr := delegation.ValidatorAddr.String()
+			shares, ok := delegatorShares[validatorAddr]
+			if !ok {
+				shares = sdk.ZeroRat()
+			}
+			delegatorShares[validatorAddr] = shares.Add(delegation.Shares)
+		}
+
+		for _, validator := range k.GetAllValidators(ctx) {
+			shares, ok := delegatorShares[validator.Owner.String()]
+			if !ok {
+				shares = sdk.ZeroRat()
+			}
+			if !validator.DelegatorShares.Equal(shares) {
+				return fmt.Errorf("delegator shares of validator %v (%v) differ from the sum of its delegations (%v)",
+					validator.Owner, validator.DelegatorShares, shares)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestInvariants(t *testing.T) {
+	ctx, am, keeper := CreateTestInput(t, false, 1000)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeper.SetValidator(ctx, validator)
+	_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin("steak", 100), validator)
+	require.Nil(t, err)
+	require.Nil(t, keeper.BeginUnbonding(ctx, addrDels[0], addrVals[0], sdk.NewRat(10)))
+
+	require.Nil(t, SupplyInvariant(keeper, am)(ctx))
+	require.Nil(t, PoolSharesInvariant(keeper)(ctx))
+	require.Nil(t, DelegatorSharesInvariant(keeper)(ctx))
+
+	// tokens out of thin air
+	cacheCtx, _ := ctx.CacheContext()
+	_, _, err = keeper.coinKeeper.AddCoins(cacheCtx, addrDels[1], sdk.Coins{sdk.NewCoin("steak", 1)})
+	require.Nil(t, err)
+	require.NotNil(t, SupplyInvariant(keeper, am)(cacheCtx))
+
+	// loose tokens out of thin air
+	cacheCtx, _ = ctx.CacheContext()
+	pool := keeper.GetPool(cacheCtx)
+	pool.LooseTokens += 5
+	keeper.SetPool(cacheCtx, pool)
+	require.NotNil(t, SupplyInvariant(keeper, am)(cacheCtx))
+
+	// unless they are held by another module, to the whole token
+	held := func(_ sdk.Context, denom string) sdk.Rat {
+		if denom != "steak" {
+			return sdk.NewRat(1000)
+		}
+		return sdk.NewRat(99999, 20000)
+	}
+	require.Nil(t, SupplyInvariant(keeper, am, held)(cacheCtx))
+
+	// burned tokens are removed from the loose tokens
+	cacheCtx, _ = ctx.CacheContext()
+	burned := sdk.Coins{sdk.NewCoin("photon", 10), sdk.NewCoin("steak", 10)}
+	_, _, err = keeper.coinKeeper.SubtractCoins(cacheCtx, addrDels[1], sdk.Coins{sdk.NewCoin("steak", 10)})
+	require.Nil(t, err)
+	require.NotNil(t, SupplyInvariant(keeper, am)(cacheCtx))
+	keeper.BurnLooseTokens(cacheCtx, burned)
+	require.Nil(t, SupplyInvariant(keeper, am)(cacheCtx))
+
+	// pool shares out of sync with the validators
+	cacheCtx, _ = ctx.CacheContext()
+	pool = keeper.GetPool(cacheCtx)
+	pool.UnbondedShares = pool.UnbondedShares.Add(sdk.OneRat())
+	keeper.SetPool(cacheCtx, pool)
+	require.NotNil(t, PoolSharesInvariant(keeper)(cacheCtx))
+
+	// delegator shares out of sync with the delegations
+	cacheCtx, _ = ctx.CacheContext()
+	validator, _ = keeper.GetValidator(cacheCtx, addrVals[0])
+	validator.DelegatorShares = validator.DelegatorShares.Add(sdk.OneRat())
+	keeper.SetValidator(cacheCtx, validator)
+	require.NotNil(t, DelegatorSharesInvariant(keeper)(cacheCtx))
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -13,16 +14,20 @@ type Keeper struct {
 	storeKey   sdk.StoreKey
 	cdc        *wire.Codec
 	coinKeeper bank.Keeper
+	paramstore params.Subspace
+	hooks      *sdk.StakingHooks // shared by the copies of the keeper
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, paramstore params.Subspace, codespace sdk.CodespaceType) Keeper {
 	keeper := Keeper{
 		storeKey:   key,
 		cdc:        cdc,
 		coinKeeper: ck,
+		paramstore: paramstore.WithKeyTable(ParamKeyTable()),
+		hooks:      new(sdk.StakingHooks),
 		codespace:  codespace,
 	}
 	return keeper
@@ -38,16 +43,22 @@ func (k Keeper) Codespace() sdk.CodespaceType {
 //_________________________________________________________________________
 // some generic reads/writes that don't need their own files
 
-// load/save the global staking params
-func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	store := ctx.KVStore(k.storeKey)
-
-	b := store.Get(ParamKey)
-	if b == nil {
-		panic("Stored params should not have been nil")
-	}
+// ParamKeyTable registers the types of the staking parameters and their
+// validation
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().
+		RegisterParamSet(&types.Params{}).
+		RegisterValidation(func(ctx sdk.Context, space params.Subspace) error {
+			var p types.Params
+			space.GetParamSet(ctx, &p)
+			return p.Validate()
+		})
+}
 
-	k.cdc.MustUnmarshalBinary(b, &params)
+// load/save the global staking params, kept in the stake subspace of the
+// params store
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramstore.GetParamSet(ctx, &params)
 	return
 }
 
@@ -56,22 +67,18 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 // panic on retrieval if it doesn't exist - hence if we use setParams for the very
 // first params set it will panic.
 func (k Keeper) SetNewParams(ctx sdk.Context, params types.Params) {
-	store := ctx.KVStore(k.storeKey)
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
+	k.paramstore.SetParamSet(ctx, &params)
 }
 
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
-	store := ctx.KVStore(k.storeKey)
 	exParams := k.GetParams(ctx)
 
 	// if max validator count changes, must recalculate validator set
 	if exParams.MaxValidators != params.MaxValidators {
 		k.UpdateBondedValidatorsFull(ctx)
 	}
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
+	k.paramstore.SetParamSet(ctx, &params)
 }
 
 //_______________________________________________________________________
@@ -94,6 +101,20 @@ func (k Keeper) SetPool(ctx sdk.Context, pool types.Pool) {
 	store.Set(PoolKey, b)
 }
 
+var _ sdk.TokenBurner = Keeper{}
+
+// BurnLooseTokens removes the bond denom of the coins burned by another
+// module from the loose tokens of the pool
+func (k Keeper) BurnLooseTokens(ctx sdk.Context, coins sdk.Coins) {
+	burned := coins.AmountOf(k.GetParams(ctx).BondDenom)
+	if burned.IsZero() {
+		return
+	}
+	pool := k.GetPool(ctx)
+	pool.LooseTokens -= burned.Int64()
+	k.SetPool(ctx, pool)
+}
+
 //__________________________________________________________________________
 
 // get the current in-block validator operation counter
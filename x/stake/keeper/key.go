@@ -14,7 +14,6 @@ import (
 //nolint
 var (
 	// Keys for store prefixes
-	ParamKey                         = []byte{0x00} // key for parameters relating to staking
 	PoolKey                          = []byte{0x01} // key for the staking pools
 	ValidatorsKey                    = []byte{0x02} // prefix for each key to a validator
 	ValidatorsByPubKeyIndexKey       = []byte{0x03} // prefix for each key to a validator index, by pubkey
@@ -30,6 +29,8 @@ var (
 	RedelegationKey                  = []byte{0x0D} // key for a redelegation
 	RedelegationByValSrcIndexKey     = []byte{0x0E} // prefix for each key for an redelegation, by source validator owner
 	RedelegationByValDstIndexKey     = []byte{0x0F} // prefix for each key for an redelegation, by destination validator owner
+	UnbondingQueueKey                = []byte{0x10} // prefix for the unbonding delegations queue, by completion time
+	RedelegationQueueKey             = []byte{0x11} // prefix for the redelegations queue, by completion time
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -237,3 +238,34 @@ func GetREDsByDelToValDstIndexKey(delegatorAddr sdk.Address,
 		GetREDsToValDstIndexKey(validatorDstAddr),
 		delegatorAddr.Bytes()...)
 }
+
+//________________________________________________________________________________
+
+// get the prefix of the queue entries completing at a time, the big endian
+// time orders the queue by completion time
+func getQueueTimeKey(queueKey []byte, completionTime int64) []byte {
+	timeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timeBytes, uint64(completionTime))
+	return append(queueKey, timeBytes...)
+}
+
+// get the key for an unbonding delegation in the unbonding queue
+// VALUE: none (key rearrangement used)
+func GetUnbondingQueueKey(completionTime int64, delegatorAddr, validatorAddr sdk.Address) []byte {
+	return append(append(
+		getQueueTimeKey(UnbondingQueueKey, completionTime),
+		delegatorAddr.Bytes()...),
+		validatorAddr.Bytes()...)
+}
+
+// get the key for a redelegation in the redelegation queue
+// VALUE: none (key rearrangement used)
+func GetRedelegationQueueKey(completionTime int64, delegatorAddr, validatorSrcAddr,
+	validatorDstAddr sdk.Address) []byte {
+
+	return append(append(append(
+		getQueueTimeKey(RedelegationQueueKey, completionTime),
+		delegatorAddr.Bytes()...),
+		validatorSrcAddr.Bytes()...),
+		validatorDstAddr.Bytes()...)
+}
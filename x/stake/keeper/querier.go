@@ -0,0 +1,218 @@
+package keeper
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// query endpoints supported by the stake Querier
+const (
+	QueryValidators                    = "validators"
+	QueryValidator                     = "validator"
+	QueryDelegatorDelegations          = "delegatorDelegations"
+	QueryDelegatorUnbondingDelegations = "delegatorUnbondingDelegations"
+	QueryDelegatorRedelegations        = "delegatorRedelegations"
+	QueryValidatorDelegations          = "validatorDelegations"
+	QueryValidatorUnbondingDelegations = "validatorUnbondingDelegations"
+	QueryValidatorRedelegations        = "validatorRedelegations"
+	QueryDelegation                    = "delegation"
+	QueryUnbondingDelegation           = "unbondingDelegation"
+	QueryPool                          = "pool"
+	QueryParameters                    = "parameters"
+)
+
+// defines the params for the following queries:
+// - 'custom/stake/delegatorDelegations'
+// - 'custom/stake/delegatorUnbondingDelegations'
+// - 'custom/stake/delegatorRedelegations'
+type QueryDelegatorParams struct {
+	DelegatorAddr sdk.Address
+}
+
+// defines the params for the following queries:
+// - 'custom/stake/validator'
+// - 'custom/stake/validatorDelegations'
+// - 'custom/stake/validatorUnbondingDelegations'
+// - 'custom/stake/validatorRedelegations'
+type QueryValidatorParams struct {
+	ValidatorAddr sdk.Address
+}
+
+// defines the params for the following queries:
+// - 'custom/stake/delegation'
+// - 'custom/stake/unbondingDelegation'
+type QueryBondsParams struct {
+	DelegatorAddr sdk.Address
+	ValidatorAddr sdk.Address
+}
+
+// creates a querier for stake REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no stake query endpoint specified")
+		}
+		switch path[0] {
+		case QueryValidators:
+			return queryValidators(ctx, k)
+		case QueryValidator:
+			return queryValidator(ctx, req, k)
+		case QueryDelegatorDelegations:
+			return queryDelegatorDelegations(ctx, req, k)
+		case QueryDelegatorUnbondingDelegations:
+			return queryDelegatorUnbondingDelegations(ctx, req, k)
+		case QueryDelegatorRedelegations:
+			return queryDelegatorRedelegations(ctx, req, k)
+		case QueryValidatorDelegations:
+			return queryValidatorDelegations(ctx, req, k)
+		case QueryValidatorUnbondingDelegations:
+			return queryValidatorUnbondingDelegations(ctx, req, k)
+		case QueryValidatorRedelegations:
+			return queryValidatorRedelegations(ctx, req, k)
+		case QueryDelegation:
+			return queryDelegation(ctx, req, k)
+		case QueryUnbondingDelegation:
+			return queryUnbondingDelegation(ctx, req, k)
+		case QueryPool:
+			return queryPool(ctx, k)
+		case QueryParameters:
+			return queryParameters(ctx, k)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown stake query endpoint %s", path[0]))
+		}
+	}
+}
+
+func queryValidators(ctx sdk.Context, k Keeper) (res []byte, err sdk.Error) {
+	validators := k.GetAllValidators(ctx)
+	return sdk.MarshalQueryResult(k.cdc, validators)
+}
+
+func queryValidator(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryValidatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	validator, found := k.GetValidator(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound(k.codespace)
+	}
+	return sdk.MarshalQueryResult(k.cdc, validator)
+}
+
+func queryDelegatorDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDelegatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	var delegations []types.Delegation
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationsKey(params.DelegatorAddr))
+	for ; iterator.Valid(); iterator.Next() {
+		delegations = append(delegations, types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value()))
+	}
+	iterator.Close()
+	return sdk.MarshalQueryResult(k.cdc, delegations)
+}
+
+func queryDelegatorUnbondingDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDelegatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	var ubds []types.UnbondingDelegation
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetUBDsKey(params.DelegatorAddr))
+	for ; iterator.Valid(); iterator.Next() {
+		ubds = append(ubds, types.MustUnmarshalUBD(k.cdc, iterator.Key(), iterator.Value()))
+	}
+	iterator.Close()
+	return sdk.MarshalQueryResult(k.cdc, ubds)
+}
+
+func queryDelegatorRedelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDelegatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	var reds []types.Redelegation
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetREDsKey(params.DelegatorAddr))
+	for ; iterator.Valid(); iterator.Next() {
+		reds = append(reds, types.MustUnmarshalRED(k.cdc, iterator.Key(), iterator.Value()))
+	}
+	iterator.Close()
+	return sdk.MarshalQueryResult(k.cdc, reds)
+}
+
+func queryValidatorDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryValidatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	delegations := k.GetValidatorDelegations(ctx, params.ValidatorAddr)
+	return sdk.MarshalQueryResult(k.cdc, delegations)
+}
+
+func queryValidatorUnbondingDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryValidatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	ubds := k.GetUnbondingDelegationsFromValidator(ctx, params.ValidatorAddr)
+	return sdk.MarshalQueryResult(k.cdc, ubds)
+}
+
+func queryValidatorRedelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryValidatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	reds := k.GetRedelegationsFromValidator(ctx, params.ValidatorAddr)
+	return sdk.MarshalQueryResult(k.cdc, reds)
+}
+
+func queryDelegation(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryBondsParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	delegation, found := k.GetDelegation(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoDelegation(k.codespace)
+	}
+	return sdk.MarshalQueryResult(k.cdc, delegation)
+}
+
+func queryUnbondingDelegation(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryBondsParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	ubd, found := k.GetUnbondingDelegation(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoUnbondingDelegation(k.codespace)
+	}
+	return sdk.MarshalQueryResult(k.cdc, ubd)
+}
+
+func queryPool(ctx sdk.Context, k Keeper) (res []byte, err sdk.Error) {
+	return sdk.MarshalQueryResult(k.cdc, k.GetPool(ctx))
+}
+
+func queryParameters(ctx sdk.Context, k Keeper) (res []byte, err sdk.Error) {
+	return sdk.MarshalQueryResult(k.cdc, k.GetParams(ctx))
+}
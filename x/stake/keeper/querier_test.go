@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestQueryValidatorDelegations(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 10)
+	querier := NewQuerier(keeper)
+
+	bond1to1 := types.Delegation{addrDels[0], addrVals[0], sdk.NewRat(9), 0}
+	bond1to2 := types.Delegation{addrDels[0], addrVals[1], sdk.NewRat(9), 1}
+	bond2to1 := types.Delegation{addrDels[1], addrVals[0], sdk.NewRat(9), 2}
+	keeper.SetDelegation(ctx, bond1to1)
+	keeper.SetDelegation(ctx, bond1to2)
+	keeper.SetDelegation(ctx, bond2to1)
+
+	bz, err := keeper.cdc.MarshalJSON(QueryValidatorParams{ValidatorAddr: addrVals[0]})
+	require.Nil(t, err)
+	req := abci.RequestQuery{Path: "/custom/stake/validatorDelegations", Data: bz}
+
+	res, sdkErr := querier(ctx, []string{QueryValidatorDelegations}, req)
+	require.Nil(t, sdkErr)
+
+	var delegations []types.Delegation
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &delegations))
+	require.Equal(t, 2, len(delegations))
+	require.True(t, bond1to1.Equal(delegations[0]))
+	require.True(t, bond2to1.Equal(delegations[1]))
+
+	// delegations from the delegator side
+	bz, err = keeper.cdc.MarshalJSON(QueryDelegatorParams{DelegatorAddr: addrDels[0]})
+	require.Nil(t, err)
+	req = abci.RequestQuery{Path: "/custom/stake/delegatorDelegations", Data: bz}
+
+	res, sdkErr = querier(ctx, []string{QueryDelegatorDelegations}, req)
+	require.Nil(t, sdkErr)
+	delegations = nil
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &delegations))
+	require.Equal(t, 2, len(delegations))
+	require.True(t, bond1to1.Equal(delegations[0]))
+	require.True(t, bond1to2.Equal(delegations[1]))
+}
+
+func TestQueryErrors(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 10)
+	querier := NewQuerier(keeper)
+
+	// unknown endpoint
+	_, err := querier(ctx, []string{"foo"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// malformed params
+	_, err = querier(ctx, []string{QueryValidator}, abci.RequestQuery{Data: []byte("foo")})
+	require.NotNil(t, err)
+
+	// validator which doesn't exist
+	bz, jsonErr := keeper.cdc.MarshalJSON(QueryValidatorParams{ValidatorAddr: addrVals[0]})
+	require.Nil(t, jsonErr)
+	_, err = querier(ctx, []string{QueryValidator}, abci.RequestQuery{Data: bz})
+	require.Equal(t, types.CodeInvalidValidator, err.Code())
+}
@@ -143,9 +143,9 @@ func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation ty
 		return sdk.ZeroInt()
 	}
 
-	if unbondingDelegation.MinTime < now {
-		// Unbonding delegation no longer eligible for slashing, skip it
-		// TODO Settle and delete it automatically?
+	if unbondingDelegation.CompletionTime < now {
+		// Unbonding delegation no longer eligible for slashing, skip it,
+		// it is completed in the EndBlocker
 		return sdk.ZeroInt()
 	}
 
@@ -188,9 +188,9 @@ func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, re
 		return sdk.ZeroInt()
 	}
 
-	if redelegation.MinTime < now {
-		// Redelegation no longer eligible for slashing, skip it
-		// TODO Delete it automatically?
+	if redelegation.CompletionTime < now {
+		// Redelegation no longer eligible for slashing, skip it,
+		// it is completed in the EndBlocker
 		return sdk.ZeroInt()
 	}
 
@@ -69,7 +69,7 @@ func TestSlashUnbondingDelegation(t *testing.T) {
 		ValidatorAddr:  addrVals[0],
 		CreationHeight: 0,
 		// expiration timestamp (beyond which the unbonding delegation shouldn't be slashed)
-		MinTime:        0,
+		CompletionTime: 0,
 		InitialBalance: sdk.NewCoin(params.BondDenom, 10),
 		Balance:        sdk.NewCoin(params.BondDenom, 10),
 	}
@@ -113,7 +113,7 @@ func TestSlashRedelegation(t *testing.T) {
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   0,
 		// expiration timestamp (beyond which the redelegation shouldn't be slashed)
-		MinTime:        0,
+		CompletionTime: 0,
 		SharesSrc:      sdk.NewRat(10),
 		SharesDst:      sdk.NewRat(10),
 		InitialBalance: sdk.NewCoin(params.BondDenom, 10),
@@ -208,7 +208,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 		ValidatorAddr:  addrVals[0],
 		CreationHeight: 11,
 		// expiration timestamp (beyond which the unbonding delegation shouldn't be slashed)
-		MinTime:        0,
+		CompletionTime: 0,
 		InitialBalance: sdk.NewCoin(params.BondDenom, 4),
 		Balance:        sdk.NewCoin(params.BondDenom, 4),
 	}
@@ -309,7 +309,7 @@ func TestSlashWithRedelegation(t *testing.T) {
 		ValidatorSrcAddr: addrVals[0],
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   11,
-		MinTime:          0,
+		CompletionTime:   0,
 		SharesSrc:        sdk.NewRat(6),
 		SharesDst:        sdk.NewRat(6),
 		InitialBalance:   sdk.NewCoin(params.BondDenom, 6),
@@ -427,7 +427,7 @@ func TestSlashBoth(t *testing.T) {
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   11,
 		// expiration timestamp (beyond which the redelegation shouldn't be slashed)
-		MinTime:        0,
+		CompletionTime: 0,
 		SharesSrc:      sdk.NewRat(6),
 		SharesDst:      sdk.NewRat(6),
 		InitialBalance: sdk.NewCoin(params.BondDenom, 6),
@@ -449,7 +449,7 @@ func TestSlashBoth(t *testing.T) {
 		ValidatorAddr:  addrVals[0],
 		CreationHeight: 11,
 		// expiration timestamp (beyond which the unbonding delegation shouldn't be slashed)
-		MinTime:        0,
+		CompletionTime: 0,
 		InitialBalance: sdk.NewCoin(params.BondDenom, 4),
 		Balance:        sdk.NewCoin(params.BondDenom, 4),
 	}
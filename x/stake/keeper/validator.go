@@ -57,6 +57,20 @@ func (k Keeper) SetValidatorBondedIndex(ctx sdk.Context, validator types.Validat
 	store.Set(GetValidatorsBondedIndexKey(validator.Owner), []byte{})
 }
 
+// set a new validator along with its pubkey index and call the validator
+// created hook, validators which are already bonded (at genesis) also get
+// their bonded index and call the bonded hook
+func (k Keeper) CreateValidator(ctx sdk.Context, validator types.Validator) {
+	k.SetValidator(ctx, validator)
+	k.SetValidatorByPubKeyIndex(ctx, validator)
+	k.onValidatorCreated(ctx, validator.Owner)
+
+	if validator.Status() == sdk.Bonded {
+		k.SetValidatorBondedIndex(ctx, validator)
+		k.onValidatorBonded(ctx, validator.PubKey.Address(), validator.Owner)
+	}
+}
+
 // used in testing
 func (k Keeper) validatorByPowerIndexExists(ctx sdk.Context, power []byte) bool {
 	store := ctx.KVStore(k.storeKey)
@@ -82,6 +96,17 @@ func (k Keeper) GetAllValidators(ctx sdk.Context) (validators []types.Validator)
 	return validators
 }
 
+// reset the commission changes of the day of all the validators
+func (k Keeper) ResetCommissionChangesToday(ctx sdk.Context) {
+	for _, validator := range k.GetAllValidators(ctx) {
+		if validator.CommissionChangeToday.IsZero() {
+			continue
+		}
+		validator.CommissionChangeToday = sdk.ZeroRat()
+		k.SetValidator(ctx, validator)
+	}
+}
+
 // Get the set of all validators, retrieve a maxRetrieve number of records
 func (k Keeper) GetValidators(ctx sdk.Context, maxRetrieve int16) (validators []types.Validator) {
 	store := ctx.KVStore(k.storeKey)
@@ -444,6 +469,8 @@ func (k Keeper) unbondValidator(ctx sdk.Context, validator types.Validator) type
 
 	// also remove from the Bonded types.Validators Store
 	store.Delete(GetValidatorsBondedIndexKey(validator.Owner))
+
+	k.onValidatorBeginUnbonding(ctx, validator.PubKey.Address(), validator.Owner)
 	return validator
 }
 
@@ -470,6 +497,7 @@ func (k Keeper) bondValidator(ctx sdk.Context, validator types.Validator) types.
 	bzABCI := k.cdc.MustMarshalBinary(validator.ABCIValidator())
 	store.Set(GetTendermintUpdatesKey(validator.Owner), bzABCI)
 
+	k.onValidatorBonded(ctx, validator.PubKey.Address(), validator.Owner)
 	return validator
 }
 
@@ -481,6 +509,7 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.Address) {
 	if !found {
 		return
 	}
+	k.onValidatorRemoved(ctx, address)
 
 	// delete the old validator record
 	store := ctx.KVStore(k.storeKey)
@@ -0,0 +1,89 @@
+package stake
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// name of the stake module, used for its routes and genesis state
+const ModuleName = "stake"
+
+// default name of the stake subspace of the params store
+const DefaultParamspace = ModuleName
+
+// AppModule implements module.AppModule for the stake module
+type AppModule struct {
+	keeper        Keeper
+	accountMapper auth.AccountMapper // for the supply invariant
+	holders       []TokenHolder      // for the supply invariant
+}
+
+// NewAppModule creates a new AppModule, the holders report the bond tokens
+// held outside of the accounts by the other modules of the app
+func NewAppModule(k Keeper, accountMapper auth.AccountMapper, holders ...TokenHolder) AppModule {
+	return AppModule{keeper: k, accountMapper: accountMapper, holders: holders}
+}
+
+// nolint
+func (AppModule) Name() string                      { return ModuleName }
+func (AppModule) Route() string                     { return ModuleName }
+func (am AppModule) NewHandler() sdk.Handler        { return NewHandler(am.keeper) }
+func (AppModule) QuerierRoute() string              { return ModuleName }
+func (am AppModule) NewQuerierHandler() sdk.Querier { return NewQuerier(am.keeper) }
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter) {
+	keeper.RegisterInvariants(ir, ModuleName, am.keeper, am.accountMapper, am.holders...)
+}
+
+// DefaultGenesis returns the default stake genesis state
+func (AppModule) DefaultGenesis() json.RawMessage {
+	return mustMarshalGenesis(DefaultGenesisState())
+}
+
+// ValidateGenesis validates the stake genesis state
+func (AppModule) ValidateGenesis(data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := types.MsgCdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	return ValidateGenesis(genesisState)
+}
+
+// InitGenesis initializes the stake state from its genesis state
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := types.MsgCdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	InitGenesis(ctx, am.keeper, genesisState)
+	return nil
+}
+
+// ExportGenesis exports the stake state as its genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return mustMarshalGenesis(WriteGenesis(ctx, am.keeper))
+}
+
+// BeginBlock is a no-op for the stake module
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return nil
+}
+
+// EndBlock processes inflation, completes the mature unbondings and returns
+// the validator set changes
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return EndBlocker(ctx, am.keeper)
+}
+
+func mustMarshalGenesis(genesisState GenesisState) json.RawMessage {
+	bz, err := types.MsgCdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
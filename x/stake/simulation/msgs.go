@@ -0,0 +1,213 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// SimulateMsgCreateValidator creates a validator for a random account, with
+// a random self delegation. The account key is the validator key, so that
+// validators have distinct keys.
+func SimulateMsgCreateValidator(m auth.AccountMapper, k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		acc := simulation.RandomAcc(r, accs)
+		bond, ok := randomBond(r, ctx, m, k, acc.Address)
+		if !ok {
+			return simulation.Noop("stake/MsgCreateValidator", fmt.Sprintf("account %v has no tokens to bond", acc.Address), event)
+		}
+
+		msg := stake.NewMsgCreateValidator(acc.Address, acc.PubKey, bond, randomDescription(r), randomCommission(r))
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgCreateValidator", event)
+	}
+}
+
+// SimulateMsgEditValidator gives a random description and commission rate to
+// a random validator, the change of rate may exceed the daily limit
+func SimulateMsgEditValidator(k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		validator, ok := randomValidator(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgEditValidator", "no validators", event)
+		}
+
+		rate := randomRate(r, validator.CommissionMax)
+		msg := stake.NewMsgEditValidator(validator.Owner, randomDescription(r), &rate)
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgEditValidator", event)
+	}
+}
+
+// SimulateMsgDelegate delegates a random amount of a random account to a
+// random validator
+func SimulateMsgDelegate(m auth.AccountMapper, k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		validator, ok := randomValidator(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgDelegate", "no validators", event)
+		}
+		acc := simulation.RandomAcc(r, accs)
+		bond, ok := randomBond(r, ctx, m, k, acc.Address)
+		if !ok {
+			return simulation.Noop("stake/MsgDelegate", fmt.Sprintf("account %v has no tokens to bond", acc.Address), event)
+		}
+
+		msg := stake.NewMsgDelegate(acc.Address, validator.Owner, bond)
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgDelegate", event)
+	}
+}
+
+// SimulateMsgBeginUnbonding unbonds a random amount of shares of a random
+// delegation
+func SimulateMsgBeginUnbonding(k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		delegation, ok := randomDelegation(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgBeginUnbonding", "no delegations", event)
+		}
+		shares, ok := randomShares(r, delegation)
+		if !ok {
+			return simulation.Noop("stake/MsgBeginUnbonding", "random shares are zero", event)
+		}
+
+		msg := stake.NewMsgBeginUnbonding(delegation.DelegatorAddr, delegation.ValidatorAddr, shares)
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgBeginUnbonding", event)
+	}
+}
+
+// SimulateMsgCompleteUnbonding completes a random unbonding delegation of a
+// random validator, which fails if it isn't mature yet
+func SimulateMsgCompleteUnbonding(k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		validator, ok := randomValidator(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgCompleteUnbonding", "no validators", event)
+		}
+		ubds := k.GetUnbondingDelegationsFromValidator(ctx, validator.Owner)
+		if len(ubds) == 0 {
+			return simulation.Noop("stake/MsgCompleteUnbonding", fmt.Sprintf("validator %v has no unbonding delegations", validator.Owner), event)
+		}
+		ubd := ubds[r.Intn(len(ubds))]
+
+		msg := stake.NewMsgCompleteUnbonding(ubd.DelegatorAddr, ubd.ValidatorAddr)
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgCompleteUnbonding", event)
+	}
+}
+
+// SimulateMsgBeginRedelegate redelegates a random amount of shares of a
+// random delegation to a random validator
+func SimulateMsgBeginRedelegate(k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		delegation, ok := randomDelegation(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgBeginRedelegate", "no delegations", event)
+		}
+		dstValidator, ok := randomValidator(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgBeginRedelegate", "no validators", event)
+		}
+		shares, ok := randomShares(r, delegation)
+		if !ok {
+			return simulation.Noop("stake/MsgBeginRedelegate", "random shares are zero", event)
+		}
+
+		msg := stake.NewMsgBeginRedelegate(delegation.DelegatorAddr, delegation.ValidatorAddr, dstValidator.Owner, shares)
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgBeginRedelegate", event)
+	}
+}
+
+// SimulateMsgCompleteRedelegate completes a random redelegation from a
+// random validator, which fails if it isn't mature yet
+func SimulateMsgCompleteRedelegate(k stake.Keeper) simulation.Operation {
+	handler := stake.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (action string, err error) {
+
+		validator, ok := randomValidator(r, ctx, k)
+		if !ok {
+			return simulation.Noop("stake/MsgCompleteRedelegate", "no validators", event)
+		}
+		reds := k.GetRedelegationsFromValidator(ctx, validator.Owner)
+		if len(reds) == 0 {
+			return simulation.Noop("stake/MsgCompleteRedelegate", fmt.Sprintf("validator %v has no redelegations", validator.Owner), event)
+		}
+		red := reds[r.Intn(len(reds))]
+
+		msg := stake.NewMsgCompleteRedelegate(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr)
+		return simulation.RunMsg(ctx, handler, msg, "stake/MsgCompleteRedelegate", event)
+	}
+}
+
+//______________________________________________________________________
+
+func randomDescription(r *rand.Rand) stake.Description {
+	return stake.NewDescription(simulation.RandStringOfLength(r, 10), "", "", "")
+}
+
+// random valid commission rates
+func randomCommission(r *rand.Rand) stake.CommissionMsg {
+	maxRate := randomRate(r, sdk.OneRat())
+	return stake.NewCommissionMsg(randomRate(r, maxRate), maxRate, randomRate(r, maxRate))
+}
+
+// a random rate in [0, max] with a precision of 1%
+func randomRate(r *rand.Rand, max sdk.Rat) sdk.Rat {
+	return sdk.NewRat(r.Int63n(max.Mul(sdk.NewRat(100)).RoundInt64()+1), 100)
+}
+
+// a random amount of the bond denom of an account, false if it's zero
+func randomBond(r *rand.Rand, ctx sdk.Context, m auth.AccountMapper, k stake.Keeper, addr sdk.Address) (sdk.Coin, bool) {
+	denom := k.GetParams(ctx).BondDenom
+	acc := m.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.Coin{}, false
+	}
+	amount := simulation.RandomAmount(r, acc.GetCoins().AmountOf(denom))
+	return sdk.Coin{Denom: denom, Amount: amount}, !amount.IsZero()
+}
+
+func randomValidator(r *rand.Rand, ctx sdk.Context, k stake.Keeper) (stake.Validator, bool) {
+	validators := k.GetAllValidators(ctx)
+	if len(validators) == 0 {
+		return stake.Validator{}, false
+	}
+	return validators[r.Intn(len(validators))], true
+}
+
+func randomDelegation(r *rand.Rand, ctx sdk.Context, k stake.Keeper) (stake.Delegation, bool) {
+	delegations := k.GetAllDelegations(ctx)
+	if len(delegations) == 0 {
+		return stake.Delegation{}, false
+	}
+	return delegations[r.Intn(len(delegations))], true
+}
+
+// a random whole number of shares of a delegation, false if it's zero.
+// Whole numbers keep the precision of the shares within the limits of the
+// messages.
+func randomShares(r *rand.Rand, delegation stake.Delegation) (sdk.Rat, bool) {
+	shares := simulation.RandomAmount(r, delegation.Shares.RoundInt())
+	return sdk.NewRatFromInt(shares), !shares.IsZero()
+}
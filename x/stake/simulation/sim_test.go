@@ -0,0 +1,75 @@
+package simulation
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestStakeWithRandomMessages(t *testing.T) {
+	mapp := mock.NewApp()
+
+	bank.RegisterWire(mapp.Cdc)
+	stake.RegisterWire(mapp.Cdc)
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyParams := sdk.NewKVStoreKey("params")
+	paramsKeeper := params.NewKeeper(mapp.Cdc, keyParams)
+	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
+	stakeKeeper := stake.NewKeeper(mapp.Cdc, keyStake, coinKeeper, paramsKeeper.Subspace(stake.DefaultParamspace), mapp.RegisterCodespace(stake.DefaultCodespace))
+	mapp.Router().AddRoute("stake", stake.NewHandler(stakeKeeper))
+	mapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+		validatorUpdates, tags := stake.EndBlocker(ctx, stakeKeeper)
+		return abci.ResponseEndBlock{
+			ValidatorUpdates: validatorUpdates,
+			Tags:             tags.ToKVPairs(),
+		}
+	})
+
+	// the loose tokens of the pool are the bond denom of the accounts
+	var looseTokens int64
+	mapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		mapp.InitChainer(ctx, req)
+		stakeGenesis := stake.DefaultGenesisState()
+		stakeGenesis.Pool.LooseTokens = looseTokens
+		// short enough for unbondings to complete during the simulation
+		stakeGenesis.Params.UnbondingTime = 10 * 60
+		stake.InitGenesis(ctx, stakeKeeper, stakeGenesis)
+		return abci.ResponseInitChain{}
+	})
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyParams}))
+
+	appStateFn := func(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+		mapp.GenesisAccounts = simulation.RandomGenesisAccounts(r, accs, []string{"steak"}, 1000)
+		for _, acc := range mapp.GenesisAccounts {
+			looseTokens += acc.GetCoins().AmountOf("steak").Int64()
+		}
+		return json.RawMessage("{}")
+	}
+
+	invariants := simulation.NewInvariants()
+	stake.NewAppModule(stakeKeeper, mapp.AccountMapper, mapp.FeeCollectionKeeper.HeldTokens).RegisterInvariants(invariants)
+
+	simulation.Simulate(
+		t, mapp.BaseApp, appStateFn,
+		[]simulation.WeightedOperation{
+			{Weight: 5, Op: SimulateMsgCreateValidator(mapp.AccountMapper, stakeKeeper)},
+			{Weight: 5, Op: SimulateMsgEditValidator(stakeKeeper)},
+			{Weight: 10, Op: SimulateMsgDelegate(mapp.AccountMapper, stakeKeeper)},
+			{Weight: 5, Op: SimulateMsgBeginUnbonding(stakeKeeper)},
+			{Weight: 5, Op: SimulateMsgCompleteUnbonding(stakeKeeper)},
+			{Weight: 5, Op: SimulateMsgBeginRedelegate(stakeKeeper)},
+			{Weight: 5, Op: SimulateMsgCompleteRedelegate(stakeKeeper)},
+		},
+		invariants, 100, 20,
+	)
+}
@@ -11,6 +11,7 @@ type (
 	Keeper                = keeper.Keeper
 	Validator             = types.Validator
 	Description           = types.Description
+	CommissionMsg         = types.CommissionMsg
 	Delegation            = types.Delegation
 	UnbondingDelegation   = types.UnbondingDelegation
 	Redelegation          = types.Redelegation
@@ -25,10 +26,22 @@ type (
 	MsgBeginRedelegate    = types.MsgBeginRedelegate
 	MsgCompleteRedelegate = types.MsgCompleteRedelegate
 	GenesisState          = types.GenesisState
+	TokenHolder           = keeper.TokenHolder
+
+	QueryDelegatorParams = keeper.QueryDelegatorParams
+	QueryValidatorParams = keeper.QueryValidatorParams
+	QueryBondsParams     = keeper.QueryBondsParams
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper  = keeper.NewKeeper
+	NewQuerier = keeper.NewQuerier
+
+	ParamKeyTable = keeper.ParamKeyTable
+
+	SupplyInvariant          = keeper.SupplyInvariant
+	PoolSharesInvariant      = keeper.PoolSharesInvariant
+	DelegatorSharesInvariant = keeper.DelegatorSharesInvariant
 
 	GetValidatorKey              = keeper.GetValidatorKey
 	GetValidatorByPubKeyIndexKey = keeper.GetValidatorByPubKeyIndexKey
@@ -37,7 +50,6 @@ var (
 	GetTendermintUpdatesKey      = keeper.GetTendermintUpdatesKey
 	GetDelegationKey             = keeper.GetDelegationKey
 	GetDelegationsKey            = keeper.GetDelegationsKey
-	ParamKey                     = keeper.ParamKey
 	PoolKey                      = keeper.PoolKey
 	ValidatorsKey                = keeper.ValidatorsKey
 	ValidatorsByPubKeyIndexKey   = keeper.ValidatorsByPubKeyIndexKey
@@ -67,6 +79,7 @@ var (
 	NewBondedShares     = types.NewBondedShares
 	NewValidator        = types.NewValidator
 	NewDescription      = types.NewDescription
+	NewCommissionMsg    = types.NewCommissionMsg
 	NewGenesisState     = types.NewGenesisState
 	DefaultGenesisState = types.DefaultGenesisState
 	RegisterWire        = types.RegisterWire
@@ -80,6 +93,21 @@ var (
 	NewMsgCompleteRedelegate = types.NewMsgCompleteRedelegate
 )
 
+const (
+	QueryValidators                    = keeper.QueryValidators
+	QueryValidator                     = keeper.QueryValidator
+	QueryDelegatorDelegations          = keeper.QueryDelegatorDelegations
+	QueryDelegatorUnbondingDelegations = keeper.QueryDelegatorUnbondingDelegations
+	QueryDelegatorRedelegations        = keeper.QueryDelegatorRedelegations
+	QueryValidatorDelegations          = keeper.QueryValidatorDelegations
+	QueryValidatorUnbondingDelegations = keeper.QueryValidatorUnbondingDelegations
+	QueryValidatorRedelegations        = keeper.QueryValidatorRedelegations
+	QueryDelegation                    = keeper.QueryDelegation
+	QueryUnbondingDelegation           = keeper.QueryUnbondingDelegation
+	QueryPool                          = keeper.QueryPool
+	QueryParameters                    = keeper.QueryParameters
+)
+
 const (
 	DefaultCodespace      = types.DefaultCodespace
 	CodeInvalidValidator  = types.CodeInvalidValidator
@@ -101,6 +129,10 @@ var (
 	ErrCommissionNegative     = types.ErrCommissionNegative
 	ErrCommissionHuge         = types.ErrCommissionHuge
 
+	ErrCommissionGTMaxRate           = types.ErrCommissionGTMaxRate
+	ErrCommissionChangeRateGTMaxRate = types.ErrCommissionChangeRateGTMaxRate
+	ErrCommissionChangeTooHigh       = types.ErrCommissionChangeTooHigh
+
 	ErrNilDelegatorAddr          = types.ErrNilDelegatorAddr
 	ErrBadDenom                  = types.ErrBadDenom
 	ErrBadDelegationAmount       = types.ErrBadDelegationAmount
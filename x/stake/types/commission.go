@@ -0,0 +1,74 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CommissionMsg defines the commission rates a validator is created with
+type CommissionMsg struct {
+	Rate          sdk.Rat `json:"rate"`            // the commission rate charged to the delegators
+	MaxRate       sdk.Rat `json:"max_rate"`        // maximum commission rate which the validator can ever charge
+	MaxChangeRate sdk.Rat `json:"max_change_rate"` // maximum daily change of the commission rate
+}
+
+// NewCommissionMsg returns a new CommissionMsg with the provided rates.
+func NewCommissionMsg(rate, maxRate, maxChangeRate sdk.Rat) CommissionMsg {
+	return CommissionMsg{
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+	}
+}
+
+// Validate checks that the rates are set, within [0, 1], and that neither
+// the rate nor the max change rate exceed the max rate.
+func (c CommissionMsg) Validate() sdk.Error {
+	switch {
+	case c.Rate.Rat == nil || c.MaxRate.Rat == nil || c.MaxChangeRate.Rat == nil:
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "commission rates must be included")
+	case c.Rate.LT(sdk.ZeroRat()) || c.MaxRate.LT(sdk.ZeroRat()) || c.MaxChangeRate.LT(sdk.ZeroRat()):
+		return ErrCommissionNegative(DefaultCodespace)
+	case c.MaxRate.GT(sdk.OneRat()):
+		return ErrCommissionHuge(DefaultCodespace)
+	case c.Rate.GT(c.MaxRate):
+		return ErrCommissionGTMaxRate(DefaultCodespace)
+	case c.MaxChangeRate.GT(c.MaxRate):
+		return ErrCommissionChangeRateGTMaxRate(DefaultCodespace)
+	}
+	return nil
+}
+
+// SetInitialCommission sets the commission rates of a new validator, the
+// rates must have been validated.
+func (v Validator) SetInitialCommission(c CommissionMsg) Validator {
+	v.Commission = c.Rate
+	v.CommissionMax = c.MaxRate
+	v.CommissionChangeRate = c.MaxChangeRate
+	v.CommissionChangeToday = sdk.ZeroRat()
+	return v
+}
+
+// UpdateCommission changes the commission rate of a validator. An error is
+// returned if the new rate exceeds the max rate, or if the changes of the
+// day would exceed the max change rate.
+func (v Validator) UpdateCommission(rate sdk.Rat) (Validator, sdk.Error) {
+	if rate.LT(sdk.ZeroRat()) {
+		return v, ErrCommissionNegative(DefaultCodespace)
+	}
+	if rate.GT(v.CommissionMax) {
+		return v, ErrCommissionGTMaxRate(DefaultCodespace)
+	}
+
+	change := rate.Sub(v.Commission)
+	if change.LT(sdk.ZeroRat()) {
+		change = change.Mul(sdk.NewRat(-1))
+	}
+	changeToday := v.CommissionChangeToday.Add(change)
+	if changeToday.GT(v.CommissionChangeRate) {
+		return v, ErrCommissionChangeTooHigh(DefaultCodespace)
+	}
+
+	v.Commission = rate
+	v.CommissionChangeToday = changeToday
+	return v, nil
+}
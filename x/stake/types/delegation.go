@@ -110,14 +110,14 @@ type UnbondingDelegation struct {
 	DelegatorAddr  sdk.Address `json:"delegator_addr"`  // delegator
 	ValidatorAddr  sdk.Address `json:"validator_addr"`  // validator unbonding from owner addr
 	CreationHeight int64       `json:"creation_height"` // height which the unbonding took place
-	MinTime        int64       `json:"min_time"`        // unix time for unbonding completion
+	CompletionTime int64       `json:"completion_time"` // unix time for unbonding completion
 	InitialBalance sdk.Coin    `json:"initial_balance"` // atoms initially scheduled to receive at completion
 	Balance        sdk.Coin    `json:"balance"`         // atoms to receive at completion
 }
 
 type ubdValue struct {
 	CreationHeight int64
-	MinTime        int64
+	CompletionTime int64
 	InitialBalance sdk.Coin
 	Balance        sdk.Coin
 }
@@ -126,7 +126,7 @@ type ubdValue struct {
 func MustMarshalUBD(cdc *wire.Codec, ubd UnbondingDelegation) []byte {
 	val := ubdValue{
 		ubd.CreationHeight,
-		ubd.MinTime,
+		ubd.CompletionTime,
 		ubd.InitialBalance,
 		ubd.Balance,
 	}
@@ -162,7 +162,7 @@ func UnmarshalUBD(cdc *wire.Codec, key, value []byte) (ubd UnbondingDelegation,
 		DelegatorAddr:  delAddr,
 		ValidatorAddr:  valAddr,
 		CreationHeight: storeValue.CreationHeight,
-		MinTime:        storeValue.MinTime,
+		CompletionTime: storeValue.CompletionTime,
 		InitialBalance: storeValue.InitialBalance,
 		Balance:        storeValue.Balance,
 	}, nil
@@ -193,7 +193,7 @@ func (d UnbondingDelegation) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Delegator: %s\n", bechAcc)
 	resp += fmt.Sprintf("Validator: %s\n", bechVal)
 	resp += fmt.Sprintf("Creation height: %v\n", d.CreationHeight)
-	resp += fmt.Sprintf("Min time to unbond (unix): %v\n", d.MinTime)
+	resp += fmt.Sprintf("Completion time (unix): %v\n", d.CompletionTime)
 	resp += fmt.Sprintf("Expected balance: %s", d.Balance.String())
 
 	return resp, nil
@@ -206,7 +206,7 @@ type Redelegation struct {
 	ValidatorSrcAddr sdk.Address `json:"validator_src_addr"` // validator redelegation source owner addr
 	ValidatorDstAddr sdk.Address `json:"validator_dst_addr"` // validator redelegation destination owner addr
 	CreationHeight   int64       `json:"creation_height"`    // height which the redelegation took place
-	MinTime          int64       `json:"min_time"`           // unix time for redelegation completion
+	CompletionTime   int64       `json:"completion_time"`    // unix time for redelegation completion
 	InitialBalance   sdk.Coin    `json:"initial_balance"`    // initial balance when redelegation started
 	Balance          sdk.Coin    `json:"balance"`            // current balance
 	SharesSrc        sdk.Rat     `json:"shares_src"`         // amount of source shares redelegating
@@ -215,7 +215,7 @@ type Redelegation struct {
 
 type redValue struct {
 	CreationHeight int64
-	MinTime        int64
+	CompletionTime int64
 	InitialBalance sdk.Coin
 	Balance        sdk.Coin
 	SharesSrc      sdk.Rat
@@ -226,7 +226,7 @@ type redValue struct {
 func MustMarshalRED(cdc *wire.Codec, red Redelegation) []byte {
 	val := redValue{
 		red.CreationHeight,
-		red.MinTime,
+		red.CompletionTime,
 		red.InitialBalance,
 		red.Balance,
 		red.SharesSrc,
@@ -266,7 +266,7 @@ func UnmarshalRED(cdc *wire.Codec, key, value []byte) (red Redelegation, err err
 		ValidatorSrcAddr: valSrcAddr,
 		ValidatorDstAddr: valDstAddr,
 		CreationHeight:   storeValue.CreationHeight,
-		MinTime:          storeValue.MinTime,
+		CompletionTime:   storeValue.CompletionTime,
 		InitialBalance:   storeValue.InitialBalance,
 		Balance:          storeValue.Balance,
 		SharesSrc:        storeValue.SharesSrc,
@@ -305,7 +305,7 @@ func (d Redelegation) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Source Validator: %s\n", bechValSrc)
 	resp += fmt.Sprintf("Destination Validator: %s\n", bechValDst)
 	resp += fmt.Sprintf("Creation height: %v\n", d.CreationHeight)
-	resp += fmt.Sprintf("Min time to unbond (unix): %v\n", d.MinTime)
+	resp += fmt.Sprintf("Completion time (unix): %v\n", d.CompletionTime)
 	resp += fmt.Sprintf("Source shares: %s", d.SharesSrc.String())
 	resp += fmt.Sprintf("Destination shares: %s", d.SharesDst.String())
 
@@ -57,7 +57,7 @@ func TestUnbondingDelegationEqual(t *testing.T) {
 	require.True(t, ok)
 
 	ud2.ValidatorAddr = addr3
-	ud2.MinTime = 20 * 20 * 2
+	ud2.CompletionTime = 20 * 20 * 2
 
 	ok = ud1.Equal(ud2)
 	require.False(t, ok)
@@ -93,7 +93,7 @@ func TestRedelegationEqual(t *testing.T) {
 
 	r2.SharesDst = sdk.NewRat(10)
 	r2.SharesSrc = sdk.NewRat(20)
-	r2.MinTime = 20 * 20 * 2
+	r2.CompletionTime = 20 * 20 * 2
 
 	ok = r1.Equal(r2)
 	require.False(t, ok)
@@ -27,15 +27,17 @@ var maximumBondingRationalDenominator sdk.Int = sdk.NewInt(int64(math.Pow10(MaxB
 // MsgCreateValidator - struct for unbonding transactions
 type MsgCreateValidator struct {
 	Description
+	Commission     CommissionMsg `json:"commission"`
 	ValidatorAddr  sdk.Address   `json:"address"`
 	PubKey         crypto.PubKey `json:"pubkey"`
 	SelfDelegation sdk.Coin      `json:"self_delegation"`
 }
 
 func NewMsgCreateValidator(validatorAddr sdk.Address, pubkey crypto.PubKey,
-	selfDelegation sdk.Coin, description Description) MsgCreateValidator {
+	selfDelegation sdk.Coin, description Description, commission CommissionMsg) MsgCreateValidator {
 	return MsgCreateValidator{
 		Description:    description,
+		Commission:     commission,
 		ValidatorAddr:  validatorAddr,
 		PubKey:         pubkey,
 		SelfDelegation: selfDelegation,
@@ -52,11 +54,13 @@ func (msg MsgCreateValidator) GetSigners() []sdk.Address {
 func (msg MsgCreateValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr string   `json:"address"`
-		PubKey        string   `json:"pubkey"`
-		Bond          sdk.Coin `json:"bond"`
+		Commission    CommissionMsg `json:"commission"`
+		ValidatorAddr string        `json:"address"`
+		PubKey        string        `json:"pubkey"`
+		Bond          sdk.Coin      `json:"bond"`
 	}{
 		Description:   msg.Description,
+		Commission:    msg.Commission,
 		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
 		PubKey:        sdk.MustBech32ifyValPub(msg.PubKey),
 	})
@@ -78,7 +82,7 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if msg.Description == empty {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "description must be included")
 	}
-	return nil
+	return msg.Commission.Validate()
 }
 
 //______________________________________________________________________
@@ -87,12 +91,16 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 type MsgEditValidator struct {
 	Description
 	ValidatorAddr sdk.Address `json:"address"`
+
+	// the new commission rate, the commission is left unchanged if nil
+	CommissionRate *sdk.Rat `json:"commission_rate"`
 }
 
-func NewMsgEditValidator(validatorAddr sdk.Address, description Description) MsgEditValidator {
+func NewMsgEditValidator(validatorAddr sdk.Address, description Description, commissionRate *sdk.Rat) MsgEditValidator {
 	return MsgEditValidator{
-		Description:   description,
-		ValidatorAddr: validatorAddr,
+		Description:    description,
+		ValidatorAddr:  validatorAddr,
+		CommissionRate: commissionRate,
 	}
 }
 
@@ -106,10 +114,12 @@ func (msg MsgEditValidator) GetSigners() []sdk.Address {
 func (msg MsgEditValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr string `json:"address"`
+		ValidatorAddr  string   `json:"address"`
+		CommissionRate *sdk.Rat `json:"commission_rate"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		Description:    msg.Description,
+		ValidatorAddr:  sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		CommissionRate: msg.CommissionRate,
 	})
 	if err != nil {
 		panic(err)
@@ -123,9 +133,17 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "nil validator address")
 	}
 	empty := Description{}
-	if msg.Description == empty {
+	if msg.Description == empty && msg.CommissionRate == nil {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "transaction must include some information to modify")
 	}
+	if msg.CommissionRate != nil {
+		if msg.CommissionRate.Rat == nil || msg.CommissionRate.LT(sdk.ZeroRat()) {
+			return ErrCommissionNegative(DefaultCodespace)
+		}
+		if msg.CommissionRate.GT(sdk.OneRat()) {
+			return ErrCommissionHuge(DefaultCodespace)
+		}
+	}
 	return nil
 }
 
@@ -249,7 +267,9 @@ func (msg MsgBeginRedelegate) ValidateBasic() sdk.Error {
 	return nil
 }
 
-// MsgDelegate - struct for bonding transactions
+// MsgCompleteRedelegate - struct for completing a redelegation
+//
+// Deprecated: redelegations are completed in the EndBlocker once they mature
 type MsgCompleteRedelegate struct {
 	DelegatorAddr    sdk.Address `json:"delegator_addr"`
 	ValidatorSrcAddr sdk.Address `json:"validator_source_addr"`
@@ -358,7 +378,10 @@ func (msg MsgBeginUnbonding) ValidateBasic() sdk.Error {
 	return nil
 }
 
-// MsgCompleteUnbonding - struct for unbonding transactions
+// MsgCompleteUnbonding - struct for completing an unbonding delegation
+//
+// Deprecated: unbonding delegations are completed in the EndBlocker once
+// they mature
 type MsgCompleteUnbonding struct {
 	DelegatorAddr sdk.Address `json:"delegator_addr"`
 	ValidatorAddr sdk.Address `json:"validator_addr"`
@@ -17,26 +17,33 @@ var (
 
 // test ValidateBasic for MsgCreateValidator
 func TestMsgCreateValidator(t *testing.T) {
+	commission1 := NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(1, 2), sdk.NewRat(1, 100))
 	tests := []struct {
 		name, moniker, identity, website, details string
+		commission                                CommissionMsg
 		validatorAddr                             sdk.Address
 		pubkey                                    crypto.PubKey
 		bond                                      sdk.Coin
 		expectPass                                bool
 	}{
-		{"basic good", "a", "b", "c", "d", addr1, pk1, coinPos, true},
-		{"partial description", "", "", "c", "", addr1, pk1, coinPos, true},
-		{"empty description", "", "", "", "", addr1, pk1, coinPos, false},
-		{"empty address", "a", "b", "c", "d", emptyAddr, pk1, coinPos, false},
-		{"empty pubkey", "a", "b", "c", "d", addr1, emptyPubkey, coinPos, true},
-		{"empty bond", "a", "b", "c", "d", addr1, pk1, coinZero, false},
-		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, false},
-		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, false},
+		{"basic good", "a", "b", "c", "d", commission1, addr1, pk1, coinPos, true},
+		{"partial description", "", "", "c", "", commission1, addr1, pk1, coinPos, true},
+		{"empty description", "", "", "", "", commission1, addr1, pk1, coinPos, false},
+		{"empty address", "a", "b", "c", "d", commission1, emptyAddr, pk1, coinPos, false},
+		{"empty pubkey", "a", "b", "c", "d", commission1, addr1, emptyPubkey, coinPos, true},
+		{"empty bond", "a", "b", "c", "d", commission1, addr1, pk1, coinZero, false},
+		{"negative bond", "a", "b", "c", "d", commission1, addr1, pk1, coinNeg, false},
+		{"negative bond", "a", "b", "c", "d", commission1, addr1, pk1, coinNeg, false},
+		{"empty commission", "a", "b", "c", "d", CommissionMsg{}, addr1, pk1, coinPos, false},
+		{"negative commission", "a", "b", "c", "d", NewCommissionMsg(sdk.NewRat(-1, 10), sdk.NewRat(1, 2), sdk.ZeroRat()), addr1, pk1, coinPos, false},
+		{"max rate above 100%", "a", "b", "c", "d", NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(3, 2), sdk.ZeroRat()), addr1, pk1, coinPos, false},
+		{"rate above max rate", "a", "b", "c", "d", NewCommissionMsg(sdk.NewRat(1, 2), sdk.NewRat(1, 10), sdk.ZeroRat()), addr1, pk1, coinPos, false},
+		{"change rate above max rate", "a", "b", "c", "d", NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(1, 10), sdk.NewRat(1, 2)), addr1, pk1, coinPos, false},
 	}
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description)
+		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description, tc.commission)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -47,20 +54,25 @@ func TestMsgCreateValidator(t *testing.T) {
 
 // test ValidateBasic for MsgEditValidator
 func TestMsgEditValidator(t *testing.T) {
+	rate, negativeRate, hugeRate := sdk.NewRat(1, 10), sdk.NewRat(-1, 10), sdk.NewRat(3, 2)
 	tests := []struct {
 		name, moniker, identity, website, details string
+		commissionRate                            *sdk.Rat
 		validatorAddr                             sdk.Address
 		expectPass                                bool
 	}{
-		{"basic good", "a", "b", "c", "d", addr1, true},
-		{"partial description", "", "", "c", "", addr1, true},
-		{"empty description", "", "", "", "", addr1, false},
-		{"empty address", "a", "b", "c", "d", emptyAddr, false},
+		{"basic good", "a", "b", "c", "d", nil, addr1, true},
+		{"partial description", "", "", "c", "", nil, addr1, true},
+		{"empty description", "", "", "", "", nil, addr1, false},
+		{"empty address", "a", "b", "c", "d", nil, emptyAddr, false},
+		{"commission only", "", "", "", "", &rate, addr1, true},
+		{"negative commission", "", "", "", "", &negativeRate, addr1, false},
+		{"commission above 100%", "", "", "", "", &hugeRate, addr1, false},
 	}
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgEditValidator(tc.validatorAddr, description)
+		msg := NewMsgEditValidator(tc.validatorAddr, description, tc.commissionRate)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
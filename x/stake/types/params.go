@@ -2,8 +2,10 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // defaultUnbondingTime reflects three weeks in seconds as the default
@@ -23,6 +25,64 @@ type Params struct {
 	BondDenom     string `json:"bond_denom"`     // bondable coin denomination
 }
 
+// keys of the staking parameters in the params store
+// nolint
+var (
+	KeyInflationRateChange = []byte("InflationRateChange")
+	KeyInflationMax        = []byte("InflationMax")
+	KeyInflationMin        = []byte("InflationMin")
+	KeyGoalBonded          = []byte("GoalBonded")
+	KeyUnbondingTime       = []byte("UnbondingTime")
+	KeyMaxValidators       = []byte("MaxValidators")
+	KeyBondDenom           = []byte("BondDenom")
+)
+
+var _ params.ParamSet = (*Params)(nil)
+
+// KeyValuePairs implements params.ParamSet
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{Key: KeyInflationRateChange, Value: &p.InflationRateChange},
+		{Key: KeyInflationMax, Value: &p.InflationMax},
+		{Key: KeyInflationMin, Value: &p.InflationMin},
+		{Key: KeyGoalBonded, Value: &p.GoalBonded},
+		{Key: KeyUnbondingTime, Value: &p.UnbondingTime},
+		{Key: KeyMaxValidators, Value: &p.MaxValidators},
+		{Key: KeyBondDenom, Value: &p.BondDenom},
+	}
+}
+
+// Validate checks the bounds of the staking parameters
+func (p Params) Validate() error {
+	if p.BondDenom == "" {
+		return fmt.Errorf("staking parameter BondDenom can't be an empty string")
+	}
+	if p.MaxValidators == 0 {
+		return fmt.Errorf("staking parameter MaxValidators must be positive")
+	}
+	if p.UnbondingTime < 0 {
+		return fmt.Errorf("staking parameter UnbondingTime (%d) can't be negative", p.UnbondingTime)
+	}
+	for name, rate := range map[string]sdk.Rat{
+		"InflationRateChange": p.InflationRateChange,
+		"InflationMax":        p.InflationMax,
+		"InflationMin":        p.InflationMin,
+	} {
+		if rate.Rat == nil || rate.LT(sdk.ZeroRat()) || rate.GT(sdk.OneRat()) {
+			return fmt.Errorf("staking parameter %s (%v) must be between 0 and 1", name, rate)
+		}
+	}
+	if p.InflationMin.GT(p.InflationMax) {
+		return fmt.Errorf("staking parameter InflationMin (%v) can't be greater than InflationMax (%v)",
+			p.InflationMin, p.InflationMax)
+	}
+	// the bonded ratio is divided by the goal
+	if p.GoalBonded.Rat == nil || !p.GoalBonded.GT(sdk.ZeroRat()) || p.GoalBonded.GT(sdk.OneRat()) {
+		return fmt.Errorf("staking parameter GoalBonded (%v) must be positive and at most 1", p.GoalBonded)
+	}
+	return nil
+}
+
 // Equal returns a boolean determining if two Param types are identical.
 func (p Params) Equal(p2 Params) bool {
 	bz1 := MsgCdc.MustMarshalBinary(&p)
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestParamsEqual(t *testing.T) {
@@ -19,3 +21,22 @@ func TestParamsEqual(t *testing.T) {
 	ok = p1.Equal(p2)
 	require.False(t, ok)
 }
+
+func TestParamsValidate(t *testing.T) {
+	require.Nil(t, DefaultParams().Validate())
+
+	tests := []func(p *Params){
+		func(p *Params) { p.BondDenom = "" },
+		func(p *Params) { p.MaxValidators = 0 },
+		func(p *Params) { p.UnbondingTime = -1 },
+		func(p *Params) { p.InflationMax = sdk.NewRat(3, 2) },
+		func(p *Params) { p.InflationRateChange = sdk.NewRat(-1, 100) },
+		func(p *Params) { p.InflationMin = sdk.NewRat(21, 100) },
+		func(p *Params) { p.GoalBonded = sdk.ZeroRat() },
+	}
+	for i, tc := range tests {
+		p := DefaultParams()
+		tc(&p)
+		require.NotNil(t, p.Validate(), "test: %v", i)
+	}
+}
@@ -22,7 +22,7 @@ type Pool struct {
 	DateLastCommissionReset int64 `json:"date_last_commission_reset"` // unix timestamp for last commission accounting reset (daily)
 
 	// Fee Related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // last recorded bonded shares - for fee calculations
+	UndistributedProvisions int64 `json:"undistributed_provisions"` // inflation provisions not yet taken by the fee distribution
 }
 
 // nolint
@@ -45,7 +45,7 @@ func InitialPool() Pool {
 		InflationLastTime:       0,
 		Inflation:               sdk.NewRat(7, 100),
 		DateLastCommissionReset: 0,
-		PrevBondedShares:        sdk.ZeroRat(),
+		UndistributedProvisions: 0,
 	}
 }
 
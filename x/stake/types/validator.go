@@ -33,15 +33,11 @@ type Validator struct {
 	Description        Description `json:"description"`           // description terms for the validator
 	BondHeight         int64       `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16       `json:"bond_intra_tx_counter"` // block-local tx index of validator change
-	ProposerRewardPool sdk.Coins   `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
-
-	// fee related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
+	Commission            sdk.Rat `json:"commission"`              // the commission rate of fees charged to any delegators
+	CommissionMax         sdk.Rat `json:"commission_max"`          // maximum commission rate which this validator can ever charge
+	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // maximum daily change of the validator commission
+	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // commission rate change today, reset each day (UTC time)
 }
 
 // NewValidator - initialize a new validator
@@ -55,12 +51,10 @@ func NewValidator(owner sdk.Address, pubKey crypto.PubKey, description Descripti
 		Description:           description,
 		BondHeight:            int64(0),
 		BondIntraTxCounter:    int16(0),
-		ProposerRewardPool:    sdk.Coins{},
 		Commission:            sdk.ZeroRat(),
 		CommissionMax:         sdk.ZeroRat(),
 		CommissionChangeRate:  sdk.ZeroRat(),
 		CommissionChangeToday: sdk.ZeroRat(),
-		PrevBondedShares:      sdk.ZeroRat(),
 	}
 }
 
@@ -73,12 +67,10 @@ type validatorValue struct {
 	Description           Description
 	BondHeight            int64
 	BondIntraTxCounter    int16
-	ProposerRewardPool    sdk.Coins
 	Commission            sdk.Rat
 	CommissionMax         sdk.Rat
 	CommissionChangeRate  sdk.Rat
 	CommissionChangeToday sdk.Rat
-	PrevBondedShares      sdk.Rat
 }
 
 // return the redelegation without fields contained within the key for the store
@@ -91,12 +83,10 @@ func MustMarshalValidator(cdc *wire.Codec, validator Validator) []byte {
 		Description:           validator.Description,
 		BondHeight:            validator.BondHeight,
 		BondIntraTxCounter:    validator.BondIntraTxCounter,
-		ProposerRewardPool:    validator.ProposerRewardPool,
 		Commission:            validator.Commission,
 		CommissionMax:         validator.CommissionMax,
 		CommissionChangeRate:  validator.CommissionChangeRate,
 		CommissionChangeToday: validator.CommissionChangeToday,
-		PrevBondedShares:      validator.PrevBondedShares,
 	}
 	return cdc.MustMarshalBinary(val)
 }
@@ -133,12 +123,10 @@ func UnmarshalValidator(cdc *wire.Codec, ownerAddr, value []byte) (validator Val
 		Description:           storeValue.Description,
 		BondHeight:            storeValue.BondHeight,
 		BondIntraTxCounter:    storeValue.BondIntraTxCounter,
-		ProposerRewardPool:    storeValue.ProposerRewardPool,
 		Commission:            storeValue.Commission,
 		CommissionMax:         storeValue.CommissionMax,
 		CommissionChangeRate:  storeValue.CommissionChangeRate,
 		CommissionChangeToday: storeValue.CommissionChangeToday,
-		PrevBondedShares:      storeValue.PrevBondedShares,
 	}, nil
 }
 
@@ -149,12 +137,10 @@ func (v Validator) Equal(c2 Validator) bool {
 		v.PoolShares.Equal(c2.PoolShares) &&
 		v.DelegatorShares.Equal(c2.DelegatorShares) &&
 		v.Description == c2.Description &&
-		v.ProposerRewardPool.IsEqual(c2.ProposerRewardPool) &&
 		v.Commission.Equal(c2.Commission) &&
 		v.CommissionMax.Equal(c2.CommissionMax) &&
 		v.CommissionChangeRate.Equal(c2.CommissionChangeRate) &&
-		v.CommissionChangeToday.Equal(c2.CommissionChangeToday) &&
-		v.PrevBondedShares.Equal(c2.PrevBondedShares)
+		v.CommissionChangeToday.Equal(c2.CommissionChangeToday)
 }
 
 // Description - description fields for a validator
@@ -411,12 +397,10 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Delegator Shares: %s\n", v.DelegatorShares.FloatString())
 	resp += fmt.Sprintf("Description: %s\n", v.Description)
 	resp += fmt.Sprintf("Bond Height: %d\n", v.BondHeight)
-	resp += fmt.Sprintf("Proposer Reward Pool: %s\n", v.ProposerRewardPool.String())
 	resp += fmt.Sprintf("Commission: %s\n", v.Commission.String())
 	resp += fmt.Sprintf("Max Commission Rate: %s\n", v.CommissionMax.String())
 	resp += fmt.Sprintf("Commission Change Rate: %s\n", v.CommissionChangeRate.String())
 	resp += fmt.Sprintf("Commission Change Today: %s\n", v.CommissionChangeToday.String())
-	resp += fmt.Sprintf("Previously Bonded Stares: %s\n", v.PrevBondedShares.String())
 
 	return resp, nil
 }
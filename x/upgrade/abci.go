@@ -0,0 +1,38 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker performs the scheduled upgrade once it is due. If the binary
+// has no handler for the upgrade, it halts the chain so that the nodes can
+// switch to a binary which has one. A binary with the handler of an
+// upgrade which is not due yet halts too, it was started too early.
+func BeginBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return nil
+	}
+	logger := ctx.Logger().With("module", "x/upgrade")
+
+	if !plan.ShouldExecute(ctx) {
+		if k.HasUpgradeHandler(plan.Name) {
+			msg := fmt.Sprintf("binary updated before upgrade %s, which is due %s", plan.Name, plan.DueAt())
+			logger.Error(msg)
+			panic(msg)
+		}
+		return nil
+	}
+
+	if !k.HasUpgradeHandler(plan.Name) {
+		msg := fmt.Sprintf("UPGRADE %s NEEDED %s: %s", plan.Name, plan.DueAt(), plan.Info)
+		logger.Error(msg)
+		panic(msg)
+	}
+
+	logger.Info(fmt.Sprintf("applying upgrade %s at height %d", plan.Name, ctx.BlockHeight()))
+	k.ApplyUpgrade(ctx, plan)
+	return sdk.NewTags("upgrade", []byte(plan.Name))
+}
@@ -0,0 +1,52 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBeginBlocker(t *testing.T) {
+	ctx, keeper := createTestInput()
+
+	// nothing to do without a plan
+	require.Nil(t, BeginBlocker(ctx, keeper))
+
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 20, 0, "")))
+	require.Nil(t, BeginBlocker(ctx.WithBlockHeight(19), keeper))
+
+	// the old binary halts at the upgrade height
+	require.Panics(t, func() { BeginBlocker(ctx.WithBlockHeight(20), keeper) })
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+
+	// the new binary can't run before the upgrade height
+	var called bool
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context) { called = true })
+	require.Panics(t, func() { BeginBlocker(ctx.WithBlockHeight(19), keeper) })
+	require.False(t, called)
+
+	// the new binary applies the upgrade and continues
+	ctx = ctx.WithBlockHeight(20)
+	tags := BeginBlocker(ctx, keeper)
+	require.True(t, called)
+	require.Equal(t, sdk.NewTags("upgrade", []byte("v2")), tags)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	require.Equal(t, int64(20), keeper.GetDoneHeight(ctx, "v2"))
+	require.Nil(t, BeginBlocker(ctx.WithBlockHeight(21), keeper))
+}
+
+func TestBeginBlockerTime(t *testing.T) {
+	ctx, keeper := createTestInput()
+
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 0, 2000, "")))
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context) {})
+
+	header := ctx.BlockHeader()
+	header.Time = 2000
+	tags := BeginBlocker(ctx.WithBlockHeader(header), keeper)
+	require.Equal(t, sdk.NewTags("upgrade", []byte("v2")), tags)
+}
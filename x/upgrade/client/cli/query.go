@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// get the command to query the scheduled upgrade plan
+func GetCmdQueryPlan(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Query the scheduled upgrade plan",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			ctx := context.NewCoreContextFromViper()
+			path := fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryCurrent)
+			res, err := ctx.Query(path)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				fmt.Println("No upgrade scheduled")
+				return nil
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// get the command to query the applied upgrades
+func GetCmdQueryApplied(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "applied",
+		Short: "Query the upgrades applied to the chain and their heights",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			ctx := context.NewCoreContextFromViper()
+			path := fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryApplied)
+			res, err := ctx.Query(path)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,20 @@
+// nolint
+package upgrade
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default upgrade codespace
+	DefaultCodespace sdk.CodespaceType = 8
+
+	CodeInvalidPlan CodeType = 101
+)
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, msg)
+}
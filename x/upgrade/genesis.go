@@ -0,0 +1,80 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the scheduled upgrade plan, if any, and the upgrades
+// already applied to the chain
+type GenesisState struct {
+	Plan            *Plan            `json:"plan"`
+	AppliedUpgrades []AppliedUpgrade `json:"applied_upgrades"`
+}
+
+func NewGenesisState(plan *Plan, appliedUpgrades []AppliedUpgrade) GenesisState {
+	return GenesisState{
+		Plan:            plan,
+		AppliedUpgrades: appliedUpgrades,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		AppliedUpgrades: []AppliedUpgrade{},
+	}
+}
+
+// ValidateGenesis - check the plan is valid and the applied upgrades are
+// unique, named and applied at a positive height. The plan can't be an
+// applied upgrade.
+func ValidateGenesis(data GenesisState) error {
+	seen := make(map[string]bool, len(data.AppliedUpgrades))
+	for _, upgrade := range data.AppliedUpgrades {
+		if len(upgrade.Name) == 0 {
+			return fmt.Errorf("unnamed applied upgrade in genesis state")
+		}
+		if upgrade.Height <= 0 {
+			return fmt.Errorf("invalid height %d of applied upgrade %s, must be positive", upgrade.Height, upgrade.Name)
+		}
+		if seen[upgrade.Name] {
+			return fmt.Errorf("duplicate applied upgrade in genesis state: %s", upgrade.Name)
+		}
+		seen[upgrade.Name] = true
+	}
+
+	if data.Plan != nil {
+		if err := data.Plan.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[data.Plan.Name] {
+			return fmt.Errorf("upgrade plan %s was already applied", data.Plan.Name)
+		}
+	}
+	return nil
+}
+
+// InitGenesis - store the scheduled plan and the heights of the applied
+// upgrades
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, upgrade := range data.AppliedUpgrades {
+		keeper.setDoneHeight(ctx, upgrade.Name, upgrade.Height)
+	}
+	if data.Plan != nil {
+		keeper.setUpgradePlan(ctx, *data.Plan)
+	}
+}
+
+// WriteGenesis - output the scheduled plan and the applied upgrades
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	data := DefaultGenesisState()
+	if plan, found := keeper.GetUpgradePlan(ctx); found {
+		data.Plan = &plan
+	}
+	if upgrades := keeper.GetAppliedUpgrades(ctx); upgrades != nil {
+		data.AppliedUpgrades = upgrades
+	}
+	return data
+}
@@ -0,0 +1,65 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	plan := NewPlan("v3", 100, 0, "")
+	applied := []AppliedUpgrade{{"v1", 5}, {"v2", 50}}
+	require.Nil(t, ValidateGenesis(NewGenesisState(&plan, applied)))
+
+	// invalid plan
+	badPlan := NewPlan("v3", 0, 0, "")
+	require.NotNil(t, ValidateGenesis(NewGenesisState(&badPlan, applied)))
+
+	// plan already applied
+	donePlan := NewPlan("v2", 100, 0, "")
+	require.NotNil(t, ValidateGenesis(NewGenesisState(&donePlan, applied)))
+
+	// invalid applied upgrades
+	require.NotNil(t, ValidateGenesis(NewGenesisState(nil, []AppliedUpgrade{{"", 5}})))
+	require.NotNil(t, ValidateGenesis(NewGenesisState(nil, []AppliedUpgrade{{"v1", 0}})))
+	require.NotNil(t, ValidateGenesis(NewGenesisState(nil, []AppliedUpgrade{{"v1", 5}, {"v1", 6}})))
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, keeper := createTestInput()
+
+	// nothing scheduled nor applied
+	exported := WriteGenesis(ctx, keeper)
+	require.Equal(t, DefaultGenesisState(), exported)
+
+	// apply an upgrade, then schedule the next one
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context) {})
+	keeper.ApplyUpgrade(ctx, NewPlan("v2", 10, 0, ""))
+	plan := NewPlan("v3", 0, 2000, "binaries")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+
+	exported = WriteGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(exported))
+	require.Equal(t, NewGenesisState(&plan, []AppliedUpgrade{{"v2", 10}}), exported)
+
+	// the exported state goes through the JSON of the genesis file
+	module := NewAppModule(keeper)
+	bz := module.ExportGenesis(ctx)
+	require.Nil(t, module.ValidateGenesis(bz))
+
+	// import the exported state in a new chain
+	newCtx, newKeeper := createTestInput()
+	require.Nil(t, NewAppModule(newKeeper).InitGenesis(newCtx, bz))
+	require.Equal(t, exported, WriteGenesis(newCtx, newKeeper))
+	newPlan, found := newKeeper.GetUpgradePlan(newCtx)
+	require.True(t, found)
+	require.Equal(t, plan, newPlan)
+	require.Equal(t, int64(10), newKeeper.GetDoneHeight(newCtx, "v2"))
+
+	// the applied upgrade can't be scheduled again
+	require.NotNil(t, newKeeper.ValidatePlan(newCtx, NewPlan("v2", 100, 0, "")))
+}
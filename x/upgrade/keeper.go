@@ -0,0 +1,160 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var (
+	// key for the scheduled upgrade plan
+	PlanKey = []byte{0x00}
+	// prefix for the heights of the applied upgrades, keyed by name
+	DoneKeyPrefix = []byte{0x01}
+)
+
+// get the key for the height at which an upgrade was applied
+func GetDoneKey(name string) []byte {
+	return append(DoneKeyPrefix, []byte(name)...)
+}
+
+// UpgradeHandler performs an upgrade of the state, e.g. migrates the
+// stores, when the binary reaches the height of the upgrade
+type UpgradeHandler func(ctx sdk.Context)
+
+// Keeper - upgrade keeper, holds the scheduled upgrade plan and the
+// handlers of the upgrades known to the binary
+type Keeper struct {
+	upgradeHandlers map[string]UpgradeHandler // shared by the copies of the keeper
+
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a new upgrade Keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		upgradeHandlers: make(map[string]UpgradeHandler),
+		storeKey:        key,
+		cdc:             cdc,
+		codespace:       codespace,
+	}
+}
+
+// SetUpgradeHandler registers the handler of the upgrade with the given
+// name. A binary registers the handlers of the upgrades it performs, it
+// halts at any other upgrade.
+func (k Keeper) SetUpgradeHandler(name string, upgradeHandler UpgradeHandler) {
+	k.upgradeHandlers[name] = upgradeHandler
+}
+
+// HasUpgradeHandler returns true if the binary can perform the upgrade
+func (k Keeper) HasUpgradeHandler(name string) bool {
+	_, ok := k.upgradeHandlers[name]
+	return ok
+}
+
+// ValidatePlan checks that the plan is valid, in the future and has not
+// been applied already
+func (k Keeper) ValidatePlan(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if plan.ShouldExecute(ctx) {
+		return ErrInvalidPlan(k.codespace, fmt.Sprintf("upgrade %s cannot be scheduled in the past", plan.DueAt()))
+	}
+	if height := k.GetDoneHeight(ctx, plan.Name); height != 0 {
+		return ErrInvalidPlan(k.codespace, fmt.Sprintf("upgrade %s was already applied at height %d", plan.Name, height))
+	}
+	return nil
+}
+
+// ScheduleUpgrade schedules an upgrade, replacing any scheduled one
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := k.ValidatePlan(ctx, plan); err != nil {
+		return err
+	}
+	k.setUpgradePlan(ctx, plan)
+	return nil
+}
+
+// set the scheduled upgrade without validating it
+func (k Keeper) setUpgradePlan(ctx sdk.Context, plan Plan) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(plan)
+	store.Set(PlanKey, bz)
+}
+
+// GetUpgradePlan returns the scheduled upgrade, if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(PlanKey)
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan cancels the scheduled upgrade, if any
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(PlanKey)
+}
+
+// GetDoneHeight returns the height at which an upgrade was applied, zero
+// if it was not
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDoneKey(name))
+	if bz == nil {
+		return 0
+	}
+	var height int64
+	k.cdc.MustUnmarshalBinary(bz, &height)
+	return height
+}
+
+// AppliedUpgrade is an upgrade applied to the chain
+type AppliedUpgrade struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+// GetAppliedUpgrades returns the applied upgrades, sorted by name
+func (k Keeper) GetAppliedUpgrades(ctx sdk.Context) (upgrades []AppliedUpgrade) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DoneKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var height int64
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &height)
+		name := string(iterator.Key()[len(DoneKeyPrefix):])
+		upgrades = append(upgrades, AppliedUpgrade{Name: name, Height: height})
+	}
+	return upgrades
+}
+
+// ApplyUpgrade runs the handler of the scheduled upgrade, records the
+// upgrade as applied and clears the plan
+func (k Keeper) ApplyUpgrade(ctx sdk.Context, plan Plan) {
+	handler, ok := k.upgradeHandlers[plan.Name]
+	if !ok {
+		panic(fmt.Sprintf("no handler for upgrade %s", plan.Name))
+	}
+	handler(ctx)
+
+	k.ClearUpgradePlan(ctx)
+	k.setDoneHeight(ctx, plan.Name, ctx.BlockHeight())
+}
+
+// record the height at which an upgrade was applied
+func (k Keeper) setDoneHeight(ctx sdk.Context, name string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(height)
+	store.Set(GetDoneKey(name), bz)
+}
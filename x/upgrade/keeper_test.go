@@ -0,0 +1,111 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+func createTestInput() (sdk.Context, Keeper) {
+	key := sdk.NewKVStoreKey("upgrade")
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	cms.LoadLatestVersion()
+	ctx := sdk.NewContext(cms, abci.Header{Height: 10, Time: 1000}, false, log.NewNopLogger())
+	return ctx, NewKeeper(wire.NewCodec(), key, DefaultCodespace)
+}
+
+func TestPlanValidateBasic(t *testing.T) {
+	tests := []struct {
+		plan       Plan
+		expectPass bool
+	}{
+		{NewPlan("v2", 100, 0, "info"), true},
+		{NewPlan("v2", 0, 2000, ""), true},
+		{NewPlan("", 100, 0, "info"), false},
+		{NewPlan("v2", 0, 0, "info"), false},
+		{NewPlan("v2", 100, 2000, "info"), false},
+		{NewPlan("v2", -100, 0, "info"), false},
+	}
+
+	for i, tc := range tests {
+		err := tc.plan.ValidateBasic()
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", i)
+		} else {
+			require.NotNil(t, err, "test: %v", i)
+		}
+	}
+}
+
+func TestPlanShouldExecute(t *testing.T) {
+	ctx, _ := createTestInput()
+
+	require.False(t, NewPlan("v2", 11, 0, "").ShouldExecute(ctx))
+	require.True(t, NewPlan("v2", 10, 0, "").ShouldExecute(ctx))
+	require.False(t, NewPlan("v2", 0, 1001, "").ShouldExecute(ctx))
+	require.True(t, NewPlan("v2", 0, 1000, "").ShouldExecute(ctx))
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput()
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// plans must be valid and in the future
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("", 20, 0, "")))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 10, 0, "")))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 0, 1000, "")))
+
+	plan := NewPlan("v2", 20, 0, "info")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	stored, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	// a new plan replaces the scheduled one
+	plan = NewPlan("v3", 0, 2000, "info")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	stored, _ = keeper.GetUpgradePlan(ctx)
+	require.Equal(t, plan, stored)
+
+	keeper.ClearUpgradePlan(ctx)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestApplyUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput()
+
+	var called int
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context) { called++ })
+	require.True(t, keeper.HasUpgradeHandler("v2"))
+	require.False(t, keeper.HasUpgradeHandler("v3"))
+
+	plan := NewPlan("v2", 20, 0, "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	ctx = ctx.WithBlockHeight(20)
+	keeper.ApplyUpgrade(ctx, plan)
+	require.Equal(t, 1, called)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	require.Equal(t, int64(20), keeper.GetDoneHeight(ctx, "v2"))
+	require.Equal(t, []AppliedUpgrade{{Name: "v2", Height: 20}}, keeper.GetAppliedUpgrades(ctx))
+
+	// applied upgrades can't be scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 30, 0, "")))
+
+	// upgrades without a handler can't be applied
+	require.Panics(t, func() { keeper.ApplyUpgrade(ctx, NewPlan("v3", 20, 0, "")) })
+}
@@ -0,0 +1,81 @@
+package upgrade
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name of the upgrade module, used for its query route
+const ModuleName = "upgrade"
+
+// AppModule implements module.AppModule for the upgrade module. Upgrades
+// are scheduled by governance, the module has no messages.
+type AppModule struct {
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// nolint
+func (AppModule) Name() string                             { return ModuleName }
+func (AppModule) Route() string                            { return "" }
+func (AppModule) NewHandler() sdk.Handler                  { return nil }
+func (AppModule) QuerierRoute() string                     { return ModuleName }
+func (am AppModule) NewQuerierHandler() sdk.Querier        { return NewQuerier(am.keeper) }
+func (AppModule) RegisterInvariants(_ sdk.InvariantRouter) {}
+
+// DefaultGenesis returns the default upgrade genesis state
+func (am AppModule) DefaultGenesis() json.RawMessage {
+	return am.mustMarshalGenesis(DefaultGenesisState())
+}
+
+// ValidateGenesis validates the upgrade genesis state
+func (am AppModule) ValidateGenesis(data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	return ValidateGenesis(genesisState)
+}
+
+// InitGenesis initializes the scheduled plan and the applied upgrades from
+// the genesis state
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
+	var genesisState GenesisState
+	if err := am.keeper.cdc.UnmarshalJSON(data, &genesisState); err != nil {
+		return err
+	}
+	InitGenesis(ctx, am.keeper, genesisState)
+	return nil
+}
+
+// ExportGenesis exports the scheduled plan and the applied upgrades as the
+// upgrade genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return am.mustMarshalGenesis(WriteGenesis(ctx, am.keeper))
+}
+
+// BeginBlock performs or halts at the scheduled upgrade, it should run
+// before the BeginBlock of the other modules
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return BeginBlocker(ctx, am.keeper)
+}
+
+// EndBlock is a no-op for the upgrade module
+func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.Validator, sdk.Tags) {
+	return nil, nil
+}
+
+func (am AppModule) mustMarshalGenesis(genesisState GenesisState) json.RawMessage {
+	bz, err := am.keeper.cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,62 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Plan specifies an upgrade of the chain software. The upgrade is
+// performed at a block height, or at the first block past a time if no
+// height is set.
+type Plan struct {
+	Name   string `json:"name"`   // name of the upgrade, used to look up its handler
+	Height int64  `json:"height"` // height at which the upgrade is performed, if not zero
+	Time   int64  `json:"time"`   // unix time after which the upgrade is performed, if Height is zero
+	Info   string `json:"info"`   // any information about the upgrade, e.g. where to get the new binary
+}
+
+func NewPlan(name string, height int64, time int64, info string) Plan {
+	return Plan{
+		Name:   name,
+		Height: height,
+		Time:   time,
+		Info:   info,
+	}
+}
+
+// ValidateBasic checks the plan is named and is due at either a height or
+// a time
+func (p Plan) ValidateBasic() sdk.Error {
+	if len(p.Name) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "upgrade plan has no name")
+	}
+	if p.Height < 0 || p.Time < 0 {
+		return ErrInvalidPlan(DefaultCodespace, fmt.Sprintf("negative height or time in upgrade plan %v", p))
+	}
+	if (p.Height == 0) == (p.Time == 0) {
+		return ErrInvalidPlan(DefaultCodespace, fmt.Sprintf("upgrade plan %v must have either a height or a time", p))
+	}
+	return nil
+}
+
+// ShouldExecute returns true if the upgrade is due in the block of the
+// context
+func (p Plan) ShouldExecute(ctx sdk.Context) bool {
+	if p.Height > 0 {
+		return ctx.BlockHeight() >= p.Height
+	}
+	return ctx.BlockHeader().Time >= p.Time
+}
+
+// DueAt describes when the upgrade is due
+func (p Plan) DueAt() string {
+	if p.Height > 0 {
+		return fmt.Sprintf("at height %d", p.Height)
+	}
+	return fmt.Sprintf("at time %d", p.Time)
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf("Upgrade %s %s: %s", p.Name, p.DueAt(), p.Info)
+}
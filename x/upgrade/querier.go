@@ -0,0 +1,49 @@
+package upgrade
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the upgrade Querier
+const (
+	QueryCurrent = "current"
+	QueryApplied = "applied"
+)
+
+// NewQuerier returns the querier of the upgrade module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no upgrade query endpoint specified")
+		}
+		switch path[0] {
+		case QueryCurrent:
+			return queryCurrent(ctx, k)
+		case QueryApplied:
+			return queryApplied(ctx, k)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown upgrade query endpoint %s", path[0]))
+		}
+	}
+}
+
+// returns the scheduled plan, nothing if no upgrade is scheduled
+func queryCurrent(ctx sdk.Context, k Keeper) (res []byte, err sdk.Error) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return nil, nil
+	}
+	return sdk.MarshalQueryResult(k.cdc, plan)
+}
+
+func queryApplied(ctx sdk.Context, k Keeper) (res []byte, err sdk.Error) {
+	upgrades := k.GetAppliedUpgrades(ctx)
+	if upgrades == nil {
+		upgrades = []AppliedUpgrade{}
+	}
+	return sdk.MarshalQueryResult(k.cdc, upgrades)
+}